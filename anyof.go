@@ -0,0 +1,36 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// AnyOf returns a Checker that applies checkers in order and passes as soon as
+// one of them passes, such as to accept code that satisfies either an old or a
+// new style while migrating between them. It is the dual of Group, which passes
+// only if all of its checkers pass. If every checker fails, the errors of all of
+// them are returned, prefixed as described in Group.Check.
+//
+// An AnyOf with no checkers always fails, since none of its checkers passed.
+func AnyOf(checkers ...Checker) Checker {
+	return anyOf(checkers)
+}
+
+type anyOf []Checker
+
+// Check runs each checker in a until one passes.
+func (a anyOf) Check(pkgs ...string) error {
+	if len(a) == 0 {
+		return fmt.Errorf("AnyOf: no checkers to run")
+	}
+	var errs []string
+	for _, checker := range a {
+		found := prefixErrors(prefixName(checker), checker.Check(pkgs...))
+		if len(found) == 0 {
+			return nil
+		}
+		errs = append(errs, found...)
+	}
+	return checkers.Error(errs...)
+}
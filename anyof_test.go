@@ -0,0 +1,36 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestAnyOf(t *testing.T) {
+	var ran []string
+	check := func(name string, errs ...string) lint.Checker {
+		return checkFn(func(pkgs ...string) error {
+			ran = append(ran, name)
+			return checkers.Error(errs...)
+		})
+	}
+
+	err := lint.AnyOf(check("a", "one"), check("b"), check("c", "two")).Check()
+	assert(t, err == nil && fmt.Sprint(ran) == "[a b]", fmt.Sprintf("%v %v", err, ran))
+
+	ran = nil
+	err = lint.AnyOf(check("a", "one"), check("b", "two", "three")).Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: one\nlint_test.checkFn: two\nlint_test.checkFn: three", fmt.Sprintf("%v", err))
+	assert(t, fmt.Sprint(ran) == "[a b]", fmt.Sprint(ran))
+	_, isList := err.(interface{ Errors() []string })
+	assert(t, isList, fmt.Sprintf("%T", err))
+
+	// Nested in a Group the errors are not prefixed again.
+	err = lint.Group{lint.AnyOf(check("a", "one"))}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: one", fmt.Sprintf("%v", err))
+
+	err = lint.AnyOf().Check()
+	assert(t, err != nil && err.Error() == "AnyOf: no checkers to run", fmt.Sprintf("%v", err))
+}
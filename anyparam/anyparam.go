@@ -0,0 +1,182 @@
+// Package anyparam provides lint integration for finding the empty interface in
+// exported signatures.
+package anyparam
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported functions and methods of
+// exported types, in .go files other than test files, whose parameters or
+// results use the empty interface, written as any or interface{}. A use is the
+// type of a parameter or result, or the element type of a pointer, slice,
+// array, map or channel it holds. Types are resolved using go/types, so
+// interfaces with methods are allowed while named empty interfaces are not.
+// Type parameter constraints are not checked.
+type Checker struct {
+	// Allow holds path.Match patterns for the names of functions that may use
+	// the empty interface, such as "Marshal*". Methods match both their name
+	// and their name qualified by the receiver type, as in "T.Marshal*".
+	Allow []string
+	// AllowPrintf allows a final ...any parameter following a string parameter,
+	// as in Printf(format string, args ...any).
+	AllowPrintf bool
+}
+
+// Check reports each exported function in pkgs that uses the empty interface in
+// its signature as
+//
+//	file.go:line:col: exported function Marshal uses any in its signature
+//
+// Methods are reported as "exported method T.Marshal".
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each exported function in pkgs that
+// uses the empty interface in its signature.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports exported functions that use the empty interface in their
+// signature in the packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || !fn.Name.IsExported() {
+					continue
+				}
+				kind, name := "function", fn.Name.Name
+				names := []string{name}
+				if fn.Recv != nil {
+					recv := recvName(fn)
+					if !ast.IsExported(recv) {
+						continue
+					}
+					kind, name = "method", recv+"."+name
+					names = append(names, name)
+				}
+				if c.allowed(names) {
+					continue
+				}
+				obj := info.Defs[fn.Name]
+				if obj == nil {
+					continue
+				}
+				if c.usesAny(obj.Type().(*types.Signature)) {
+					diags = append(diags, s.Diagnostic(fn.Name.Pos(), "exported %s %s uses any in its signature", kind, name))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether one of names matches one of Allow.
+func (c Checker) allowed(names []string) bool {
+	for _, pattern := range c.Allow {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usesAny reports whether a parameter or result of sig uses the empty
+// interface.
+func (c Checker) usesAny(sig *types.Signature) bool {
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		if c.AllowPrintf && sig.Variadic() && i == params.Len()-1 && i > 0 && isString(params.At(i-1).Type()) {
+			if slice, ok := params.At(i).Type().(*types.Slice); ok && isEmpty(slice.Elem()) {
+				continue
+			}
+		}
+		if hasEmpty(params.At(i).Type()) {
+			return true
+		}
+	}
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		if hasEmpty(results.At(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasEmpty reports whether typ is the empty interface or a pointer, slice,
+// array, map or channel holding it.
+func hasEmpty(typ types.Type) bool {
+	switch t := typ.(type) {
+	case *types.Pointer:
+		return hasEmpty(t.Elem())
+	case *types.Slice:
+		return hasEmpty(t.Elem())
+	case *types.Array:
+		return hasEmpty(t.Elem())
+	case *types.Map:
+		return hasEmpty(t.Key()) || hasEmpty(t.Elem())
+	case *types.Chan:
+		return hasEmpty(t.Elem())
+	}
+	return isEmpty(typ)
+}
+
+// isEmpty reports whether typ is an interface without methods. Type parameters
+// are not.
+func isEmpty(typ types.Type) bool {
+	if _, ok := typ.(*types.TypeParam); ok {
+		return false
+	}
+	iface, ok := typ.Underlying().(*types.Interface)
+	return ok && iface.Empty()
+}
+
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
@@ -0,0 +1,65 @@
+package anyparam_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/anyparam"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package anyparamtest
+
+import "fmt"
+
+type Value interface{}
+
+type Codec struct{}
+
+type codec struct{}
+
+func Marshal(v any) ([]byte, error) { return nil, nil }
+
+func Decode(data []byte) map[string]interface{} { return nil }
+
+func Logf(format string, args ...any) {}
+
+func Print(args ...any) {}
+
+func Stringer(s fmt.Stringer) {}
+
+func Named(v Value) {}
+
+func Generic[T any](v T) T { return v }
+
+func (Codec) Encode(v *any) {}
+
+func (codec) Encode(v any) {}
+
+func internal(v any) {}
+`
+
+func TestAnyParam(t *testing.T) {
+	testutil.Test(t, "anyparamtest", []testutil.StaticCheckTest{
+		{
+			Checker: anyparam.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:6: exported function Marshal uses any in its signature\n` +
+				`[^\n]*file\.go:13:6: exported function Decode uses any in its signature\n` +
+				`[^\n]*file\.go:15:6: exported function Logf uses any in its signature\n` +
+				`[^\n]*file\.go:17:6: exported function Print uses any in its signature\n` +
+				`[^\n]*file\.go:21:6: exported function Named uses any in its signature\n` +
+				`[^\n]*file\.go:25:14: exported method Codec\.Encode uses any in its signature$`),
+		},
+		{
+			Checker: anyparam.Checker{Allow: []string{"Marshal", "Codec.*", "N*"}, AllowPrintf: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:13:6: exported function Decode uses any in its signature\n` +
+				`[^\n]*file\.go:17:6: exported function Print uses any in its signature$`),
+		},
+		{
+			Checker:  anyparam.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+	})
+}
@@ -0,0 +1,119 @@
+// Package appendcheck provides lint integration for finding misused calls to
+// the append builtin.
+package appendcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports calls to append in .go files,
+// including test files, whose result is lost or stored in a different slice
+// than the one appended to. Calls are resolved using go/types, so functions
+// named append are not reported. Each check is enabled by its field and a zero
+// Checker runs every check.
+type Checker struct {
+	// Discarded reports calls to append whose result is assigned to the blank
+	// identifier.
+	Discarded bool
+	// Mismatch reports assignments of the form
+	//
+	//	x = append(y, v)
+	//
+	// where x and y are different variables or fields. Only assignments with =
+	// are checked, since declaring a new slice with := from an existing one is
+	// common, and so are calls whose first argument is not a variable or field,
+	// such as append([]byte(nil), b...).
+	Mismatch bool
+}
+
+// all returns c with every check enabled if none is.
+func (c Checker) all() Checker {
+	if !c.Discarded && !c.Mismatch {
+		return Checker{Discarded: true, Mismatch: true}
+	}
+	return c
+}
+
+// Check reports the calls in pkgs as
+//
+//	file.go:line:col: result of append is not assigned
+//	file.go:line:col: append assigns to a different slice than its argument
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each misused call to append in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports misused calls to append in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	c = c.all()
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					assign, ok := n.(*ast.AssignStmt)
+					if !ok || len(assign.Lhs) != len(assign.Rhs) {
+						return true
+					}
+					for i, rhs := range assign.Rhs {
+						call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+						if !ok || len(call.Args) == 0 || !isAppend(info, call) {
+							continue
+						}
+						lhs := ast.Unparen(assign.Lhs[i])
+						if id, ok := lhs.(*ast.Ident); ok && id.Name == "_" {
+							if c.Discarded {
+								diags = append(diags, s.Diagnostic(call.Pos(), "result of append is not assigned"))
+							}
+							continue
+						}
+						if c.Mismatch && assign.Tok == token.ASSIGN && isVariable(info, call.Args[0]) && types.ExprString(lhs) != types.ExprString(ast.Unparen(call.Args[0])) {
+							diags = append(diags, s.Diagnostic(call.Pos(), "append assigns to a different slice than its argument"))
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// isAppend reports whether call calls the append builtin.
+func isAppend(info *types.Info, call *ast.CallExpr) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == "append"
+}
+
+// isVariable reports whether e is a variable or a field.
+func isVariable(info *types.Info, e ast.Expr) bool {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	_, ok := info.Uses[id].(*types.Var)
+	return ok
+}
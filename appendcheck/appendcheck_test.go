@@ -0,0 +1,61 @@
+package appendcheck_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/appendcheck"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package appendchecktest
+
+type T struct{ items, other []int }
+
+func Append(x, y []int, t *T) []int {
+	x = append(x, 1)
+	x = append(y, 2)
+	_ = append(x, 3)
+	t.items = append(t.items, 4)
+	t.items = append(t.other, 5)
+	z := append(x, 6)
+	x = append([]int(nil), z...)
+	x, y = append(x, 7), append(x, 8)
+	return x
+}
+
+func Shadowed(x []int) []int {
+	append := func(s []int, v int) []int { return s }
+	_ = append(x, 1)
+	return append(x, 2)
+}
+`
+
+func TestAppendCheck(t *testing.T) {
+	testutil.Test(t, "appendchecktest", []testutil.StaticCheckTest{
+		{
+			Checker: appendcheck.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:7:6: append assigns to a different slice than its argument\n` +
+				`[^\n]*file\.go:8:6: result of append is not assigned\n` +
+				`[^\n]*file\.go:10:12: append assigns to a different slice than its argument\n` +
+				`[^\n]*file\.go:13:23: append assigns to a different slice than its argument$`),
+		},
+		{
+			Checker:  appendcheck.Checker{Discarded: true},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:6: result of append is not assigned$`),
+		},
+		{
+			Checker: appendcheck.Checker{Mismatch: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:7:6: append assigns to a different slice than its argument\n` +
+				`[^\n]*file\.go:10:12: append assigns to a different slice than its argument\n` +
+				`[^\n]*file\.go:13:23: append assigns to a different slice than its argument$`),
+		},
+		{
+			Checker:  appendcheck.Checker{Discarded: true},
+			Content:  []byte("package appendchecktest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Baseline returns a Checker that runs c and drops the errors recorded in the
+// baseline file at path, so that only new findings are reported. If path does
+// not exist, every error from the first run is recorded in it and Check returns
+// nil. Use UpdateBaseline to record the errors again after fixing some of them.
+//
+// Errors are recorded as fingerprints of their file and message, ignoring line
+// and column numbers, so a finding that moves to another line is still dropped.
+// Each recorded fingerprint drops a single error, so a new finding identical to
+// a recorded one in the same file is still reported. Files are recorded relative
+// to the current directory where possible. Errors that do not implement the
+// errors interface described in Skip are returned unmodified and never recorded.
+func Baseline(path string, c Checker) Checker {
+	return baseline{checker: c, path: path}
+}
+
+type baseline struct {
+	checker Checker
+	path    string
+}
+
+func (b baseline) unwrap() interface{} { return b.checker }
+
+// Name returns the name of the wrapped checker.
+func (b baseline) Name() string { return checkerName(b.checker) }
+
+type baselineFile struct {
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// Check runs the wrapped checker and drops errors recorded in the baseline,
+// creating the baseline if it does not exist.
+func (b baseline) Check(pkgs ...string) error {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return UpdateBaseline(b.path, b.checker, pkgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read baseline: %v", err)
+	}
+	var file baselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid baseline %s: %v", b.path, err)
+	}
+	err = b.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok {
+		return err
+	}
+	known := map[string]int{}
+	for _, fp := range file.Fingerprints {
+		known[fp]++
+	}
+	var errs []string
+	for _, e := range list.Errors() {
+		fp := fingerprint(e)
+		if known[fp] > 0 {
+			known[fp]--
+			continue
+		}
+		errs = append(errs, e)
+	}
+	return checkers.Error(errs...)
+}
+
+// UpdateBaseline runs c for pkgs and records every error in the baseline file
+// at path, as described in Baseline, replacing its previous contents. It
+// returns an error if c fails with an error that cannot be recorded.
+func UpdateBaseline(path string, c Checker, pkgs ...string) error {
+	file := baselineFile{Fingerprints: []string{}}
+	switch e := c.Check(pkgs...).(type) {
+	case nil:
+	case errors:
+		for _, str := range e.Errors() {
+			file.Fingerprints = append(file.Fingerprints, fingerprint(str))
+		}
+	default:
+		return e
+	}
+	// Sorted so that the file only changes when the findings do.
+	sort.Strings(file.Fingerprints)
+	data, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to write baseline: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %v", err)
+	}
+	return nil
+}
+
+// positionsRE matches line and column numbers following a file name, such as
+// in "duplicate of file.go:12".
+var positionsRE = regexp.MustCompile(`\.go(:\d+)+`)
+
+// fingerprint returns a hash of str that does not depend on line numbers.
+func fingerprint(str string) string {
+	d := ParseDiagnostic(str)
+	file := d.File
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			file = filepath.ToSlash(rel)
+		}
+	}
+	msg := positionsRE.ReplaceAllString(d.Message, ".go")
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%s", d.Checker, d.Severity, file, msg)))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,50 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint", "baseline.json")
+	var errs []string
+	c := checkFn(func(pkgs ...string) error { return checkers.Error(errs...) })
+	b := lint.Baseline(path, c)
+
+	errs = []string{"a.go:1: legacy", "a.go:2:3: legacy", "b.go:3: duplicate of a.go:10 (20 tokens)"}
+	err := b.Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	data, err := os.ReadFile(path)
+	assert(t, err == nil && strings.Count(string(data), `"`) == 8, fmt.Sprintf("%v %s", err, data))
+
+	// Findings that moved are still dropped, new ones are reported.
+	errs = []string{"a.go:11: legacy", "a.go:12: legacy", "a.go:13: legacy", "b.go:4: duplicate of a.go:20 (20 tokens)", "b.go:5: new"}
+	err = b.Check()
+	assert(t, err != nil && err.Error() == "a.go:13: legacy\nb.go:5: new", fmt.Sprintf("%v", err))
+	_, ok := err.(interface{ Errors() []string })
+	assert(t, ok, "baseline errors must implement the errors interface")
+
+	err = lint.UpdateBaseline(path, c)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	err = b.Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	errs = nil
+	err = lint.UpdateBaseline(path, c)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	errs = []string{"a.go:1: legacy"}
+	err = b.Check()
+	assert(t, err != nil && err.Error() == "a.go:1: legacy", fmt.Sprintf("%v", err))
+
+	failed := checkFn(func(pkgs ...string) error { return fmt.Errorf("tool failed") })
+	err = lint.UpdateBaseline(path, failed)
+	assert(t, err != nil && err.Error() == "tool failed", fmt.Sprintf("%v", err))
+	err = lint.Baseline(filepath.Join(t.TempDir(), "new.json"), failed).Check()
+	assert(t, err != nil && err.Error() == "tool failed", fmt.Sprintf("%v", err))
+}
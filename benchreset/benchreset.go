@@ -0,0 +1,162 @@
+// Package benchreset provides lint integration for finding benchmarks that
+// measure their setup.
+package benchreset
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports benchmarks in _test.go files that
+// run statements before their b.N loop without resetting the timer, so the time
+// taken by the setup is included in the result. A setup statement is one that
+// comes after the last call to b.ResetTimer before the loop and is not between
+// calls to b.StopTimer and b.StartTimer. Other calls to methods of b, such as
+// b.ReportAllocs, are not setup.
+//
+// The loop is the first statement of the form
+//
+//	for i := 0; i < b.N; i++ {
+//	for range b.N {
+//
+// in the benchmark, and in the functions passed to b.Run. Benchmarks without
+// such a loop, including those using b.Loop, which excludes setup itself, are
+// not reported.
+type Checker struct {
+	// MaxSetup is the number of setup statements allowed before the loop, so
+	// that trivial setups are not reported. If it is 0 any setup is reported.
+	MaxSetup int
+}
+
+// Check reports each benchmark in pkgs whose setup is measured as
+//
+//	file_test.go:line:col: benchmark has setup before the loop but no b.ResetTimer()
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each benchmark in pkgs whose setup is
+// measured.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				for _, decl := range f.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Benchmark") {
+						continue
+					}
+					ast.Inspect(fn, func(n ast.Node) bool {
+						var typ *ast.FuncType
+						var body *ast.BlockStmt
+						switch fn := n.(type) {
+						case *ast.FuncDecl:
+							typ, body = fn.Type, fn.Body
+						case *ast.FuncLit:
+							typ, body = fn.Type, fn.Body
+						default:
+							return true
+						}
+						if b := benchmarkParam(typ); b != "" {
+							if loop := c.measuredSetup(b, body); loop != nil {
+								diags = append(diags, s.Diagnostic(loop.Pos(), "benchmark has setup before the loop but no %s.ResetTimer()", b))
+							}
+						}
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// measuredSetup returns the b.N loop of body if more than MaxSetup statements
+// before it are measured, and nil otherwise.
+func (c Checker) measuredSetup(b string, body *ast.BlockStmt) ast.Stmt {
+	setup, stopped := 0, false
+	for _, stmt := range body.List {
+		if isLoop(b, stmt) {
+			if setup > c.MaxSetup {
+				return stmt
+			}
+			return nil
+		}
+		switch method(b, stmt) {
+		case "ResetTimer":
+			setup = 0
+		case "StopTimer":
+			stopped = true
+		case "StartTimer":
+			stopped = false
+		case "":
+			if !stopped {
+				setup++
+			}
+		}
+	}
+	return nil
+}
+
+// method returns the name of the method of b called by stmt, or "" if stmt is
+// not such a call.
+func method(b string, stmt ast.Stmt) string {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return ""
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, b) {
+		return sel.Sel.Name
+	}
+	return ""
+}
+
+// isLoop reports whether stmt is a loop over b.N.
+func isLoop(b string, stmt ast.Stmt) bool {
+	switch loop := stmt.(type) {
+	case *ast.ForStmt:
+		cond, ok := loop.Cond.(*ast.BinaryExpr)
+		return ok && (cond.Op == token.LSS || cond.Op == token.LEQ) && isN(b, cond.Y)
+	case *ast.RangeStmt:
+		return isN(b, loop.X)
+	}
+	return false
+}
+
+// isN reports whether e is b.N.
+func isN(b string, e ast.Expr) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "N" && isIdent(sel.X, b)
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// benchmarkParam returns the name of the only parameter of typ if it is a
+// *testing.B, assuming that the testing package is imported as testing, and ""
+// otherwise.
+func benchmarkParam(typ *ast.FuncType) string {
+	params := typ.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return ""
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || !isIdent(sel.X, "testing") || sel.Sel.Name != "B" {
+		return ""
+	}
+	return params[0].Names[0].Name
+}
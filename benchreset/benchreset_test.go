@@ -0,0 +1,87 @@
+package benchreset_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/benchreset"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package benchresettest
+
+import (
+	"strings"
+	"testing"
+)
+
+func BenchmarkSetup(b *testing.B) {
+	data := strings.Repeat("x", 1<<20)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = strings.ToUpper(data)
+	}
+}
+
+func BenchmarkReset(b *testing.B) {
+	data := strings.Repeat("x", 1<<20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = strings.ToUpper(data)
+	}
+}
+
+func BenchmarkStopped(b *testing.B) {
+	b.StopTimer()
+	data := strings.Repeat("x", 1<<20)
+	upper := ""
+	b.StartTimer()
+	for range b.N {
+		upper = strings.ToUpper(data)
+	}
+	_ = upper
+}
+
+func BenchmarkRun(b *testing.B) {
+	b.Run("sub", func(sb *testing.B) {
+		data := strings.Repeat("x", 1<<20)
+		upper := ""
+		for range sb.N {
+			upper = strings.ToUpper(data)
+		}
+		_ = upper
+	})
+}
+
+func BenchmarkLoop(b *testing.B) {
+	data := strings.Repeat("x", 1<<20)
+	for b.Loop() {
+		_ = strings.ToUpper(data)
+	}
+}
+`
+
+func TestBenchReset(t *testing.T) {
+	checkers.Unload("benchresettest")
+	tmp, err := testutil.NewGopath("benchresettest", []testutil.SourceFile{
+		{Content: []byte("package benchresettest\n"), Dest: filepath.Join("benchresettest", "file.go")},
+		{Content: []byte(src), Dest: filepath.Join("benchresettest", "file_test.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	err = benchreset.Checker{}.Check("benchresettest")
+	if err := testutil.MatchesRegexp(`^[^\n]*file_test\.go:11:2: benchmark has setup before the loop but no b\.ResetTimer\(\)\n` +
+		`[^\n]*file_test\.go:39:3: benchmark has setup before the loop but no sb\.ResetTimer\(\)$`)(err); err != nil {
+		t.Error(err)
+	}
+	err = benchreset.Checker{MaxSetup: 1}.Check("benchresettest")
+	if err := testutil.MatchesRegexp(`^[^\n]*file_test\.go:39:3: benchmark has setup before the loop but no sb\.ResetTimer\(\)$`)(err); err != nil {
+		t.Error(err)
+	}
+	if err := (benchreset.Checker{MaxSetup: 2}).Check("benchresettest"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,109 @@
+package lint
+
+import "time"
+
+// Builder composes a Checker from checkers and the wrappers of this package,
+// so that
+//
+//	lint.New().Add(govet.Check{}, golint.Check{}).Exclude("**/*.pb.go").Timeout(time.Minute).Build()
+//
+// is the same as
+//
+//	lint.Timeout(time.Minute, lint.FilterPaths(lint.Group{govet.Check{}, golint.Check{}}, nil, []string{"**/*.pb.go"}))
+//
+// Each wrapping method wraps everything added so far, so wrappers apply in the
+// order they are called and the last one is the outermost. Checkers added after
+// a wrapper are grouped with the wrapped checker rather than wrapped by it. A
+// Builder is a value, so a partial Builder can be extended in several ways. The
+// zero Builder, returned by New, builds an empty Group.
+type Builder struct {
+	checker Checker
+}
+
+// New returns an empty Builder.
+func New() Builder {
+	return Builder{}
+}
+
+// Add returns a Builder that also runs checkers, after those already added, as
+// a Group.
+func (b Builder) Add(checkers ...Checker) Builder {
+	switch g := b.checker.(type) {
+	case nil:
+		return Builder{checker: Group(append([]Checker{}, checkers...))}
+	case Group:
+		return Builder{checker: g.With(checkers...)}
+	}
+	return Builder{checker: append(Group{b.checker}, checkers...)}
+}
+
+// wrap returns a Builder whose checker is fn applied to the checker built so
+// far.
+func (b Builder) wrap(fn func(c Checker) Checker) Builder {
+	return Builder{checker: fn(b.Build())}
+}
+
+// Timeout wraps the checkers added so far as described in Timeout.
+func (b Builder) Timeout(d time.Duration) Builder {
+	return b.wrap(func(c Checker) Checker { return Timeout(d, c) })
+}
+
+// Cache wraps the checkers added so far as described in Cache.
+func (b Builder) Cache(dir string) Builder {
+	return b.wrap(func(c Checker) Checker { return Cache(dir, c) })
+}
+
+// Include wraps the checkers added so far to keep only errors for files
+// matching one of patterns, as described in FilterPaths.
+func (b Builder) Include(patterns ...string) Builder {
+	return b.wrap(func(c Checker) Checker { return FilterPaths(c, patterns, nil) })
+}
+
+// Exclude wraps the checkers added so far to drop errors for files matching one
+// of patterns, as described in FilterPaths.
+func (b Builder) Exclude(patterns ...string) Builder {
+	return b.wrap(func(c Checker) Checker { return FilterPaths(c, nil, patterns) })
+}
+
+// Skip wraps the checkers added so far as described in SkipChecker.
+func (b Builder) Skip(skippers ...Skipper) Builder {
+	return b.wrap(func(c Checker) Checker { return SkipChecker(c, skippers...) })
+}
+
+// NoLint wraps the checkers added so far as described in NoLint.
+func (b Builder) NoLint() Builder {
+	return b.wrap(func(c Checker) Checker { return NoLint(c) })
+}
+
+// Retry wraps the checkers added so far as described in Retry.
+func (b Builder) Retry(attempts int) Builder {
+	return b.wrap(func(c Checker) Checker { return Retry(attempts, c) })
+}
+
+// Limit wraps the checkers added so far as described in Limit.
+func (b Builder) Limit(n int) Builder {
+	return b.wrap(func(c Checker) Checker { return Limit(n, c) })
+}
+
+// Sorted wraps the checkers added so far as described in Sorted.
+func (b Builder) Sorted() Builder {
+	return b.wrap(Sorted)
+}
+
+// Dedup wraps the checkers added so far as described in Dedup.
+func (b Builder) Dedup() Builder {
+	return b.wrap(Dedup)
+}
+
+// Severity wraps the checkers added so far as described in WithSeverity.
+func (b Builder) Severity(s Severity) Builder {
+	return b.wrap(func(c Checker) Checker { return WithSeverity(s, c) })
+}
+
+// Build returns the composed Checker.
+func (b Builder) Build() Checker {
+	if b.checker == nil {
+		return Group{}
+	}
+	return b.checker
+}
@@ -0,0 +1,47 @@
+package lint_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+type fixedCheck []string
+
+func (f fixedCheck) Check(pkgs ...string) error { return checkers.Error(f...) }
+
+func TestBuilder(t *testing.T) {
+	a := fixedCheck{"a.go:1: one", "b.pb.go:2: two", "c.go:3: three"}
+	b := fixedCheck{"d.go:4: four"}
+
+	built := lint.New().Add(a).Exclude("*.pb.go").Timeout(time.Minute).Build()
+	expected := lint.Timeout(time.Minute, lint.FilterPaths(lint.Group{a}, nil, []string{"*.pb.go"}))
+	assert(t, reflect.DeepEqual(built, expected), fmt.Sprintf("%#v", built))
+
+	// Checkers added after a wrapper are grouped with it, not wrapped by it.
+	built = lint.New().Add(a).Limit(1).Add(b).Build()
+	expected = lint.Group{lint.Limit(1, lint.Group{a}), b}
+	assert(t, reflect.DeepEqual(built, expected), fmt.Sprintf("%#v", built))
+
+	// Checkers added before any wrapper form a single Group.
+	built = lint.New().Add(a).Add(b).Build()
+	assert(t, reflect.DeepEqual(built, lint.Group{a, b}), fmt.Sprintf("%#v", built))
+
+	// The last wrapper is the outermost.
+	base := lint.New().Add(a)
+	err := base.Exclude("*.pb.go").Limit(1).Build().Check()
+	assert(t, fmt.Sprint(err.(interface{ Errors() []string }).Errors()) == "[lint_test.fixedCheck: a.go:1: one ... and 1 more]", fmt.Sprintf("%v", err))
+	err = base.Limit(1).Exclude("*.pb.go").Build().Check()
+	assert(t, fmt.Sprint(err.(interface{ Errors() []string }).Errors()) == "[lint_test.fixedCheck: a.go:1: one ... and 2 more]", fmt.Sprintf("%v", err))
+
+	// Extending a Builder does not change it.
+	err = base.Build().Check()
+	assert(t, len(err.(interface{ Errors() []string }).Errors()) == 3, fmt.Sprintf("%v", err))
+
+	built = lint.New().Build()
+	assert(t, reflect.DeepEqual(built, lint.Group{}) && built.Check() == nil, fmt.Sprintf("%#v", built))
+}
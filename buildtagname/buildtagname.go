@@ -0,0 +1,176 @@
+// Package buildtagname provides lint integration for finding unknown tags in
+// build constraints.
+package buildtagname
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// knownOS holds the values of GOOS known to the go command.
+var knownOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios",
+	"js", "linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1",
+	"windows", "zos",
+}
+
+// knownArch holds the values of GOARCH known to the go command.
+var knownArch = []string{
+	"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64",
+	"mips", "mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc",
+	"ppc64", "ppc64le", "riscv", "riscv64", "s390", "s390x", "sparc", "sparc64",
+	"wasm",
+}
+
+// knownOther holds the other tags set by the go command, along with ignore,
+// which is conventionally used to exclude a file from every build.
+var knownOther = []string{
+	"asan", "boringcrypto", "cgo", "gc", "gccgo", "ignore", "msan", "race", "unix",
+}
+
+// goVersion matches the release tags, such as go1.21.
+var goVersion = regexp.MustCompile(`^go1\.[0-9]+$`)
+
+// builtin holds knownOS, knownArch and knownOther.
+var builtin = set(knownOS, knownArch, knownOther)
+
+// arches holds knownArch.
+var arches = set(knownArch)
+
+// set returns a set of the tags in lists.
+func set(lists ...[]string) map[string]bool {
+	tags := map[string]bool{}
+	for _, list := range lists {
+		for _, tag := range list {
+			tags[tag] = true
+		}
+	}
+	return tags
+}
+
+// Checker implements lint.Checker and reports tags in the //go:build lines of
+// .go files, including test files, that are neither set by the go command nor
+// in KnownTags. A misspelled tag is never satisfied, so it silently excludes
+// the file from builds where it is required, or includes it where it is negated.
+//
+// The tags set by the go command are the values of GOOS and GOARCH, the release
+// tags such as go1.21, cgo, gc, gccgo, unix, race, msan, asan and boringcrypto,
+// architecture feature tags such as amd64.v2 and experiment tags such as
+// goexperiment.rangefunc. ignore, used to exclude files from every build, is
+// also known. Every tag of the expression is checked, including negated tags.
+type Checker struct {
+	// KnownTags holds the custom tags used by the module, such as "integration".
+	KnownTags []string
+}
+
+// Check reports each unknown tag in the build constraints of pkgs as
+//
+//	file.go:1: unknown build tag "linx"
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each unknown tag in the build
+// constraints of pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports unknown tags in the build constraints of files as described
+// in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.checkSource(file, nil)...)
+	}
+	return diags
+}
+
+// CheckSource reports unknown tags in the build constraints of src, the contents
+// of filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.checkSource(filename, src), nil)
+}
+
+// checkSource checks src, or the contents of file if src is nil. src is passed
+// to parser.ParseFile.
+func (c Checker) checkSource(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	custom := make(map[string]bool, len(c.KnownTags))
+	for _, tag := range c.KnownTags {
+		custom[tag] = true
+	}
+	var diags []checkers.Diagnostic
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			if comment.Pos() > f.Package || !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				// Reported by buildtags.
+				continue
+			}
+			for _, tag := range exprTags(expr, nil) {
+				if custom[tag] || known(tag) {
+					continue
+				}
+				diags = append(diags, checkers.Diagnostic{
+					File:    file,
+					Line:    fset.Position(comment.Pos()).Line,
+					Message: fmt.Sprintf("unknown build tag %q", tag),
+				})
+			}
+		}
+	}
+	return diags
+}
+
+// known reports whether tag is set by the go command.
+func known(tag string) bool {
+	if builtin[tag] || goVersion.MatchString(tag) || strings.HasPrefix(tag, "goexperiment.") {
+		return true
+	}
+	// Architecture feature tags, such as amd64.v2 and arm.7.
+	arch, _, ok := strings.Cut(tag, ".")
+	return ok && arches[arch]
+}
+
+// exprTags appends the tags used in expr to tags, in the order they appear and
+// without duplicates, and returns the result.
+func exprTags(expr constraint.Expr, tags []string) []string {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		for _, tag := range tags {
+			if tag == e.Tag {
+				return tags
+			}
+		}
+		return append(tags, e.Tag)
+	case *constraint.NotExpr:
+		return exprTags(e.X, tags)
+	case *constraint.AndExpr:
+		return exprTags(e.Y, exprTags(e.X, tags))
+	case *constraint.OrExpr:
+		return exprTags(e.Y, exprTags(e.X, tags))
+	}
+	return tags
+}
@@ -0,0 +1,50 @@
+package buildtagname_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/buildtagname"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestBuildTagName(t *testing.T) {
+	testutil.Test(t, "buildtagnametest", []testutil.StaticCheckTest{
+		{
+			Checker:  buildtagname.Checker{},
+			Content:  []byte("package buildtagnametest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtagname.Checker{},
+			Content:  []byte("//go:build (linux || darwin) && !cgo && go1.21 && amd64.v3 && !goexperiment.rangefunc\n\npackage buildtagnametest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtagname.Checker{},
+			Content:  []byte("//go:build linx\n\npackage buildtagnametest\n"),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:1: unknown build tag "linx"$`),
+		},
+		{
+			Checker: buildtagname.Checker{},
+			Content: []byte("// Package doc.\n//go:build !(windows || integration) && (!integration || linux.v2 || darwn)\n\npackage buildtagnametest\n"),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:2: unknown build tag "integration"\n` +
+				`[^\n]*file\.go:2: unknown build tag "linux\.v2"\n` +
+				`[^\n]*file\.go:2: unknown build tag "darwn"$`),
+		},
+		{
+			Checker:  buildtagname.Checker{KnownTags: []string{"integration"}},
+			Content:  []byte("//go:build integration && !windows\n\npackage buildtagnametest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtagname.Checker{},
+			Content:  []byte("package buildtagnametest\n\n//go:build linx\n\nvar x int\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtagname.Checker{},
+			Content:  []byte("package buildtagnametest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,172 @@
+// Package buildtags provides lint integration for checking build constraints.
+package buildtags
+
+import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// maxTags is the largest number of distinct tags for which //go:build and
+// // +build lines are compared by evaluating every assignment of the tags.
+// Beyond it the lines are compared as text.
+const maxTags = 16
+
+// Checker implements lint.Checker and reports problems with the build
+// constraints in .go files, including test files.
+type Checker struct{}
+
+// Check reports each problem with the build constraints in pkgs as one of
+//
+//	file.go:1: build constraints out of sync
+//	file.go:1: invalid build constraint: unexpected token )
+//	file.go:5: +build line after package clause is ignored
+//
+// Constraints are out of sync when a file has both a //go:build line and
+// // +build lines and they are not satisfied by the same set of tags.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each problem with the build
+// constraints in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports problems with the build constraints in files as described
+// in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, checkSource(file, nil)...)
+	}
+	return diags
+}
+
+// CheckSource reports problems with the build constraints in src, the contents
+// of filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(checkSource(filename, src), nil)
+}
+
+// checkSource checks src, or the contents of file if src is nil. src is passed
+// to parser.ParseFile.
+func checkSource(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	diagnostic := func(pos token.Pos, msg string) checkers.Diagnostic {
+		return checkers.Diagnostic{File: file, Line: fset.Position(pos).Line, Message: msg}
+	}
+	var (
+		diags   []checkers.Diagnostic
+		goBuild constraint.Expr
+		goPos   token.Pos
+		plus    []constraint.Expr
+	)
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			text := comment.Text
+			isGo, isPlus := constraint.IsGoBuild(text), constraint.IsPlusBuild(text)
+			if !isGo && !isPlus {
+				continue
+			}
+			if comment.Pos() > f.Package {
+				if isPlus {
+					diags = append(diags, diagnostic(comment.Pos(), "+build line after package clause is ignored"))
+				}
+				continue
+			}
+			expr, err := constraint.Parse(text)
+			if err != nil {
+				diags = append(diags, diagnostic(comment.Pos(), "invalid build constraint: "+err.Error()))
+				continue
+			}
+			if isGo {
+				if goBuild != nil {
+					diags = append(diags, diagnostic(comment.Pos(), "multiple //go:build lines"))
+					continue
+				}
+				goBuild, goPos = expr, comment.Pos()
+			} else {
+				plus = append(plus, expr)
+			}
+		}
+	}
+	if goBuild != nil && len(plus) > 0 && !equivalent(goBuild, plus) {
+		diags = append(diags, diagnostic(goPos, "build constraints out of sync"))
+	}
+	return diags
+}
+
+// equivalent reports whether expr is satisfied by the same tags as all of the
+// // +build lines in plus.
+func equivalent(expr constraint.Expr, plus []constraint.Expr) bool {
+	all := plus[0]
+	for _, p := range plus[1:] {
+		all = &constraint.AndExpr{X: all, Y: p}
+	}
+	tags := exprTags(expr, exprTags(all, nil))
+	if len(tags) > maxTags {
+		lines, err := constraint.PlusBuildLines(expr)
+		if err != nil {
+			return false
+		}
+		want := make([]string, len(plus))
+		for i, p := range plus {
+			want[i] = "// +build " + p.String()
+		}
+		return strings.Join(lines, "\n") == strings.Join(want, "\n")
+	}
+	names := make([]string, 0, len(tags))
+	for tag := range tags {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+	set := map[string]bool{}
+	ok := func(tag string) bool { return set[tag] }
+	for bits := 0; bits < 1<<uint(len(names)); bits++ {
+		for i, name := range names {
+			set[name] = bits&(1<<uint(i)) != 0
+		}
+		if expr.Eval(ok) != all.Eval(ok) {
+			return false
+		}
+	}
+	return true
+}
+
+// exprTags adds the tags used in expr to tags and returns it.
+func exprTags(expr constraint.Expr, tags map[string]bool) map[string]bool {
+	if tags == nil {
+		tags = map[string]bool{}
+	}
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		tags[e.Tag] = true
+	case *constraint.NotExpr:
+		exprTags(e.X, tags)
+	case *constraint.AndExpr:
+		exprTags(e.X, tags)
+		exprTags(e.Y, tags)
+	case *constraint.OrExpr:
+		exprTags(e.X, tags)
+		exprTags(e.Y, tags)
+	}
+	return tags
+}
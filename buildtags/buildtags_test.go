@@ -0,0 +1,43 @@
+package buildtags_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/buildtags"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestBuildTags(t *testing.T) {
+	testutil.Test(t, "buildtagstest", []testutil.StaticCheckTest{
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("package buildtagstest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("//go:build linux && (amd64 || arm64)\n// +build linux\n// +build amd64 arm64\n\npackage buildtagstest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("//go:build !windows\n\npackage buildtagstest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("//go:build linux && amd64\n// +build linux darwin\n\npackage buildtagstest\n"),
+			Validate: testutil.HasSuffix("file.go:1: build constraints out of sync"),
+		},
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("//go:build linux &&\n\npackage buildtagstest\n"),
+			Validate: testutil.MatchesRegexp(`file\.go:1: invalid build constraint: .+$`),
+		},
+		{
+			Checker:  buildtags.Checker{},
+			Content:  []byte("package buildtagstest\n\n// +build linux\n\nvar x int\n"),
+			Validate: testutil.HasSuffix("file.go:3: +build line after package clause is ignored"),
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Cache returns a Checker that records the results of c in dir and replays them
+// when nothing has changed. Results are keyed by a hash of the type of c, the
+// packages passed to Check and the name and content of every .go file in them,
+// so changing any file invalidates the entry.
+//
+// Only results that are nil or implement the errors interface described in Skip
+// are recorded. The configuration of c is not part of the key, so use a separate
+// dir for differently configured checkers of the same type. Failures to read or
+// write the cache are ignored and c is run as usual.
+func Cache(dir string, c Checker) Checker {
+	return cache{checker: c, dir: dir}
+}
+
+type cache struct {
+	checker Checker
+	dir     string
+}
+
+func (c cache) unwrap() interface{} { return c.checker }
+
+// Name returns the name of the wrapped checker.
+func (c cache) Name() string { return checkerName(c.checker) }
+
+type cacheEntry struct {
+	Errors []string `json:"errors"`
+}
+
+// Check replays cached results for pkgs or runs the wrapped checker.
+func (c cache) Check(pkgs ...string) error {
+	key, err := c.key(pkgs)
+	if err != nil {
+		return c.checker.Check(pkgs...)
+	}
+	path := filepath.Join(c.dir, key+".json")
+	if entry, err := readCacheEntry(path); err == nil {
+		return checkers.Error(entry.Errors...)
+	}
+	err = c.checker.Check(pkgs...)
+	var entry cacheEntry
+	switch e := err.(type) {
+	case nil:
+		entry.Errors = []string{}
+	case errors:
+		entry.Errors = e.Errors()
+	default:
+		return err
+	}
+	_ = writeCacheEntry(path, entry)
+	return err
+}
+
+func (c cache) key(pkgs []string) (string, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%q\x00", checkerName(c.checker), pkgs)
+	for _, file := range files {
+		if err := hashFile(h, file); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fh := sha256.New()
+	if _, err := io.Copy(fh, f); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\x00%x\x00", file, fh.Sum(nil))
+	return err
+}
+
+func readCacheEntry(path string) (cacheEntry, error) {
+	var entry cacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	if entry.Errors == nil {
+		return entry, fmt.Errorf("invalid cache entry: %s", path)
+	}
+	return entry, nil
+}
+
+func writeCacheEntry(path string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a concurrent run never reads a partial entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,69 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestCache(t *testing.T) {
+	tmp, err := testutil.NewGopath("cache", []testutil.SourceFile{
+		{Content: []byte("package cache\n"), Dest: filepath.Join("cache", "file.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	dir := t.TempDir()
+
+	runs := 0
+	counter := checkFn(func(pkgs ...string) error {
+		runs++
+		return checkers.Error(fmt.Sprintf("run %d", runs))
+	})
+	cached := lint.Cache(dir, counter)
+
+	err = cached.Check("cache")
+	assert(t, err != nil && err.Error() == "run 1", fmt.Sprintf("%v", err))
+	err = cached.Check("cache")
+	assert(t, err != nil && err.Error() == "run 1" && runs == 1, fmt.Sprintf("%v", err))
+
+	// A different package list is a different entry.
+	err = cached.Check("cache", "cache")
+	assert(t, err != nil && err.Error() == "run 2", fmt.Sprintf("%v", err))
+
+	// Changing a file invalidates the entry.
+	file := filepath.Join(tmp.Path, "src", "cache", "file.go")
+	if err := os.WriteFile(file, []byte("package cache\n\nvar a int\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = cached.Check("cache")
+	assert(t, err != nil && err.Error() == "run 3", fmt.Sprintf("%v", err))
+
+	// Corrupt entries are ignored.
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, e := range entries {
+		if err := os.WriteFile(e, []byte("{"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = cached.Check("cache")
+	assert(t, err != nil && err.Error() == "run 4", fmt.Sprintf("%v", err))
+
+	// Successful checks are cached.
+	runs = 0
+	pass := lint.Cache(dir, passCheck{&runs})
+	assert(t, pass.Check("cache") == nil && pass.Check("cache") == nil && runs == 1, fmt.Sprintf("%d", runs))
+}
+
+type passCheck struct{ runs *int }
+
+func (p passCheck) Check(pkgs ...string) error {
+	*p.runs++
+	return nil
+}
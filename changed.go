@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// ChangedOnly returns a Checker that only runs c for packages containing .go
+// files that differ from baseRef, as reported by
+//
+//	git diff --name-only baseRef
+//
+// Files that no longer exist, such as deleted files or the old name of a renamed
+// file, are ignored. If no package has changed, c is not run.
+//
+// If git fails, for example because it is not installed or baseRef is invalid, c is
+// run for all packages and an informational entry explaining why is added to the
+// errors, tagged as described in WithSeverity.
+func ChangedOnly(baseRef string, c Checker) Checker {
+	return changedOnly{checker: c, baseRef: baseRef}
+}
+
+type changedOnly struct {
+	checker Checker
+	baseRef string
+}
+
+func (c changedOnly) unwrap() interface{} { return c.checker }
+
+// Name returns the name of the wrapped checker.
+func (c changedOnly) Name() string { return checkerName(c.checker) }
+
+// Check runs the wrapped checker for the changed packages in pkgs.
+func (c changedOnly) Check(pkgs ...string) error {
+	changed, err := c.changedDirs()
+	if err != nil {
+		errs := []string{SeverityInfo.String() + ": " + fmt.Sprintf("checking all packages: %v", err)}
+		switch cerr := c.checker.Check(pkgs...).(type) {
+		case nil:
+		case errors:
+			errs = append(errs, cerr.Errors()...)
+		default:
+			errs = append(errs, cerr.Error())
+		}
+		return checkers.Error(errs...)
+	}
+	var selected []string
+	for _, pkg := range pkgs {
+		p, err := checkers.Load(pkg)
+		if err != nil {
+			return fmt.Errorf("failed to load pkg info: %s: %v", pkg, err)
+		}
+		for _, path := range p.Pkgs {
+			// Packages whose directory has been removed are not found and are skipped.
+			b, err := build.Import(path, ".", build.FindOnly)
+			if err == nil && changed[realPath(b.Dir)] {
+				selected = append(selected, path)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return c.checker.Check(selected...)
+}
+
+// changedDirs returns the directories of existing .go files that differ from baseRef.
+func (c changedOnly) changedDirs() (map[string]bool, error) {
+	top, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	out, err := git("diff", "--name-only", c.baseRef, "--")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(top)
+	changed := map[string]bool{}
+	for _, name := range strings.Split(out, "\n") {
+		if name = strings.TrimSpace(name); name == "" || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		changed[realPath(filepath.Dir(path))] = true
+	}
+	return changed, nil
+}
+
+func realPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	return path
+}
+
+func git(args ...string) (string, error) {
+	res, err := checkers.Exec(exec.Command("git", args...))
+	if err != nil {
+		if msg := strings.TrimSpace(res.Stderr); msg != "" {
+			return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s failed: %v", args[0], err)
+	}
+	return res.Stdout, nil
+}
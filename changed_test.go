@@ -0,0 +1,66 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestChangedOnly(t *testing.T) {
+	tmp, err := testutil.NewGopath("changed", []testutil.SourceFile{
+		{Content: []byte("package a\n"), Dest: filepath.Join("changed", "a", "a.go")},
+		{Content: []byte("package b\n"), Dest: filepath.Join("changed", "b", "b.go")},
+		{Content: []byte("package c\n"), Dest: filepath.Join("changed", "c", "c.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	repo := filepath.Join(tmp.Path, "src", "changed")
+	t.Chdir(repo)
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@a", "GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@a")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	run("add", "-A")
+	run("commit", "-q", "-m", "initial")
+
+	var checked []string
+	record := checkFn(func(pkgs ...string) error {
+		checked = append(checked, pkgs...)
+		return nil
+	})
+	changed := lint.ChangedOnly("HEAD", record)
+
+	err = changed.Check("changed/...")
+	assert(t, err == nil && len(checked) == 0, fmt.Sprintf("%v %v", err, checked))
+
+	if err := os.WriteFile(filepath.Join(repo, "a", "a.go"), []byte("package a\n\nvar A int\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Renaming leaves only the new name on disk and deleting leaves nothing.
+	run("mv", filepath.Join("b", "b.go"), filepath.Join("b", "renamed.go"))
+	run("rm", "-q", filepath.Join("c", "c.go"))
+
+	err = changed.Check("changed/...")
+	sort.Strings(checked)
+	assert(t, err == nil && strings.Join(checked, ",") == "changed/a,changed/b", fmt.Sprintf("%v %v", err, checked))
+
+	checked = nil
+	err = lint.ChangedOnly("not-a-valid-ref", record).Check("changed/a")
+	assert(t,
+		err != nil && strings.HasPrefix(err.Error(), "info: checking all packages: git diff failed") &&
+			strings.Join(checked, ",") == "changed/a",
+		fmt.Sprintf("%v %v", err, checked))
+}
@@ -38,6 +38,9 @@ func (e errorList) Errors() []string { return []string(e) }
 func (e errorList) Error() string    { return strings.Join(e, "\n") }
 
 func packageDir(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
 	pkg, err := build.Import(path, ".", build.FindOnly)
 	if err != nil {
 		return "", fmt.Errorf("import failed: %s: %v", path, err)
@@ -174,6 +177,23 @@ func GoFiles(pkgs ...string) ([]string, error) {
 	return files, nil
 }
 
+// AllGoFiles lists all .go files in pkgs, including test files.
+func AllGoFiles(pkgs ...string) ([]string, error) {
+	var files []string
+	for _, pkg := range pkgs {
+		p, err := Load(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load go files for %s: %v", pkg, err)
+		}
+		for _, f := range p.Files {
+			if strings.HasSuffix(f, ".go") {
+				files = append(files, f)
+			}
+		}
+	}
+	return files, nil
+}
+
 func filterGoFiles(files []string) []string {
 	gofiles, i := make([]string, len(files)), 0
 	for _, f := range files {
@@ -197,6 +217,9 @@ type Package struct {
 	GoFiles []string
 	// All sub packages if Path is a wildcard, or just Path if not.
 	Pkgs []string
+	// File is the file named by Path if Path names a single .go file, as
+	// described in NormalizeSpec. Files then holds only File.
+	File string
 	// build.Package instance for this package
 	Build *build.Package
 }
@@ -242,6 +265,10 @@ func (p *Package) load() error {
 }
 
 func (p *Package) readFiles() error {
+	if p.File != "" {
+		p.Files = []string{p.File}
+		return nil
+	}
 	var res []string
 	for _, pkg := range p.Pkgs {
 		dir, err := packageDir(pkg)
@@ -272,11 +299,20 @@ func (p *Package) readPackages() error {
 		return fmt.Errorf("failed to find cwd: %v", err)
 	}
 	if filepath.Base(p.Path) != "..." {
+		path, file, err := NormalizeSpec(p.Path)
+		if err != nil {
+			return err
+		}
 		var b *build.Package
-		if b, err = build.Import(p.Path, wd, build.FindOnly); err != nil {
+		if filepath.IsAbs(path) {
+			b, err = build.ImportDir(path, build.FindOnly)
+		} else {
+			b, err = build.Import(path, wd, build.FindOnly)
+		}
+		if err != nil {
 			return fmt.Errorf("import failed: %s: %v", p.Path, err)
 		}
-		p.Pkgs, p.Build = []string{b.ImportPath}, b
+		p.Pkgs, p.Build, p.File = []string{path}, b, file
 		return nil
 	}
 
@@ -286,30 +322,13 @@ func (p *Package) readPackages() error {
 		return fmt.Errorf("import failed %s: %v", d, err)
 	}
 	p.Build = b
-	dir := b.Dir
-	var paths []string
-	err = filepath.Walk(dir, func(path string, stat os.FileInfo, walkErr error) error {
-		if walkErr != nil {
-			return walkErr
-		}
-		if !stat.IsDir() {
-			return nil
-		}
-		if strings.HasPrefix(stat.Name(), "_") || stat.Name() == "testdata" {
-			return filepath.SkipDir
-		}
-		p, perr := build.ImportDir(path, build.FindOnly)
-		if perr != nil {
-			if _, noGo := perr.(*build.NoGoError); noGo {
-				return nil
-			}
-			return fmt.Errorf("import failed: %s: %v", path, perr)
-		}
-		paths = append(paths, p.ImportPath)
-		return nil
-	})
+	dirs, err := ExpandPackages(p.Path)
 	if err != nil {
-		return fmt.Errorf("failed to list %s: %v", dir, err)
+		return fmt.Errorf("failed to list %s: %v", b.Dir, err)
+	}
+	paths := make([]string, len(dirs))
+	for i, dir := range dirs {
+		paths[i] = dirImportPath(dir)
 	}
 	p.Pkgs = paths
 	return nil
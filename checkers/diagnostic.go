@@ -0,0 +1,140 @@
+package checkers
+
+import (
+	"go/scanner"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity indicates how serious an error is.
+type Severity int
+
+// Severity levels, from most to least severe. The zero value is SeverityError,
+// which is the severity of every message that has not been tagged by
+// lint.WithSeverity.
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+var severityNames = []string{"error", "warning", "info"}
+
+// String returns the lower case name of s.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// Diagnostic holds the parts of a single error message. Any part that could
+// not be parsed is left empty, with the remaining text in Message.
+type Diagnostic struct {
+	// Checker is the checker prefix added by lint.Group.
+	Checker string `json:"checker"`
+	// File is the file the message refers to.
+	File string `json:"file"`
+	// Line is the line in File, starting at 1.
+	Line int `json:"line"`
+	// Col is the column in Line, starting at 1.
+	Col int `json:"column"`
+	// Message is the text of the message following the position.
+	Message string `json:"message"`
+	// Severity is the severity added by lint.WithSeverity. It is SeverityError
+	// for messages without a severity.
+	Severity Severity `json:"severity"`
+}
+
+// String formats d in the form described by lint.Group, including the severity
+// if it is not SeverityError.
+func (d Diagnostic) String() string {
+	var parts []string
+	if d.Checker != "" {
+		parts = append(parts, d.Checker)
+	}
+	if d.Severity != SeverityError {
+		parts = append(parts, d.Severity.String())
+	}
+	if d.File != "" {
+		pos := d.File
+		if d.Line > 0 {
+			pos += ":" + strconv.Itoa(d.Line)
+			if d.Col > 0 {
+				pos += ":" + strconv.Itoa(d.Col)
+			}
+		}
+		parts = append(parts, pos)
+	}
+	return strings.Join(append(parts, d.Message), ": ")
+}
+
+// positionRE matches messages of the form file.go[:line[:col]]: text
+var positionRE = regexp.MustCompile(`^([^\s:]+\.go)(?::(\d+))?(?::(\d+))?: ?(.*)$`)
+
+// ParsePosition parses a message of the form
+//
+//	file.go:line:col: message
+//
+// where the line and column are optional. It returns false if str does not start
+// with a .go file.
+func ParsePosition(str string) (Diagnostic, bool) {
+	match := positionRE.FindStringSubmatch(str)
+	if match == nil {
+		return Diagnostic{}, false
+	}
+	d := Diagnostic{File: match[1], Message: match[4]}
+	d.Line, _ = strconv.Atoi(match[2])
+	d.Col, _ = strconv.Atoi(match[3])
+	return d, true
+}
+
+// DiagnosticsError converts the result of a CheckDiagnostics method into the
+// result of Check. If err is not nil it is returned. Otherwise each diagnostic is
+// formatted using Diagnostic.String and returned as described in Error.
+func DiagnosticsError(diags []Diagnostic, err error) error {
+	if err != nil {
+		return err
+	}
+	errs := make([]string, len(diags))
+	for i, d := range diags {
+		errs[i] = d.String()
+	}
+	return Error(errs...)
+}
+
+// ErrorDiagnostics converts err into diagnostics. Each error in a
+// scanner.ErrorList, such as those returned by go/parser, is converted using its
+// position. Other errors are parsed using ParsePosition, with the whole error as
+// the message if that fails. A nil error has no diagnostics.
+func ErrorDiagnostics(err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+	var msgs []string
+	switch e := err.(type) {
+	case scanner.ErrorList:
+		var diags []Diagnostic
+		for _, se := range e {
+			diags = append(diags, Diagnostic{File: se.Pos.Filename, Line: se.Pos.Line, Col: se.Pos.Column, Message: se.Msg})
+		}
+		return diags
+	case errorList:
+		msgs = e
+	default:
+		msgs = []string{err.Error()}
+	}
+	diags := make([]Diagnostic, len(msgs))
+	for i, msg := range msgs {
+		d, ok := ParsePosition(msg)
+		if !ok {
+			d = Diagnostic{Message: msg}
+		}
+		diags[i] = d
+	}
+	return diags
+}
@@ -0,0 +1,275 @@
+package checkers
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExpandOptions controls how ExpandPackagesWith resolves patterns.
+type ExpandOptions struct {
+	// IncludeVendor includes packages in vendor directories matched by ...
+	// wildcards. They are skipped by default.
+	IncludeVendor bool
+}
+
+// ExpandPackages resolves patterns into the directories of the packages they
+// match using
+//
+//	go list -e patterns...
+//
+// Relative paths, ... wildcards and import paths are all accepted and are
+// resolved the same way the go command resolves them, relative to the current
+// directory and using the current environment, including GOFLAGS. Each directory
+// is returned once, in the order go list reports it. A pattern that matches no
+// packages is not an error, but an import path that cannot be found is.
+//
+// Following the go command, ... wildcards never match directories named vendor
+// or testdata, or directories whose names begin with . or _. Packages named
+// explicitly are always returned. Use ExpandPackagesWith to include vendored
+// packages.
+//
+// Results are cached for 10 seconds, so the checkers of a Group resolving the
+// same patterns run go list once. The cache is keyed by the patterns, the current
+// directory and the settings that change which packages and files the go command
+// selects, such as GOFLAGS, GOOS and GOARCH, whether they are set in the
+// environment or with go env -w. Use ClearPackageCache when packages may have
+// been added or removed since.
+func ExpandPackages(patterns ...string) ([]string, error) {
+	return ExpandPackagesWith(ExpandOptions{}, patterns...)
+}
+
+// ExpandPackagesWith is like ExpandPackages but uses opts to control which
+// packages wildcards match.
+func ExpandPackagesWith(opts ExpandOptions, patterns ...string) ([]string, error) {
+	key, cacheable := expandKey(opts, patterns)
+	if cacheable {
+		expandMutex.Lock()
+		entry, ok := expanded[key]
+		expandMutex.Unlock()
+		if ok && time.Since(entry.resolved) < expandTTL {
+			return append([]string(nil), entry.dirs...), nil
+		}
+	}
+	resolved := time.Now()
+	dirs, err := expandPackages(opts, patterns)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		expandMutex.Lock()
+		expanded[key] = expandEntry{dirs: dirs, resolved: resolved}
+		expandMutex.Unlock()
+	}
+	return append([]string(nil), dirs...), nil
+}
+
+// expandTTL is how long patterns resolved by ExpandPackages are cached.
+var expandTTL = 10 * time.Second
+
+type expandEntry struct {
+	dirs     []string
+	resolved time.Time
+}
+
+var (
+	expanded    = map[string]expandEntry{}
+	expandMutex sync.Mutex
+)
+
+// expandEnv holds the environment variables that change the packages go list
+// reports or the files ExpandPackages selects.
+var expandEnv = []string{"GOPATH", "GO111MODULE", "GOFLAGS", "GOOS", "GOARCH", "GOROOT", "CGO_ENABLED"}
+
+// ClearPackageCache removes all patterns resolved by ExpandPackages from the
+// cache, so that added and removed packages are seen.
+func ClearPackageCache() {
+	expandMutex.Lock()
+	defer expandMutex.Unlock()
+	expanded = map[string]expandEntry{}
+}
+
+// expandKey returns the cache key of patterns resolved with opts. It returns
+// false if the current directory cannot be found.
+func expandKey(opts ExpandOptions, patterns []string) (string, bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	parts := []string{wd, fmt.Sprint(opts.IncludeVendor)}
+	for _, name := range expandEnv {
+		parts = append(parts, os.Getenv(name))
+	}
+	parts = append(parts, build.Default.GOPATH, build.Default.GOOS, build.Default.GOARCH, goEnvFile())
+	return strings.Join(append(parts, patterns...), "\x00"), true
+}
+
+// goEnvFile returns the contents of the file holding the settings written by go
+// env -w, or "" if there is none.
+func goEnvFile() string {
+	file := os.Getenv("GOENV")
+	if file == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		file = filepath.Join(dir, "go", "env")
+	}
+	if file == "off" {
+		return ""
+	}
+	data, _ := os.ReadFile(file)
+	return string(data)
+}
+
+func expandPackages(opts ExpandOptions, patterns []string) ([]string, error) {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		listed, err := listDirs(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if root, ok := WildcardRoot(pattern); ok {
+			listed = filterIgnored(root, listed)
+			if opts.IncludeVendor {
+				vendored, err := vendorDirs(root)
+				if err != nil {
+					return nil, err
+				}
+				listed = append(listed, vendored...)
+			}
+		}
+		for _, dir := range listed {
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// listDirs returns the directories of the packages matched by patterns as
+// reported by go list.
+func listDirs(patterns ...string) ([]string, error) {
+	args := append([]string{"list", "-e", "-f", `{{.Dir}}{{"\t"}}{{with .Error}}{{.Err}}{{end}}`}, patterns...)
+	res, err := Exec(exec.Command("go", args...))
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %v: %s", err, strings.TrimSpace(res.Stderr))
+	}
+	var dirs []string
+	for _, line := range strings.Split(res.Stdout, "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 2)
+		if parts[0] == "" {
+			msg := "package not found"
+			if len(parts) == 2 && parts[1] != "" {
+				msg = parts[1]
+			}
+			return nil, fmt.Errorf("go list failed: %s", msg)
+		}
+		dirs = append(dirs, parts[0])
+	}
+	return dirs, nil
+}
+
+// WildcardRoot returns the directory a pattern ending in /... is rooted at. It
+// returns false if pattern is not a wildcard or the root cannot be found.
+func WildcardRoot(pattern string) (string, bool) {
+	if pattern != "..." && !strings.HasSuffix(pattern, "/...") {
+		return "", false
+	}
+	root := strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+	if root == "" {
+		root = "."
+	}
+	if filepath.IsAbs(root) || build.IsLocalImport(root) {
+		abs, err := filepath.Abs(root)
+		return abs, err == nil
+	}
+	b, err := build.Import(root, ".", build.FindOnly)
+	if err != nil {
+		return "", false
+	}
+	return b.Dir, true
+}
+
+// IgnoredDir reports whether the go command skips a directory with the given
+// name when matching ... wildcards.
+func IgnoredDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// filterIgnored removes directories below root that have an ignored directory
+// in their path relative to root.
+func filterIgnored(root string, dirs []string) []string {
+	var res []string
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			res = append(res, dir)
+			continue
+		}
+		ignored := false
+		for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+			if IgnoredDir(elem) {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			res = append(res, dir)
+		}
+	}
+	return res
+}
+
+// vendorDirs returns the directories of the packages in vendor directories
+// below root, including nested vendor directories. Vendored packages in
+// testdata, . or _ directories are still skipped.
+func vendorDirs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || path == root {
+			return nil
+		}
+		name := info.Name()
+		if name != "vendor" {
+			if IgnoredDir(name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		listed, err := listDirs(path + string(filepath.Separator) + "...")
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, filterIgnored(path, listed)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vendor directories in %s: %v", root, err)
+	}
+	return dirs, nil
+}
+
+// dirImportPath returns the import path of the package in dir, or dir itself if
+// the package is not in GOPATH. Load accepts either.
+func dirImportPath(dir string) string {
+	b, err := build.ImportDir(dir, build.FindOnly)
+	if err != nil || b.ImportPath == "" || b.ImportPath == "." || strings.HasPrefix(b.ImportPath, "_") {
+		return filepath.Clean(dir)
+	}
+	return b.ImportPath
+}
@@ -0,0 +1,63 @@
+package checkers
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandPackagesCacheExpiry(t *testing.T) {
+	// testutil cannot be used here since it imports this package.
+	gopath := t.TempDir()
+	defer func(path string) { build.Default.GOPATH = path }(build.Default.GOPATH)
+	build.Default.GOPATH = gopath
+	t.Setenv("GOPATH", gopath)
+	t.Setenv("GO111MODULE", "off")
+	defer ClearPackageCache()
+	defer func(ttl time.Duration) { expandTTL = ttl }(expandTTL)
+	expandTTL = time.Hour
+	src := filepath.Join(gopath, "src", "expandttl")
+	env := filepath.Join(t.TempDir(), "env")
+	t.Setenv("GOENV", env)
+	// GOFLAGS in the environment overrides the one written to env.
+	t.Setenv("GOFLAGS", "")
+
+	expand := func(expected ...string) {
+		t.Helper()
+		dirs, err := ExpandPackages("expandttl/...")
+		for i := range expected {
+			expected[i] = filepath.Join(src, expected[i])
+		}
+		if err != nil || strings.Join(dirs, ",") != strings.Join(expected, ",") {
+			t.Fatalf("expected %v, got %v %v", expected, err, dirs)
+		}
+	}
+	write := func(file, content string) {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(src, "a", "a.go"), "package a\n")
+	expand("a")
+	write(filepath.Join(src, "b", "b.go"), "//go:build expandttl\n\npackage b\n")
+	expand("a")
+
+	// Settings written by go env -w are part of the key.
+	write(env, "GOFLAGS=-tags=expandttl\n")
+	expand("a", "b")
+
+	// Results expire.
+	if err := os.RemoveAll(filepath.Join(src, "b")); err != nil {
+		t.Fatal(err)
+	}
+	expand("a", "b")
+	expandTTL = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+	expand("a")
+}
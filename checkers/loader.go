@@ -0,0 +1,59 @@
+package checkers
+
+import "sync"
+
+// Loader parses and caches the packages matching a set of patterns so that
+// several checkers can share them. The packages are parsed on first use and type
+// information is cached in each Source, so the packages are loaded and type
+// checked once however many checkers use them.
+//
+// A Loader is safe for concurrent use. Checkers using it must only read the
+// Sources it returns.
+type Loader struct {
+	pkgs []string
+	once sync.Once
+	srcs []*Source
+	err  error
+}
+
+// NewLoader returns a Loader for pkgs. Packages are not parsed until they are
+// first needed.
+func NewLoader(pkgs ...string) *Loader {
+	return &Loader{pkgs: append([]string{}, pkgs...)}
+}
+
+// Packages returns the patterns the Loader was created with.
+func (l *Loader) Packages() []string {
+	return append([]string{}, l.pkgs...)
+}
+
+// Sources returns the parsed packages matching the patterns of l, parsing them
+// with ParseSource on the first call.
+func (l *Loader) Sources() ([]*Source, error) {
+	l.once.Do(func() { l.srcs, l.err = ParseSource(l.pkgs...) })
+	return l.srcs, l.err
+}
+
+// AnalyzeDiagnostics is like the package level AnalyzeDiagnostics but uses the
+// packages of l.
+func (l *Loader) AnalyzeDiagnostics(check func(s *Source) []Diagnostic) ([]Diagnostic, error) {
+	return l.StreamDiagnostics(check, func(Diagnostic) {})
+}
+
+// StreamDiagnostics is like AnalyzeDiagnostics but also calls out with each
+// diagnostic as soon as the package it belongs to has been checked.
+func (l *Loader) StreamDiagnostics(check func(s *Source) []Diagnostic, out func(d Diagnostic)) ([]Diagnostic, error) {
+	srcs, err := l.Sources()
+	if err != nil {
+		return nil, err
+	}
+	diags := []Diagnostic{}
+	for _, s := range srcs {
+		found := s.filter(append(ErrorDiagnostics(Error(s.Errors...)), check(s)...))
+		for _, d := range found {
+			out(d)
+		}
+		diags = append(diags, found...)
+	}
+	return diags, nil
+}
@@ -0,0 +1,74 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// ParseNoLint parses comment, the text of a // comment, as a nolint directive of
+// the form
+//
+//	//nolint
+//	//nolint:govet,golint
+//
+// It returns the checkers listed after the colon, or nil for the first form,
+// which applies to every checker. It returns false if comment is not a directive,
+// such as //nolintable or //nolint: with no checkers.
+func ParseNoLint(comment string) (names []string, ok bool) {
+	if !strings.HasPrefix(comment, "//nolint") {
+		return nil, false
+	}
+	rest := comment[len("//nolint"):]
+	switch {
+	case rest == "" || rest[0] == ' ' || rest[0] == '\t':
+		return nil, true
+	case rest[0] == ':':
+		if i := strings.IndexAny(rest, " \t"); i >= 0 {
+			rest = rest[:i]
+		}
+		for _, name := range strings.Split(rest[1:], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names, names != nil
+	}
+	return nil, false
+}
+
+// NoLintMatches reports whether a nolint directive listing names, as returned by
+// ParseNoLint, applies to checker. A nil list applies to every checker. Otherwise
+// checker is listed if its name, or the package part of its name, is in names,
+// ignoring case, so govet matches govet.Checker.
+func NoLintMatches(names []string, checker string) bool {
+	if names == nil {
+		return true
+	}
+	checker = strings.ToLower(strings.TrimPrefix(checker, "*"))
+	pkg := checker
+	if i := strings.Index(pkg, "."); i >= 0 {
+		pkg = pkg[:i]
+	}
+	for _, name := range names {
+		if name = strings.ToLower(name); name == checker || name == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// NoLintLines returns the lines of f with a nolint directive that applies to the
+// checker named name, such as resourceleak.Checker, as lint.NoLint applies them.
+// A problem is suppressed by a directive on its line or the line before it.
+func NoLintLines(fset *token.FileSet, f *ast.File, name string) map[int]bool {
+	lines := map[int]bool{}
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			if names, ok := ParseNoLint(comment.Text); ok && NoLintMatches(names, name) {
+				lines[fset.Position(comment.Pos()).Line] = true
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,30 @@
+package checkers
+
+// Progress receives reports of how far a checker has got.
+//
+// Start is called at most once, before any call to Step, with the number of
+// packages that will be checked, or -1 if that is not known. Step is called once for each
+// package after it has been checked. Done is called once at the end. Step may be
+// called concurrently by checkers that check packages in parallel.
+type Progress interface {
+	Start(total int)
+	Step(pkg string)
+	Done()
+}
+
+// ProgressDiagnostics is like AnalyzeDiagnostics but reports progress to p. Start
+// is called once the packages of l have been parsed and Step once each package
+// has been checked. Done is not called, since the caller may have more to do.
+func (l *Loader) ProgressDiagnostics(check func(s *Source) []Diagnostic, p Progress) ([]Diagnostic, error) {
+	srcs, err := l.Sources()
+	if err != nil {
+		return nil, err
+	}
+	p.Start(len(srcs))
+	diags := []Diagnostic{}
+	for _, s := range srcs {
+		diags = append(diags, s.filter(append(ErrorDiagnostics(Error(s.Errors...)), check(s)...))...)
+		p.Step(s.ImportPath)
+	}
+	return diags, nil
+}
@@ -0,0 +1,214 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sync"
+)
+
+// Source holds the parsed files of a single package.
+type Source struct {
+	// ImportPath is the import path of the package.
+	ImportPath string
+	// Build holds build information for the package.
+	Build *build.Package
+	// Fset holds position information for all parsed files.
+	Fset *token.FileSet
+	// Files holds the parsed GoFiles and CgoFiles of the package.
+	Files []*ast.File
+	// TestFiles holds the parsed _test.go files in the package itself.
+	TestFiles []*ast.File
+	// XTestFiles holds the parsed _test.go files in the external _test package.
+	XTestFiles []*ast.File
+	// Errors holds any errors encountered while parsing.
+	Errors []string
+
+	// file is the only file diagnostics are kept for if the package was named by
+	// a single file.
+	file string
+
+	once  [2]sync.Once
+	info  [2]*types.Info
+	tpkg  [2]*types.Package
+	terrs [2][]string
+}
+
+// ParseSource parses all packages in pkgs. Wildcards are expanded as they are by Load.
+// Files are parsed with comments. Parse errors do not cause ParseSource to fail. They
+// are recorded in the Errors field of the Source for the package.
+//
+// A pkg naming a single .go file parses its whole package, so that it can be type
+// checked, but Analyze and the Loader only report problems in that file.
+func ParseSource(pkgs ...string) ([]*Source, error) {
+	var srcs []*Source
+	for _, pkg := range pkgs {
+		p, err := Load(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pkg info: %s: %v", pkg, err)
+		}
+		for _, path := range p.Pkgs {
+			s, err := parseSource(path)
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				s.file = p.File
+				srcs = append(srcs, s)
+			}
+		}
+	}
+	return srcs, nil
+}
+
+func parseSource(path string) (*Source, error) {
+	dir, err := packageDir(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Source{ImportPath: path, Fset: token.NewFileSet()}
+	b, err := build.ImportDir(dir, 0)
+	switch err.(type) {
+	case nil:
+	case *build.NoGoError:
+		return nil, nil
+	case *build.MultiplePackageError:
+		// Still parse what we can. The error is reported along with parse errors.
+		s.Errors = append(s.Errors, err.Error())
+	default:
+		return nil, fmt.Errorf("import failed: %s: %v", path, err)
+	}
+	s.Build = b
+	s.Files = s.parse(dir, append(append([]string{}, b.GoFiles...), b.CgoFiles...))
+	s.TestFiles = s.parse(dir, b.TestGoFiles)
+	s.XTestFiles = s.parse(dir, b.XTestGoFiles)
+	return s, nil
+}
+
+func (s *Source) parse(dir string, names []string) []*ast.File {
+	var files []*ast.File
+	for _, name := range names {
+		f, err := parser.ParseFile(s.Fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			if list, ok := err.(scanner.ErrorList); ok {
+				for _, e := range list {
+					s.Errors = append(s.Errors, e.Error())
+				}
+			} else {
+				s.Errors = append(s.Errors, err.Error())
+			}
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// Diagnostic returns a Diagnostic for pos with the formatted message.
+func (s *Source) Diagnostic(pos token.Pos, format string, args ...interface{}) Diagnostic {
+	p := s.Fset.Position(pos)
+	return Diagnostic{File: p.Filename, Line: p.Line, Col: p.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Errorf returns an error message for pos of the form
+//
+//	file.go:line: message
+func (s *Source) Errorf(pos token.Pos, format string, args ...interface{}) string {
+	p := s.Fset.Position(pos)
+	return fmt.Sprintf("%s:%d: %s", p.Filename, p.Line, fmt.Sprintf(format, args...))
+}
+
+// TypeCheck type checks the package and returns the type information for it. If
+// tests is true, TestFiles are checked along with Files and XTestFiles are checked
+// as a separate package, with type information for both recorded in the same
+// types.Info. The returned package is always the package containing Files.
+//
+// Type checking continues past errors, so the returned information is usable even
+// if errors are returned. Results are computed once and cached.
+func (s *Source) TypeCheck(tests bool) (*types.Package, *types.Info, []string) {
+	i := 0
+	if tests {
+		i = 1
+	}
+	s.once[i].Do(func() {
+		s.tpkg[i], s.info[i], s.terrs[i] = s.typeCheck(tests)
+	})
+	return s.tpkg[i], s.info[i], s.terrs[i]
+}
+
+func (s *Source) typeCheck(tests bool) (*types.Package, *types.Info, []string) {
+	info := &types.Info{
+		Types:        map[ast.Expr]types.TypeAndValue{},
+		Defs:         map[*ast.Ident]types.Object{},
+		Uses:         map[*ast.Ident]types.Object{},
+		Implicits:    map[ast.Node]types.Object{},
+		Selections:   map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:       map[ast.Node]*types.Scope{},
+		Instances:    map[*ast.Ident]types.Instance{},
+		FileVersions: map[*ast.File]string{},
+	}
+	var errs []string
+	conf := types.Config{
+		Importer: importer.ForCompiler(s.Fset, "source", nil),
+		Error:    func(err error) { errs = append(errs, err.Error()) },
+	}
+	files := s.Files
+	if tests {
+		files = append(append([]*ast.File{}, s.Files...), s.TestFiles...)
+	}
+	pkg, _ := conf.Check(s.ImportPath, s.Fset, files, info)
+	if tests && len(s.XTestFiles) > 0 {
+		_, _ = conf.Check(s.ImportPath+"_test", s.Fset, s.XTestFiles, info)
+	}
+	return pkg, info, errs
+}
+
+// keep reports whether a problem in file should be reported.
+func (s *Source) keep(file string) bool {
+	return s.file == "" || file == "" || file == s.file
+}
+
+// filter returns the diagnostics in diags that should be reported.
+func (s *Source) filter(diags []Diagnostic) []Diagnostic {
+	if s.file == "" {
+		return diags
+	}
+	var kept []Diagnostic
+	for _, d := range diags {
+		if s.keep(d.File) {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// Analyze parses pkgs using ParseSource and calls check for each package found.
+// It returns the errors returned by check, preceded by any parse errors for the
+// package.
+func Analyze(pkgs []string, check func(s *Source) []string) error {
+	srcs, err := ParseSource(pkgs...)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, s := range srcs {
+		for _, e := range append(append([]string{}, s.Errors...), check(s)...) {
+			if d, _ := ParsePosition(e); s.keep(d.File) {
+				errs = append(errs, e)
+			}
+		}
+	}
+	return Error(errs...)
+}
+
+// AnalyzeDiagnostics is like Analyze but collects diagnostics. Parse errors are
+// converted using ErrorDiagnostics.
+func AnalyzeDiagnostics(pkgs []string, check func(s *Source) []Diagnostic) ([]Diagnostic, error) {
+	return NewLoader(pkgs...).AnalyzeDiagnostics(check)
+}
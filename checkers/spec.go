@@ -0,0 +1,72 @@
+package checkers
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NormalizeSpec classifies spec, a package spec passed to Check, and returns
+// the import path of the package it names. If spec names a single .go file, the
+// path of the file is also returned and checkers only report problems in that
+// file. Directories may be given as absolute or relative paths. Patterns
+// containing ... are returned unchanged.
+//
+// Paths that do not exist are reported as
+//
+//	no such file or directory: ./fooo
+//
+// rather than as a failure to import them.
+func NormalizeSpec(spec string) (string, string, error) {
+	if strings.Contains(spec, "...") {
+		return spec, "", nil
+	}
+	local := filepath.IsAbs(spec) || build.IsLocalImport(spec)
+	if local || strings.HasSuffix(spec, ".go") {
+		info, err := os.Stat(spec)
+		switch {
+		case err == nil:
+			return statSpec(spec, info)
+		case !os.IsNotExist(err):
+			return "", "", err
+		case local:
+			return "", "", fmt.Errorf("no such file or directory: %s", spec)
+		}
+		// An import path can end in .go.
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find cwd: %v", err)
+	}
+	b, err := build.Import(spec, wd, build.FindOnly)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot find package %q: %v", spec, err)
+	}
+	return dirImportPath(b.Dir), "", nil
+}
+
+// statSpec returns the package import path and file for spec, an existing path
+// described by info.
+func statSpec(spec string, info os.FileInfo) (string, string, error) {
+	dir, file := spec, ""
+	if !info.IsDir() {
+		if !strings.HasSuffix(spec, ".go") {
+			return "", "", fmt.Errorf("not a .go file or directory: %s", spec)
+		}
+		dir, file = filepath.Dir(spec), filepath.Base(spec)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	b, err := build.ImportDir(abs, build.FindOnly)
+	if err != nil {
+		return "", "", fmt.Errorf("import failed: %s: %v", spec, err)
+	}
+	if file != "" {
+		file = filepath.Join(b.Dir, file)
+	}
+	return dirImportPath(b.Dir), file, nil
+}
@@ -0,0 +1,77 @@
+// Package checkstyle writes lint errors as Checkstyle XML, which is understood
+// by tools such as the Jenkins Warnings plugin.
+package checkstyle
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/surullabs/lint"
+)
+
+const (
+	version = "8.0"
+	// DefaultSeverity is the severity used for checkers not present in the severity map.
+	DefaultSeverity = "error"
+)
+
+// Write converts err, as returned by lint.Group.Check, into a Checkstyle document
+// and writes it to w.
+//
+// Errors are parsed using lint.Diagnostics and grouped by file, in the order in
+// which each file is first seen. The checker prefix of each error is used as its
+// source. severity maps a checker prefix to the severity for its errors. Checkers
+// missing from severity, which may be nil, use DefaultSeverity. Errors that do not
+// refer to a file are grouped in a file element with an empty name.
+func Write(w io.Writer, err error, severity map[string]string) error {
+	doc := document{Version: version}
+	files := map[string]int{}
+	for _, d := range lint.Diagnostics(err) {
+		i, ok := files[d.File]
+		if !ok {
+			i = len(doc.Files)
+			files[d.File] = i
+			doc.Files = append(doc.Files, file{Name: d.File})
+		}
+		sev, ok := severity[d.Checker]
+		if !ok {
+			sev = DefaultSeverity
+		}
+		doc.Files[i].Errors = append(doc.Files[i].Errors, fileError{
+			Line:     d.Line,
+			Column:   d.Col,
+			Severity: sev,
+			Message:  d.Message,
+			Source:   d.Checker,
+		})
+	}
+	if _, werr := io.WriteString(w, xml.Header); werr != nil {
+		return werr
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if werr := enc.Encode(doc); werr != nil {
+		return werr
+	}
+	_, werr := io.WriteString(w, "\n")
+	return werr
+}
+
+type document struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Version string   `xml:"version,attr"`
+	Files   []file   `xml:"file"`
+}
+
+type file struct {
+	Name   string      `xml:"name,attr"`
+	Errors []fileError `xml:"error"`
+}
+
+type fileError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"`
+}
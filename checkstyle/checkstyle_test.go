@@ -0,0 +1,51 @@
+package checkstyle_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/checkstyle"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		err      error
+		severity map[string]string
+		expected string
+	}{
+		{
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="8.0"></checkstyle>
+`,
+		},
+		{
+			err: checkers.Error(
+				"govet.Check: a.go:23: err is <shadowed>",
+				"golint.Check: b.go:2:1: exported Foo should have comment",
+				"govet.Check: a.go:30:4: unreachable code",
+			),
+			severity: map[string]string{"golint.Check": "warning"},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="8.0">
+  <file name="a.go">
+    <error line="23" severity="error" message="err is &lt;shadowed&gt;" source="govet.Check"></error>
+    <error line="30" column="4" severity="error" message="unreachable code" source="govet.Check"></error>
+  </file>
+  <file name="b.go">
+    <error line="2" column="1" severity="warning" message="exported Foo should have comment" source="golint.Check"></error>
+  </file>
+</checkstyle>
+`,
+		},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		if err := checkstyle.Write(&buf, test.err, test.severity); err != nil {
+			t.Fatal(i, err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%d: expected\n%s\ngot\n%s", i, test.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Command returns a Checker that runs the executable name with args. The
+// packages passed to Check replace an argument of {}, or are appended to args if
+// there is no such argument.
+//
+// The binary is looked up using checkers.FindBin. If the command exits with a
+// non-zero status each line of its output is returned as a separate error. A zero
+// exit status means there are no errors, regardless of output.
+func Command(name string, args ...string) Checker {
+	return command{name: name, args: args}
+}
+
+type command struct {
+	name string
+	args []string
+}
+
+// Check runs the command for pkgs.
+func (c command) Check(pkgs ...string) error {
+	bin, err := checkers.FindBin(c.name)
+	if err != nil {
+		return fmt.Errorf("%s not found: install it (for example using go install) and ensure it is in PATH", c.name)
+	}
+	res, err := checkers.Exec(exec.Command(bin, c.expand(pkgs)...))
+	if err == nil {
+		return nil
+	}
+	if res.Code <= 0 {
+		return fmt.Errorf("%s failed: %v", c.name, err)
+	}
+	errs := &checkers.ExecErrors{}
+	errs.Add(res)
+	if len(*errs) == 0 {
+		return fmt.Errorf("%s failed: %v", c.name, err)
+	}
+	return checkers.Error((*errs)...)
+}
+
+func (c command) expand(pkgs []string) []string {
+	var args []string
+	substituted := false
+	for _, arg := range c.args {
+		if arg == "{}" {
+			args, substituted = append(args, pkgs...), true
+			continue
+		}
+		args = append(args, arg)
+	}
+	if !substituted {
+		args = append(args, pkgs...)
+	}
+	return args
+}
@@ -0,0 +1,29 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+)
+
+func TestCommand(t *testing.T) {
+	err := lint.Command("sh", "-c", `echo "$@"; exit 1`, "sh").Check("a", "b")
+	assert(t, err != nil && err.Error() == "a b", fmt.Sprintf("%v", err))
+
+	err = lint.Command("sh", "-c", `echo "$1"; echo "$2" >&2; exit 1`, "sh", "{}", "after").Check("a")
+	assert(t, err != nil && err.Error() == "a\nafter", fmt.Sprintf("%v", err))
+
+	// Output is ignored for a zero exit status.
+	err = lint.Command("sh", "-c", `echo "$@"`, "sh").Check("a")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.Command("sh", "-c", `printf "x.go:1: one\nx.go:2: two\n"; exit 2`)}.Check("./...")
+	assert(t, err != nil && err.Error() == "lint.command: x.go:1: one\nlint.command: x.go:2: two", fmt.Sprintf("%v", err))
+
+	err = lint.Command("not-a-real-linter-binary").Check("./...")
+	assert(t,
+		err != nil && strings.HasPrefix(err.Error(), "not-a-real-linter-binary not found") && !strings.Contains(err.Error(), "\n"),
+		fmt.Sprintf("%v", err))
+}
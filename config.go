@@ -0,0 +1,270 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/aligncheck"
+	"github.com/surullabs/lint/anyparam"
+	"github.com/surullabs/lint/appendcheck"
+	"github.com/surullabs/lint/benchreset"
+	"github.com/surullabs/lint/buildtagname"
+	"github.com/surullabs/lint/buildtags"
+	"github.com/surullabs/lint/copylocks"
+	"github.com/surullabs/lint/coverage"
+	"github.com/surullabs/lint/cryptorand"
+	"github.com/surullabs/lint/ctxfield"
+	"github.com/surullabs/lint/ctxfirst"
+	"github.com/surullabs/lint/deferloop"
+	"github.com/surullabs/lint/elsereturn"
+	"github.com/surullabs/lint/enumstringer"
+	"github.com/surullabs/lint/errcheck"
+	"github.com/surullabs/lint/errcontext"
+	"github.com/surullabs/lint/errorwrap"
+	"github.com/surullabs/lint/errstyle"
+	"github.com/surullabs/lint/fielddoc"
+	"github.com/surullabs/lint/filesize"
+	"github.com/surullabs/lint/floateq"
+	"github.com/surullabs/lint/generate"
+	"github.com/surullabs/lint/gocyclo"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/goimports"
+	"github.com/surullabs/lint/golint"
+	"github.com/surullabs/lint/gorecover"
+	"github.com/surullabs/lint/gosimple"
+	"github.com/surullabs/lint/gostaticcheck"
+	"github.com/surullabs/lint/govet"
+	"github.com/surullabs/lint/httpctx"
+	"github.com/surullabs/lint/hugeparam"
+	"github.com/surullabs/lint/importorder"
+	"github.com/surullabs/lint/imports"
+	"github.com/surullabs/lint/inlineerr"
+	"github.com/surullabs/lint/jsoncase"
+	"github.com/surullabs/lint/license"
+	"github.com/surullabs/lint/linelength"
+	"github.com/surullabs/lint/loopcapture"
+	"github.com/surullabs/lint/magicnum"
+	"github.com/surullabs/lint/mutablereturn"
+	"github.com/surullabs/lint/nakedret"
+	"github.com/surullabs/lint/nesting"
+	"github.com/surullabs/lint/nopanic"
+	"github.com/surullabs/lint/osexit"
+	"github.com/surullabs/lint/params"
+	"github.com/surullabs/lint/pkgdoc"
+	"github.com/surullabs/lint/pkgname"
+	"github.com/surullabs/lint/printf"
+	"github.com/surullabs/lint/prodiface"
+	"github.com/surullabs/lint/receivers"
+	"github.com/surullabs/lint/redundanttype"
+	"github.com/surullabs/lint/regexphoisting"
+	"github.com/surullabs/lint/resourceleak"
+	"github.com/surullabs/lint/senterr"
+	"github.com/surullabs/lint/shadow"
+	"github.com/surullabs/lint/skippedtests"
+	"github.com/surullabs/lint/sortdecls"
+	"github.com/surullabs/lint/sqlclose"
+	"github.com/surullabs/lint/structcheck"
+	"github.com/surullabs/lint/structtags"
+	"github.com/surullabs/lint/switchdefault"
+	"github.com/surullabs/lint/testpkg"
+	"github.com/surullabs/lint/thelper"
+	"github.com/surullabs/lint/timecheck"
+	"github.com/surullabs/lint/todos"
+	"github.com/surullabs/lint/typednil"
+	"github.com/surullabs/lint/unexport"
+	"github.com/surullabs/lint/unkeyed"
+	"github.com/surullabs/lint/varcheck"
+	"github.com/surullabs/lint/waitgroup"
+)
+
+// configCheckers holds the checkers that can be enabled by name in a config file.
+// Options are decoded into a copy of the zero value.
+var configCheckers = map[string]Checker{
+	"aligncheck":     aligncheck.Check{},
+	"anyparam":       anyparam.Checker{},
+	"appendcheck":    appendcheck.Checker{},
+	"benchreset":     benchreset.Checker{},
+	"buildtagname":   buildtagname.Checker{},
+	"buildtags":      buildtags.Checker{},
+	"copylocks":      copylocks.Checker{},
+	"coverage":       coverage.Checker{},
+	"cryptorand":     cryptorand.Checker{},
+	"ctxfield":       ctxfield.Checker{},
+	"ctxfirst":       ctxfirst.Checker{},
+	"deferloop":      deferloop.Checker{},
+	"elsereturn":     elsereturn.Checker{},
+	"enumstringer":   enumstringer.Checker{},
+	"errcheck":       errcheck.Check{},
+	"errcontext":     errcontext.Checker{},
+	"errorwrap":      errorwrap.Checker{},
+	"errstyle":       errstyle.Checker{},
+	"fielddoc":       fielddoc.Checker{},
+	"filesize":       filesize.Checker{},
+	"floateq":        floateq.Checker{},
+	"generate":       generate.Checker{},
+	"gocyclo":        gocyclo.Checker{},
+	"gofmt":          gofmt.Check{},
+	"gofmt.Native":   gofmt.Native{},
+	"goimports":      goimports.Check{},
+	"golint":         golint.Check{},
+	"golint.Native":  golint.Native{},
+	"gorecover":      gorecover.Checker{},
+	"gosimple":       gosimple.Check{},
+	"gostaticcheck":  gostaticcheck.Check{},
+	"govet":          govet.Check{},
+	"govet.Checker":  govet.Checker{},
+	"httpctx":        httpctx.Checker{},
+	"hugeparam":      hugeparam.Checker{},
+	"importorder":    importorder.Checker{},
+	"imports":        imports.Checker{},
+	"inlineerr":      inlineerr.Checker{},
+	"jsoncase":       jsoncase.Checker{},
+	"license":        license.Checker{},
+	"linelength":     linelength.Checker{},
+	"loopcapture":    loopcapture.Checker{},
+	"magicnum":       magicnum.Checker{},
+	"mutablereturn":  mutablereturn.Checker{},
+	"nakedret":       nakedret.Checker{},
+	"nesting":        nesting.Checker{},
+	"nopanic":        nopanic.Checker{},
+	"osexit":         osexit.Checker{},
+	"params":         params.Checker{},
+	"pkgdoc":         pkgdoc.Checker{},
+	"pkgname":        pkgname.Checker{},
+	"printf":         printf.Checker{},
+	"prodiface":      prodiface.Checker{},
+	"receivers":      receivers.Checker{},
+	"redundanttype":  redundanttype.Checker{},
+	"regexphoisting": regexphoisting.Checker{},
+	"resourceleak":   resourceleak.Checker{},
+	"senterr":        senterr.Checker{},
+	"shadow":         shadow.Checker{},
+	"skippedtests":   skippedtests.Checker{},
+	"sortdecls":      sortdecls.Checker{},
+	"sqlclose":       sqlclose.Checker{},
+	"structcheck":    structcheck.Check{},
+	"structtags":     structtags.Checker{},
+	"switchdefault":  switchdefault.Checker{},
+	"testpkg":        testpkg.Checker{},
+	"thelper":        thelper.Checker{},
+	"timecheck":      timecheck.Checker{},
+	"todos":          todos.Checker{},
+	"typednil":       typednil.Checker{},
+	"unexport":       unexport.Checker{},
+	"unkeyed":        unkeyed.Checker{},
+	"varcheck":       varcheck.Check{},
+	"waitgroup":      waitgroup.Checker{},
+}
+
+// Config is the format of a config file read by LoadConfig.
+type Config struct {
+	// Packages are the packages to check.
+	Packages []string `json:"packages"`
+	// Checkers are the checkers to run, in order.
+	Checkers []CheckerConfig `json:"checkers"`
+	// Include and Exclude filter errors by file as described in FilterPaths.
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// CheckerConfig enables a single checker.
+type CheckerConfig struct {
+	// Name is the name of the checker, such as govet or gocyclo.
+	Name string `json:"name"`
+	// Options are decoded into the exported fields of the checker.
+	Options json.RawMessage `json:"options"`
+}
+
+// LoadConfig reads a JSON config file such as
+//
+//	{
+//		"packages": ["./..."],
+//		"checkers": [
+//			{"name": "gofmt"},
+//			{"name": "gocyclo", "options": {"Max": 10}},
+//			{"name": "linelength", "options": {"Max": 120, "TabWidth": 4}}
+//		],
+//		"exclude": ["**/*.pb.go"]
+//	}
+//
+// and returns a Group of the checkers, along with the packages to pass to Check.
+// Options are decoded into the exported fields of the checker type, so gocyclo
+// accepts the fields of gocyclo.Checker. If include or exclude is set the Group is
+// wrapped using FilterPaths.
+//
+// The checkers that can be enabled are aligncheck, anyparam, appendcheck,
+// benchreset, buildtagname, buildtags, copylocks, coverage, cryptorand,
+// ctxfield, ctxfirst, deferloop, elsereturn, enumstringer, errcheck,
+// errcontext, errorwrap, errstyle, fielddoc, filesize, floateq, generate,
+// gocyclo, gofmt, goimports, golint, gorecover, gosimple, gostaticcheck,
+// govet (govet.Check), httpctx, hugeparam, importorder, imports, inlineerr,
+// jsoncase, license, linelength, loopcapture, magicnum, mutablereturn,
+// nakedret, nesting, nopanic, osexit, params, pkgdoc, pkgname, printf,
+// prodiface, receivers, redundanttype, regexphoisting, resourceleak, senterr,
+// shadow, skippedtests, sortdecls, sqlclose, structcheck, structtags,
+// switchdefault, testpkg, thelper, timecheck, todos, typednil, unexport,
+// unkeyed, varcheck and waitgroup. These run gofmt, golint and govet as
+// external tools; gofmt.Native, golint.Native and govet.Checker run them in
+// process instead. Unknown checker names and options, and malformed include or
+// exclude patterns, are errors.
+// YAML config files are not supported.
+func LoadConfig(path string) (Checker, []string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return nil, nil, fmt.Errorf("%s: YAML config files are not supported, use JSON", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var config Config
+	if err := decodeStrict(data, &config); err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	var group Group
+	for _, cc := range config.Checkers {
+		c, err := cc.checker()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", path, err)
+		}
+		group = append(group, c)
+	}
+	if err := validatePatterns(append(append([]string{}, config.Include...), config.Exclude...)); err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if len(config.Include) > 0 || len(config.Exclude) > 0 {
+		return FilterPaths(group, config.Include, config.Exclude), config.Packages, nil
+	}
+	return group, config.Packages, nil
+}
+
+func (cc CheckerConfig) checker() (Checker, error) {
+	zero, ok := configCheckers[cc.Name]
+	if !ok {
+		var names []string
+		for name := range configCheckers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown checker %q, expected one of %s", cc.Name, strings.Join(names, ", "))
+	}
+	v := reflect.New(reflect.TypeOf(zero))
+	if len(cc.Options) > 0 {
+		if err := decodeStrict(cc.Options, v.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid options for %s: %v", cc.Name, err)
+		}
+	}
+	return v.Elem().Interface().(Checker), nil
+}
+
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,92 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/golint"
+	"github.com/surullabs/lint/govet"
+	"github.com/surullabs/lint/testutil"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	src := "package config\n\nfunc f(a int) int {\n\tif a > 0 && a < 10 {\n\t\treturn 1\n\t}\n\treturn 0\n}\n"
+	tmp, err := testutil.NewGopath("config", []testutil.SourceFile{
+		{Content: []byte(src), Dest: filepath.Join("config", "file.go")},
+		{Content: []byte(src), Dest: filepath.Join("config", "file.pb.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	file := filepath.Join(tmp.Path, "src", "config", "file.go")
+
+	c, pkgs, err := lint.LoadConfig(writeConfig(t, "lint.json", `{
+		"packages": ["config"],
+		"checkers": [
+			{"name": "gocyclo", "options": {"Max": 2}},
+			{"name": "linelength", "options": {"Max": 18, "TabWidth": 4}}
+		],
+		"exclude": ["**/*.pb.go"]
+	}`))
+	assert(t, err == nil && fmt.Sprint(pkgs) == "[config]", fmt.Sprintf("%v %v", err, pkgs))
+	err = c.Check(pkgs...)
+	expected := []string{
+		"gocyclo.Checker: " + file + ":3:1: function f has cyclomatic complexity 3 (> 2)",
+		"linelength.Checker: " + file + ":3: line too long (19 > 18)",
+		"linelength.Checker: " + file + ":4: line too long (24 > 18)",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	// The in process checkers are named after their type.
+	c, _, err = lint.LoadConfig(writeConfig(t, "lint.json", `{"checkers": [
+		{"name": "gofmt.Native", "options": {"Simplify": true}},
+		{"name": "golint.Native"},
+		{"name": "govet.Checker"}
+	]}`))
+	native := lint.Group{gofmt.Native{Simplify: true}, golint.Native{}, govet.Checker{}}
+	assert(t, err == nil && reflect.DeepEqual(c, native), fmt.Sprintf("%v %#v", err, c))
+
+	known := strings.Join([]string{
+		"aligncheck", "anyparam", "appendcheck", "benchreset", "buildtagname",
+		"buildtags", "copylocks", "coverage", "cryptorand", "ctxfield",
+		"ctxfirst", "deferloop", "elsereturn", "enumstringer", "errcheck",
+		"errcontext", "errorwrap", "errstyle", "fielddoc", "filesize",
+		"floateq", "generate", "gocyclo", "gofmt", "gofmt.Native", "goimports",
+		"golint", "golint.Native", "gorecover", "gosimple", "gostaticcheck",
+		"govet", "govet.Checker", "httpctx", "hugeparam", "importorder",
+		"imports", "inlineerr", "jsoncase", "license", "linelength",
+		"loopcapture", "magicnum", "mutablereturn", "nakedret", "nesting",
+		"nopanic", "osexit", "params", "pkgdoc", "pkgname", "printf",
+		"prodiface", "receivers", "redundanttype", "regexphoisting",
+		"resourceleak", "senterr", "shadow", "skippedtests", "sortdecls",
+		"sqlclose", "structcheck", "structtags", "switchdefault", "testpkg",
+		"thelper", "timecheck", "todos", "typednil", "unexport", "unkeyed",
+		"varcheck", "waitgroup",
+	}, ", ")
+	for _, test := range []struct{ name, content, expected string }{
+		{"lint.json", `{"checkers": [{"name": "nosuchlinter"}]}`, `unknown checker "nosuchlinter", expected one of ` + known},
+		{"lint.json", `{"checkers": [{"name": "gocyclo", "options": {"Maximum": 2}}]}`, `invalid options for gocyclo: json: unknown field "Maximum"`},
+		{"lint.json", `{"checker": []}`, `json: unknown field "checker"`},
+		{"lint.json", `{"checkers": [], "exclude": ["["]}`, `invalid pattern "[": syntax error in pattern`},
+		{"lint.yaml", `checkers: []`, `YAML config files are not supported, use JSON`},
+	} {
+		path := writeConfig(t, test.name, test.content)
+		_, _, err := lint.LoadConfig(path)
+		assert(t, err != nil && err.Error() == path+": "+test.expected, fmt.Sprintf("%v", err))
+	}
+}
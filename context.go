@@ -0,0 +1,65 @@
+package lint
+
+import (
+	"context"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// CheckerContext is the interface that wraps the CheckContext method.
+//
+// CheckContext is like Checker.Check but stops early, where possible, when ctx
+// is cancelled.
+type CheckerContext interface {
+	CheckContext(ctx context.Context, pkgs ...string) error
+}
+
+// AsContext returns a CheckerContext for c. Since c cannot observe ctx, the
+// returned CheckerContext only checks ctx before running c.
+func AsContext(c Checker) CheckerContext {
+	return contextAdapter{checker: c}
+}
+
+type contextAdapter struct {
+	checker Checker
+}
+
+func (c contextAdapter) unwrap() interface{} { return c.checker }
+
+// Name returns the name of the wrapped checker.
+func (c contextAdapter) Name() string { return checkerName(c.checker) }
+
+// CheckContext runs the wrapped checker unless ctx is already done.
+func (a contextAdapter) CheckContext(ctx context.Context, pkgs ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.checker.Check(pkgs...)
+}
+
+// GroupContext returns a CheckerContext that applies each of checkers in order,
+// passing ctx to each of them. Errors are flattened and prefixed as they are by
+// Group.Check.
+//
+// Cancellation is checked between checkers so it is honored even when the
+// checkers themselves cannot observe ctx. Once ctx is done the remaining
+// checkers are not run and the errors collected so far are returned, followed by
+// the context error prefixed with the name of the first checker that was skipped.
+func GroupContext(checkers ...CheckerContext) CheckerContext {
+	return groupContext(checkers)
+}
+
+type groupContext []CheckerContext
+
+// CheckContext runs each checker in g until ctx is done.
+func (g groupContext) CheckContext(ctx context.Context, pkgs ...string) error {
+	var errs []string
+	for _, checker := range g {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, checkerName(checker)+": "+err.Error())
+			break
+		}
+		errs = append(errs, prefixErrors(prefixName(checker), checker.CheckContext(ctx, pkgs...))...)
+	}
+	return checkers.Error(errs...)
+}
@@ -0,0 +1,44 @@
+package lint_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+)
+
+func TestGroupContext(t *testing.T) {
+	ctx := context.Background()
+	g := lint.GroupContext(lint.AsContext(expectRecursive))
+	err := g.CheckContext(ctx, "./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	g = lint.GroupContext(lint.AsContext(twoErrors), lint.AsContext(ungroupedError))
+	err = g.CheckContext(ctx, "./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: err1\nlint_test.checkFn: err2\nlint_test.checkFn: ungrouped: 1",
+		fmt.Sprintf("%v", err))
+
+	// Cancel after the first checker runs. The second must not run.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelling := checkFn(func(pkgs ...string) error {
+		cancel()
+		return twoErrors(pkgs...)
+	})
+	ran := false
+	second := checkFn(func(...string) error {
+		ran = true
+		return nil
+	})
+	g = lint.GroupContext(lint.AsContext(cancelling), lint.AsContext(second))
+	err = g.CheckContext(ctx, "./...")
+	assert(t, !ran, "checker ran after cancellation")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: err1\nlint_test.checkFn: err2\nlint_test.checkFn: context canceled",
+		fmt.Sprintf("%v", err))
+
+	// An adapter does not run its checker once ctx is done.
+	err = lint.AsContext(second).CheckContext(ctx, "./...")
+	assert(t, !ran && err == context.Canceled, fmt.Sprintf("%v", err))
+}
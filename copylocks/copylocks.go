@@ -0,0 +1,198 @@
+// Package copylocks provides lint integration for finding values containing
+// locks that are copied.
+package copylocks
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports copies of values containing a
+// lock, such as a sync.Mutex, in .go files, including test files. A type
+// contains a lock if it is, or has a field or array element that contains, a
+// type implementing sync.Locker with pointer receivers. Copying such a value
+// copies the state of the lock, which is almost never intended.
+//
+// Only copies of existing values are reported. Returning or storing a new value,
+// such as a composite literal or the result of a call, is not a copy. Returned
+// and stored copies are reported unless skipped, and copies in composite
+// literals and range loops are reported if enabled.
+type Checker struct {
+	// SkipReturn disables reporting values returned by value.
+	SkipReturn bool
+	// SkipStore disables reporting values stored by value in a slice or map
+	// element, either by assignment or using append.
+	SkipStore bool
+	// CompositeLit reports values used as elements or fields of composite
+	// literals.
+	CompositeLit bool
+	// Range reports range loops whose value variable is a copy of each
+	// element.
+	Range bool
+}
+
+// Check reports each copy in pkgs as
+//
+//	file.go:line:col: struct containing sync.Mutex is copied by value
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each copy of a lock in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports copies of locks in the packages of l, which may be shared
+// with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		report := func(pos token.Pos, typ types.Type) {
+			if msg := copyMessage(typ); msg != "" {
+				diags = append(diags, s.Diagnostic(pos, "%s", msg))
+			}
+		}
+		copied := func(e ast.Expr) {
+			if isCopy(e) {
+				report(e.Pos(), info.TypeOf(e))
+			}
+		}
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.ReturnStmt:
+						if !c.SkipReturn {
+							for _, r := range n.Results {
+								copied(r)
+							}
+						}
+					case *ast.AssignStmt:
+						if !c.SkipStore && len(n.Lhs) == len(n.Rhs) {
+							for i, lhs := range n.Lhs {
+								if _, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+									copied(n.Rhs[i])
+								}
+							}
+						}
+					case *ast.CallExpr:
+						if !c.SkipStore && isAppend(info, n) {
+							for _, arg := range n.Args[1:] {
+								copied(arg)
+							}
+						}
+					case *ast.CompositeLit:
+						if c.CompositeLit {
+							for _, elt := range n.Elts {
+								if kv, ok := elt.(*ast.KeyValueExpr); ok {
+									elt = kv.Value
+								}
+								copied(elt)
+							}
+						}
+					case *ast.RangeStmt:
+						if c.Range && n.Value != nil {
+							if id, ok := n.Value.(*ast.Ident); !ok || id.Name != "_" {
+								report(n.Value.Pos(), info.TypeOf(n.Value))
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// isCopy reports whether e is an existing value rather than a new one.
+func isCopy(e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		return e.Name != "nil"
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+		return true
+	}
+	return false
+}
+
+// isAppend reports whether call calls the append builtin with a value to append.
+func isAppend(info *types.Info, call *ast.CallExpr) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == "append"
+}
+
+// copyMessage returns the message for a copy of a value of type typ, or "" if
+// typ contains no lock.
+func copyMessage(typ types.Type) string {
+	if typ == nil {
+		return ""
+	}
+	lock := findLock(typ, map[types.Type]bool{})
+	switch {
+	case lock == nil:
+		return ""
+	case types.Identical(lock, typ):
+		return fmt.Sprintf("%s is copied by value", typeName(lock))
+	}
+	kind := "value"
+	if _, ok := typ.Underlying().(*types.Struct); ok {
+		kind = "struct"
+	} else if _, ok := typ.Underlying().(*types.Array); ok {
+		kind = "array"
+	}
+	return fmt.Sprintf("%s containing %s is copied by value", kind, typeName(lock))
+}
+
+// findLock returns the first lock contained in typ, or nil if there is none.
+func findLock(typ types.Type, seen map[types.Type]bool) types.Type {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+	if _, ok := typ.(*types.Named); ok && isLock(typ) {
+		return typ
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if lock := findLock(u.Field(i).Type(), seen); lock != nil {
+				return lock
+			}
+		}
+	case *types.Array:
+		return findLock(u.Elem(), seen)
+	}
+	return nil
+}
+
+// isLock reports whether *typ, but not typ, has Lock and Unlock methods.
+func isLock(typ types.Type) bool {
+	if _, ok := typ.Underlying().(*types.Interface); ok {
+		return false
+	}
+	has := func(t types.Type) bool {
+		ms := types.NewMethodSet(t)
+		return ms.Lookup(nil, "Lock") != nil && ms.Lookup(nil, "Unlock") != nil
+	}
+	return has(types.NewPointer(typ)) && !has(typ)
+}
+
+// typeName returns the name of typ qualified by its package name.
+func typeName(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string { return p.Name() })
+}
@@ -0,0 +1,109 @@
+package copylocks_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/copylocks"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package copylockstest
+
+import "sync"
+
+type Counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+type Cache struct {
+	counters [2]Counter
+}
+
+func Get(c *Counter) Counter {
+	return *c
+}
+
+func New() Counter {
+	return Counter{}
+}
+
+func Store(m map[string]Counter, all []Counter, c Counter, ptr *Counter) []Counter {
+	m["a"] = c
+	m["b"] = Counter{}
+	all[0] = *ptr
+	return append(all, c, Counter{})
+}
+
+func Literals(c Counter, mu *sync.RWMutex) (Cache, []Counter, *Counter) {
+	return Cache{counters: [2]Counter{c, {}}}, []Counter{c}, &Counter{n: c.n}
+}
+
+func RangeCopy(all []Counter, caches []Cache, ptrs []*Counter) {
+	for _, c := range all {
+		_ = c.n
+	}
+	for i := range all {
+		_ = all[i].n
+	}
+	for _, c := range caches {
+		_ = c
+	}
+	for _, c := range ptrs {
+		_ = c
+	}
+}
+
+func Lock(mu *sync.RWMutex) sync.RWMutex {
+	return *mu
+}
+`
+
+func TestCopyLocks(t *testing.T) {
+	testutil.Test(t, "copylockstest", []testutil.StaticCheckTest{
+		{
+			Checker: copylocks.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:9: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:23:11: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:25:11: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:26:21: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:49:9: sync\.RWMutex is copied by value$`),
+		},
+		{
+			Checker:  copylocks.Checker{SkipReturn: true, SkipStore: true},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: copylocks.Checker{SkipStore: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:9: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:49:9: sync\.RWMutex is copied by value$`),
+		},
+		{
+			Checker: copylocks.Checker{SkipReturn: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:23:11: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:25:11: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:26:21: struct containing sync\.Mutex is copied by value$`),
+		},
+		{
+			Checker: copylocks.Checker{SkipReturn: true, SkipStore: true, CompositeLit: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:30:36: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:30:55: struct containing sync\.Mutex is copied by value$`),
+		},
+		{
+			Checker: copylocks.Checker{SkipReturn: true, SkipStore: true, Range: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:34:9: struct containing sync\.Mutex is copied by value\n` +
+				`[^\n]*file\.go:40:9: struct containing sync\.Mutex is copied by value$`),
+		},
+		{
+			Checker:  copylocks.Checker{},
+			Content:  []byte("package copylockstest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,204 @@
+// Package coverage provides lint integration for checking that exported
+// functions have tests or examples.
+package coverage
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported functions, and exported
+// methods of exported types, that have no test or example in the _test.go files
+// of their package. This is checked statically and says nothing about how much
+// of a function its tests run.
+//
+// A function Foo, or a method T.Foo, has a test if there is a test function named
+// TestFoo or TestT_Foo, optionally followed by an underscore and a suffix, or if
+// Foo is referenced anywhere in the test files, such as from the table of a table
+// driven test. References are found by name, so they may be mistaken for one
+// another. It has an example if there is an example function named ExampleFoo or
+// ExampleT_Foo, optionally followed by a suffix starting with an underscore and a
+// lower case letter.
+type Checker struct {
+	// RequireTest requires every exported function to have a test.
+	RequireTest bool
+	// RequireExample requires every exported function to have an example. If
+	// neither RequireTest nor RequireExample is set, either one is enough.
+	RequireExample bool
+	// SkipGenerated ignores functions in files with a
+	// "// Code generated ... DO NOT EDIT." comment.
+	SkipGenerated bool
+	// SkipInternal does not check packages with an internal element in their
+	// import path.
+	SkipInternal bool
+}
+
+// Check reports each exported function in pkgs without a test or example as
+//
+//	file.go:line:col: exported Foo has no test or example
+//
+// The message says "no test" or "no example" instead if only one of them is
+// missing but required.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each exported function in pkgs
+// without a test or example.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		if c.SkipInternal && isInternal(s.ImportPath) {
+			return nil
+		}
+		t := scanTests(append(append([]*ast.File{}, s.TestFiles...), s.XTestFiles...))
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			if c.SkipGenerated && ast.IsGenerated(f) {
+				continue
+			}
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || !fn.Name.IsExported() {
+					continue
+				}
+				recv, ok := recvName(fn)
+				if !ok {
+					continue
+				}
+				name, display := fn.Name.Name, fn.Name.Name
+				if recv != "" {
+					name, display = recv+"_"+name, recv+"."+name
+				}
+				if missing := c.missing(t, fn.Name.Name, name); missing != "" {
+					diags = append(diags, s.Diagnostic(fn.Name.Pos(), "exported %s has no %s", display, missing))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// missing returns what fn, named name in test and example function names, is
+// missing, or "" if it has everything that is required.
+func (c Checker) missing(t tests, fn, name string) string {
+	hasTest := t.referenced[fn] || hasFunc(t.tests, name, false)
+	hasExample := hasFunc(t.examples, name, true)
+	switch {
+	case c.RequireTest && c.RequireExample && !hasTest && !hasExample:
+		return "test or example"
+	case c.RequireTest && !hasTest:
+		return "test"
+	case c.RequireExample && !hasExample:
+		return "example"
+	case !c.RequireTest && !c.RequireExample && !hasTest && !hasExample:
+		return "test or example"
+	}
+	return ""
+}
+
+// tests holds what was found in the test files of a package.
+type tests struct {
+	// tests and examples hold the names of test and example functions without
+	// the Test or Example prefix.
+	tests, examples []string
+	// referenced holds the identifiers used in the test files.
+	referenced map[string]bool
+}
+
+func scanTests(files []*ast.File) tests {
+	t := tests{referenced: map[string]bool{}}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			name := fn.Name.Name
+			switch {
+			case strings.HasPrefix(name, "Test"):
+				t.tests = append(t.tests, strings.TrimPrefix(name, "Test"))
+			case strings.HasPrefix(name, "Example"):
+				t.examples = append(t.examples, strings.TrimPrefix(name, "Example"))
+			}
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				// Only look inside test and helper functions, not at their names.
+				if n.Body != nil {
+					ast.Inspect(n.Body, func(n ast.Node) bool {
+						if id, ok := n.(*ast.Ident); ok {
+							t.referenced[id.Name] = true
+						}
+						return true
+					})
+				}
+				return false
+			case *ast.Ident:
+				t.referenced[n.Name] = true
+			}
+			return true
+		})
+	}
+	return t
+}
+
+// hasFunc reports whether names holds name, optionally followed by a suffix
+// starting with an underscore. Suffixes of examples must continue with a lower
+// case letter, as required by go test.
+func hasFunc(names []string, name string, example bool) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+		if !strings.HasPrefix(n, name+"_") {
+			continue
+		}
+		suffix := n[len(name)+1:]
+		if !example {
+			return true
+		}
+		if r, _ := utf8.DecodeRuneInString(suffix); unicode.IsLower(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// recvName returns the name of the receiver type of fn, or "" if fn is not a
+// method. It returns false for methods of unexported types.
+func recvName(fn *ast.FuncDecl) (string, bool) {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return "", true
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name, t.IsExported()
+		}
+		return "", false
+	}
+}
+
+func isInternal(path string) bool {
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+	return false
+}
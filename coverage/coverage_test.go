@@ -0,0 +1,132 @@
+package coverage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/coverage"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package coveragetest
+
+type T struct{}
+
+func (T) Tested()    {}
+func (T) Example()   {}
+func (*T) Untested() {}
+
+type t struct{}
+
+func (t) Missing() {}
+
+func Tested()     {}
+func Referenced() {}
+func Example()    {}
+func Missing()    {}
+func unexported() {}
+`
+
+const test = `package coveragetest
+
+import "testing"
+
+func TestTested_cases(t *testing.T) {}
+
+func TestT_Tested(t *testing.T) {}
+
+var table = []func(){Referenced}
+`
+
+const example = `package coveragetest_test
+
+import "coveragetest"
+
+func ExampleExample() {}
+
+func ExampleT_Example_second() {}
+
+func ExampleMissing_Bad() { coveragetest.Missing() }
+`
+
+func TestCoverage(t *testing.T) {
+	tests := []struct {
+		checker  coverage.Checker
+		validate func(error) error
+	}{
+		{
+			checker:  coverage.Checker{},
+			validate: testutil.MatchesRegexp(`file\.go:7:11: exported T\.Untested has no test or example$`),
+		},
+		{
+			checker: coverage.Checker{RequireTest: true},
+			validate: testutil.MatchesRegexp(`file\.go:6:10: exported T\.Example has no test\n` +
+				`.*file\.go:7:11: exported T\.Untested has no test\n` +
+				`.*file\.go:15:6: exported Example has no test$`),
+		},
+		{
+			checker: coverage.Checker{RequireExample: true},
+			validate: testutil.MatchesRegexp(`file\.go:5:10: exported T\.Tested has no example\n` +
+				`.*file\.go:7:11: exported T\.Untested has no example\n` +
+				`.*file\.go:13:6: exported Tested has no example\n` +
+				`.*file\.go:14:6: exported Referenced has no example\n` +
+				`.*file\.go:16:6: exported Missing has no example$`),
+		},
+		{
+			checker: coverage.Checker{RequireTest: true, RequireExample: true},
+			validate: testutil.MatchesRegexp(`file\.go:5:10: exported T\.Tested has no example\n` +
+				`.*file\.go:6:10: exported T\.Example has no test\n` +
+				`.*file\.go:7:11: exported T\.Untested has no test or example\n` +
+				`.*file\.go:13:6: exported Tested has no example\n` +
+				`.*file\.go:14:6: exported Referenced has no example\n` +
+				`.*file\.go:15:6: exported Example has no test\n` +
+				`.*file\.go:16:6: exported Missing has no example$`),
+		},
+	}
+	for i, tc := range tests {
+		checkers.Unload("coveragetest")
+		tmp, err := testutil.NewGopath("coveragetest", []testutil.SourceFile{
+			{Content: []byte(src), Dest: filepath.Join("coveragetest", "file.go")},
+			{Content: []byte(test), Dest: filepath.Join("coveragetest", "file_test.go")},
+			{Content: []byte(example), Dest: filepath.Join("coveragetest", "example_test.go")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tc.validate(tc.checker.Check("coveragetest")); err != nil {
+			t.Error(i, err)
+		}
+		tmp.Reset()
+	}
+}
+
+func TestCoverageSkip(t *testing.T) {
+	testutil.Test(t, "coveragetest", []testutil.StaticCheckTest{
+		{
+			Checker:  coverage.Checker{},
+			Content:  []byte("package coveragetest\n\nfunc Foo() {}\n"),
+			Validate: testutil.HasSuffix("file.go:3:6: exported Foo has no test or example"),
+		},
+		{
+			Checker:  coverage.Checker{SkipGenerated: true},
+			Content:  []byte("// Code generated by hand. DO NOT EDIT.\n\npackage coveragetest\n\nfunc Foo() {}\n"),
+			Validate: testutil.NoError,
+		},
+	})
+
+	checkers.Unload("coveragetest/internal/foo")
+	tmp, err := testutil.NewGopath("coveragetest", []testutil.SourceFile{
+		{Content: []byte("package foo\n\nfunc Foo() {}\n"), Dest: filepath.Join("coveragetest", "internal", "foo", "file.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := (coverage.Checker{SkipInternal: true}).Check("coveragetest/internal/foo"); err != nil {
+		t.Error(err)
+	}
+	if err := (coverage.Checker{}).Check("coveragetest/internal/foo"); err == nil {
+		t.Error("expected an error without SkipInternal")
+	}
+}
@@ -0,0 +1,162 @@
+// Package cryptorand provides lint integration for finding uses of math/rand.
+package cryptorand
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports calls to the functions of
+// math/rand and math/rand/v2, and to the methods of their types such as
+// *rand.Rand, in .go files including test files. Their values are predictable,
+// so tokens, keys and identifiers generated with them can be guessed. Functions
+// creating a generator or source, such as rand.New, are not reported, since
+// calls to its methods are.
+//
+// Calls are resolved using go/types, so a package of the module named rand is
+// not reported. A call in a function literal is allowed if the function
+// declaring the literal is. Reports can be suppressed with a comment on the line
+// of the call or the line before it of the form
+//
+//	//nolint:cryptorand
+type Checker struct {
+	// Allow holds path.Match patterns for the names of functions in which
+	// math/rand may be used, such as "jitter". Methods match both their name and
+	// their name qualified by the receiver type, as in "Backoff.Next".
+	Allow []string
+	// AllowFiles holds path.Match patterns for the base names of files in which
+	// math/rand may be used, such as "*_test.go".
+	AllowFiles []string
+}
+
+// Check reports each call to math/rand in pkgs as
+//
+//	file.go:line:col: math/rand used; use crypto/rand for security-sensitive randomness
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each call to math/rand in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports calls to math/rand in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range append(append([]string{}, c.Allow...), c.AllowFiles...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				if match(c.AllowFiles, filepath.Base(s.Fset.Position(f.Pos()).Filename)) {
+					continue
+				}
+				suppressed := checkers.NoLintLines(s.Fset, f, "cryptorand.Checker")
+				for _, decl := range f.Decls {
+					if fn, ok := decl.(*ast.FuncDecl); ok && c.allowed(fn) {
+						continue
+					}
+					ast.Inspect(decl, func(n ast.Node) bool {
+						call, ok := n.(*ast.CallExpr)
+						if !ok {
+							return true
+						}
+						pkg := randPackage(info, call)
+						if pkg == "" {
+							return true
+						}
+						if line := s.Fset.Position(call.Pos()).Line; suppressed[line] || suppressed[line-1] {
+							return true
+						}
+						diags = append(diags, s.Diagnostic(call.Pos(), "%s used; use crypto/rand for security-sensitive randomness", pkg))
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether fn may use math/rand.
+func (c Checker) allowed(fn *ast.FuncDecl) bool {
+	if match(c.Allow, fn.Name.Name) {
+		return true
+	}
+	recv := recvName(fn)
+	return recv != "" && match(c.Allow, recv+"."+fn.Name.Name)
+}
+
+// match reports whether name matches any of patterns.
+func match(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// randPackage returns math/rand or math/rand/v2 if call is a reported call to a
+// function or method of that package, or "" otherwise.
+func randPackage(info *types.Info, call *ast.CallExpr) string {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return ""
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	switch p := fn.Pkg().Path(); p {
+	case "math/rand", "math/rand/v2":
+		if fn.Type().(*types.Signature).Recv() == nil && strings.HasPrefix(fn.Name(), "New") {
+			return ""
+		}
+		return p
+	}
+	return ""
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
@@ -0,0 +1,84 @@
+package cryptorand_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/cryptorand"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package cryptorandtest
+
+import (
+	"math/rand"
+	"time"
+
+	randv2 "math/rand/v2"
+)
+
+func Token() int64 {
+	return rand.Int63()
+}
+
+func Shuffled(ids []int) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+}
+
+func jitter() time.Duration {
+	return time.Duration(randv2.IntN(100)) * time.Millisecond
+}
+
+type fake struct{}
+
+func (fake) Intn(n int) int { return 0 }
+
+func Local() int {
+	rand := fake{}
+	return rand.Intn(3)
+}
+
+func Ignored() int {
+	return rand.Intn(3) //nolint:cryptorand
+}
+
+var seed = rand.Int()
+`
+
+func TestCryptoRand(t *testing.T) {
+	testutil.Test(t, "cryptorandtest", []testutil.StaticCheckTest{
+		{
+			Checker:  cryptorand.Checker{},
+			Content:  []byte("package cryptorandtest\n\nimport \"crypto/rand\"\n\nfunc f(b []byte) { _, _ = rand.Read(b) }\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: cryptorand.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:9: math/rand used; use crypto/rand for security-sensitive randomness\n` +
+				`[^\n]*file\.go:16:2: math/rand used; use crypto/rand for security-sensitive randomness\n` +
+				`[^\n]*file\.go:20:23: math/rand/v2 used; use crypto/rand for security-sensitive randomness\n` +
+				`[^\n]*file\.go:36:12: math/rand used; use crypto/rand for security-sensitive randomness$`),
+		},
+		{
+			Checker:  cryptorand.Checker{Allow: []string{"jitter", "Token", "Shuffle*"}},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:36:12: math/rand used; use crypto/rand for security-sensitive randomness$`),
+		},
+		{
+			Checker:  cryptorand.Checker{AllowFiles: []string{"f*.go"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  cryptorand.Checker{AllowFiles: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+		{
+			Checker:  cryptorand.Checker{},
+			Content:  []byte("package cryptorandtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,140 @@
+// Package ctxfield provides lint integration for finding contexts stored in
+// struct fields.
+package ctxfield
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports struct fields of type
+// context.Context in .go files, including test files. A Context is scoped to a
+// call and should be passed to each function that needs it rather than stored.
+// Fields are resolved using go/types, so interfaces embedding context.Context
+// and embedded context.Context fields are reported as well.
+type Checker struct {
+	// Allow holds path.Match patterns for the names of struct types that may
+	// store a Context, such as request-scoped carriers. Fields of anonymous
+	// structs declared in an allowed type are allowed too.
+	Allow []string
+	// SkipGenerated ignores files with a "// Code generated ... DO NOT EDIT."
+	// comment.
+	SkipGenerated bool
+}
+
+// Check reports each struct field storing a Context in pkgs as
+//
+//	file.go:line:col: struct field ctx of type context.Context; pass Context as an argument instead
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each struct field storing a Context
+// in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports struct fields storing a Context in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		pkg, info, _ := s.TypeCheck(true)
+		qualifier := func(p *types.Package) string {
+			if pkg != nil && p.Path() == pkg.Path() {
+				return ""
+			}
+			return p.Name()
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				if c.SkipGenerated && ast.IsGenerated(f) {
+					continue
+				}
+				ast.Inspect(f, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.TypeSpec:
+						return !c.allowed(n.Name.Name)
+					case *ast.StructType:
+						for _, field := range n.Fields.List {
+							typ := info.TypeOf(field.Type)
+							if typ == nil || !isContext(typ, map[types.Type]bool{}) {
+								continue
+							}
+							names := field.Names
+							if len(names) == 0 {
+								names = []*ast.Ident{{Name: embeddedName(field.Type), NamePos: field.Type.Pos()}}
+							}
+							for _, name := range names {
+								diags = append(diags, s.Diagnostic(name.Pos(), "struct field %s of type %s; pass Context as an argument instead",
+									name.Name, types.TypeString(typ, qualifier)))
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether the struct type name matches one of Allow.
+func (c Checker) allowed(name string) bool {
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isContext reports whether typ is context.Context or an interface embedding it.
+func isContext(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context" {
+			return true
+		}
+	}
+	iface, ok := typ.Underlying().(*types.Interface)
+	if !ok {
+		return false
+	}
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		if isContext(iface.EmbeddedType(i), seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedName returns the name of an embedded field of type typ.
+func embeddedName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
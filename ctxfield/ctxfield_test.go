@@ -0,0 +1,88 @@
+package ctxfield_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/ctxfield"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package ctxfieldtest
+
+import "context"
+
+type Server struct {
+	ctx  context.Context
+	name string
+}
+
+type Embedded struct {
+	context.Context
+}
+
+type Carrier interface {
+	context.Context
+	ID() string
+}
+
+type Request struct {
+	carrier Carrier
+	opts    struct {
+		ctx context.Context
+	}
+}
+
+type Pair struct {
+	a, b context.Context
+}
+
+type Func struct {
+	fn func(ctx context.Context)
+}
+`
+
+const generated = `// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package ctxfieldtest
+
+import "context"
+
+type Server struct {
+	ctx context.Context
+}
+`
+
+func TestCtxField(t *testing.T) {
+	testutil.Test(t, "ctxfieldtest", []testutil.StaticCheckTest{
+		{
+			Checker: ctxfield.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:6:2: struct field ctx of type context\.Context; pass Context as an argument instead\n` +
+				`[^\n]*file\.go:11:2: struct field Context of type context\.Context; pass Context as an argument instead\n` +
+				`[^\n]*file\.go:20:2: struct field carrier of type Carrier; pass Context as an argument instead\n` +
+				`[^\n]*file\.go:22:3: struct field ctx of type context\.Context; pass Context as an argument instead\n` +
+				`[^\n]*file\.go:27:2: struct field a of type context\.Context; pass Context as an argument instead\n` +
+				`[^\n]*file\.go:27:5: struct field b of type context\.Context; pass Context as an argument instead$`),
+		},
+		{
+			Checker:  ctxfield.Checker{Allow: []string{"Server", "Embedded", "Req*", "Pair"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  ctxfield.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+		{
+			Checker:  ctxfield.Checker{},
+			Content:  []byte(generated),
+			Validate: testutil.Contains("struct field ctx of type context.Context"),
+		},
+		{
+			Checker:  ctxfield.Checker{SkipGenerated: true},
+			Content:  []byte(generated),
+			Validate: testutil.NoError,
+		},
+	})
+}
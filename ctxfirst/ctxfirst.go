@@ -0,0 +1,134 @@
+// Package ctxfirst provides lint integration for checking that functions take a
+// context.Context as their first parameter.
+package ctxfirst
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports functions and methods, in .go
+// files including test files, that take a context.Context other than as their
+// first parameter. If Require is set, exported functions and methods whose name
+// matches it must also take a context.Context as their first parameter, so
+//
+//	ctxfirst.Checker{Require: `Request$`}
+//
+// reports an exported FetchRequest(id string). Parameters are matched using
+// go/types, so aliases of context.Context are recognized.
+type Checker struct {
+	// Require is a regular expression matching the names of exported functions
+	// and methods that must take a context.Context. It is matched against the
+	// name without the receiver type.
+	Require string
+	// SkipGenerated ignores files with a "// Code generated ... DO NOT EDIT."
+	// comment.
+	SkipGenerated bool
+}
+
+// Check reports each function in pkgs that should take a context.Context first
+// as
+//
+//	file.go:line:col: context.Context should be the first parameter of Foo
+//
+// Methods are named as T.Foo.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each function in pkgs that should
+// take a context.Context first.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports functions that should take a context.Context first in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	var require *regexp.Regexp
+	if c.Require != "" {
+		var err error
+		if require, err = regexp.Compile(c.Require); err != nil {
+			return nil, fmt.Errorf("invalid Require pattern: %v", err)
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				if c.SkipGenerated && ast.IsGenerated(f) {
+					continue
+				}
+				for _, decl := range f.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok {
+						continue
+					}
+					obj, ok := info.Defs[fn.Name].(*types.Func)
+					if !ok {
+						continue
+					}
+					if needsContext(obj, require) {
+						diags = append(diags, s.Diagnostic(fn.Name.Pos(), "context.Context should be the first parameter of %s", funcName(fn)))
+					}
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// needsContext reports whether fn takes a context.Context other than first or, if it
+// is exported and matches require, does not take one first.
+func needsContext(fn *types.Func, require *regexp.Regexp) bool {
+	params := fn.Type().(*types.Signature).Params()
+	for i := 1; i < params.Len(); i++ {
+		if isContext(params.At(i).Type()) {
+			return true
+		}
+	}
+	if require == nil || !fn.Exported() || !require.MatchString(fn.Name()) {
+		return false
+	}
+	return params.Len() == 0 || !isContext(params.At(0).Type())
+}
+
+// isContext reports whether t is context.Context.
+func isContext(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+}
+
+// funcName returns the name of fn, qualified by its receiver type for methods.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch x := t.(type) {
+	case *ast.IndexExpr:
+		t = x.X
+	case *ast.IndexListExpr:
+		t = x.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
@@ -0,0 +1,70 @@
+package ctxfirst_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/ctxfirst"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package ctxfirsttest
+
+import (
+	"context"
+	stdctx "context"
+)
+
+type Client struct{}
+
+func (c *Client) Get(ctx context.Context, id string) error { return nil }
+func (c *Client) Put(id string, ctx context.Context) error { return nil }
+func (c *Client) FetchRequest(id string) error { return nil }
+func (c *Client) StoreRequest(ctx stdctx.Context) error { return nil }
+func fetchRequest(id string) error { return nil }
+func Send(id string, ctx context.Context, opts ...string) {}
+func DeleteRequest() {}
+func Options(opts ...context.Context) {}
+`
+
+const generated = `// Code generated by hand. DO NOT EDIT.
+
+package ctxfirsttest
+
+import "context"
+
+func Put(id string, ctx context.Context) {}
+`
+
+func TestCtxFirst(t *testing.T) {
+	testutil.Test(t, "ctxfirsttest", []testutil.StaticCheckTest{
+		{
+			Checker: ctxfirst.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:18: context.Context should be the first parameter of Client\.Put\n` +
+				`.*file\.go:15:6: context.Context should be the first parameter of Send$`),
+		},
+		{
+			Checker: ctxfirst.Checker{Require: `Request$`},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:18: context.Context should be the first parameter of Client\.Put\n` +
+				`.*file\.go:12:18: context.Context should be the first parameter of Client\.FetchRequest\n` +
+				`.*file\.go:15:6: context.Context should be the first parameter of Send\n` +
+				`.*file\.go:16:6: context.Context should be the first parameter of DeleteRequest$`),
+		},
+		{
+			Checker:  ctxfirst.Checker{Require: `(`},
+			Content:  []byte(src),
+			Validate: testutil.Contains("invalid Require pattern"),
+		},
+		{
+			Checker:  ctxfirst.Checker{},
+			Content:  []byte(generated),
+			Validate: testutil.HasSuffix("file.go:7:6: context.Context should be the first parameter of Put"),
+		},
+		{
+			Checker:  ctxfirst.Checker{SkipGenerated: true},
+			Content:  []byte(generated),
+			Validate: testutil.NoError,
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package lint
+
+import "github.com/surullabs/lint/checkers"
+
+// Dedup returns a Checker that runs c and removes duplicate errors, keeping the
+// first instance of each in the original order.
+//
+// If c is a Group, its errors have already been prefixed with the checker type
+// when Dedup sees them. The prefixes of the checkers in c are ignored when
+// comparing errors, so
+//
+//	govet.Check: file.go:23: err is shadowed
+//	shadow.Check: file.go:23: err is shadowed
+//
+// are duplicates and only the first is kept, prefix included. Any other text
+// before a colon, such as in errors from a checker that is not in a group, is
+// compared as is. Errors that do not implement the errors interface described in
+// Skip are returned unmodified.
+func Dedup(c Checker) Checker {
+	return dedup{checker: c}
+}
+
+type dedup struct {
+	checker Checker
+}
+
+func (d dedup) unwrap() interface{} { return d.checker }
+
+// Name returns the name of the wrapped checker.
+func (d dedup) Name() string { return checkerName(d.checker) }
+
+// Check runs the wrapped checker and removes duplicate errors.
+func (d dedup) Check(pkgs ...string) error {
+	err := d.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok {
+		return err
+	}
+	prefixes := map[string]bool{}
+	groupPrefixes(d.checker, prefixes)
+	seen := map[string]bool{}
+	var errs []string
+	for _, e := range list.Errors() {
+		key := stripPrefix(e, prefixes)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		errs = append(errs, e)
+	}
+	return checkers.Error(errs...)
+}
+
+// groupPrefixes adds the names used to prefix errors from the checkers in
+// checker to prefixes, if checker is a group. Nested groups are searched as their
+// errors are not prefixed again.
+func groupPrefixes(checker interface{}, prefixes map[string]bool) {
+	var children []interface{}
+	switch c := checker.(type) {
+	case composite:
+		for _, child := range c.children() {
+			children = append(children, child)
+		}
+	case groupContext:
+		for _, child := range c {
+			children = append(children, child)
+		}
+	case wrapper:
+		groupPrefixes(c.unwrap(), prefixes)
+	}
+	for _, child := range children {
+		if name := prefixName(child); name != "" {
+			prefixes[name] = true
+		} else {
+			groupPrefixes(child, prefixes)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+type otherFn func(pkgs ...string) error
+
+func (c otherFn) Check(pkgs ...string) error { return c(pkgs...) }
+
+func TestDedup(t *testing.T) {
+	repeated := checkFn(func(...string) error {
+		return checkers.Error("file.go:23: err is shadowed", "err2", "file.go:23: err is shadowed")
+	})
+	err := lint.Dedup(repeated).Check("./...")
+	assert(t, err != nil && err.Error() == "file.go:23: err is shadowed\nerr2", fmt.Sprintf("%v", err))
+
+	// Prefixes are ignored when comparing.
+	shadow := otherFn(func(...string) error { return checkers.Error("file.go:23: err is shadowed") })
+	err = lint.Dedup(lint.Group{repeated, shadow}).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: file.go:23: err is shadowed\nlint_test.checkFn: err2",
+		fmt.Sprintf("%v", err))
+
+	// Only the prefixes added by a Group are ignored.
+	unprefixed := checkFn(func(...string) error { return checkers.Error("foo: failed", "bar: failed") })
+	err = lint.Dedup(unprefixed).Check("./...")
+	assert(t, err != nil && err.Error() == "foo: failed\nbar: failed", fmt.Sprintf("%v", err))
+	err = lint.Dedup(lint.Group{lint.Group{unprefixed}, shadow}).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: foo: failed\nlint_test.checkFn: bar: failed\nlint_test.otherFn: file.go:23: err is shadowed",
+		fmt.Sprintf("%v", err))
+
+	err = lint.Dedup(ungroupedError).Check("./...")
+	assert(t, err != nil && err.Error() == "ungrouped: 1", fmt.Sprintf("%v", err))
+
+	err = lint.Dedup(expectRecursive).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+}
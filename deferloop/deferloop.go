@@ -0,0 +1,122 @@
+// Package deferloop provides lint integration for finding defer statements in
+// loops.
+package deferloop
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports defer statements in the body of a
+// for or range loop. Deferred calls only run when the function returns, so they
+// accumulate for as long as the loop runs. A defer in a function literal inside
+// the loop runs when the literal returns and is not reported, nor is a loop
+// inside a function literal that is deferred.
+//
+// Test files are checked along with the package. Reports can be suppressed with
+// a comment on the line of the defer or the line before it of the form
+//
+//	//nolint:deferloop
+type Checker struct{}
+
+// Check reports each defer statement in a loop in pkgs as
+//
+//	file.go:line:col: defer inside loop accumulates until function return
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each defer statement in a loop in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				diags = append(diags, checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each defer statement in a loop in files as described in
+// Check.
+func (c Checker) CheckFiles(files ...string) error {
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each defer statement in a loop in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return checkFile(fset, f)
+}
+
+func checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	suppressed := checkers.NoLintLines(fset, f, "deferloop.Checker")
+	var diags []checkers.Diagnostic
+	// walk inspects n, where inLoop reports whether n is in the body of a loop
+	// of the innermost enclosing function.
+	var walk func(n ast.Node, inLoop bool)
+	walk = func(n ast.Node, inLoop bool) {
+		ast.Inspect(n, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.FuncLit:
+				walk(node.Body, false)
+				return false
+			case *ast.ForStmt:
+				if node != n {
+					walk(node, true)
+					return false
+				}
+			case *ast.RangeStmt:
+				if node != n {
+					walk(node, true)
+					return false
+				}
+			case *ast.DeferStmt:
+				if !inLoop {
+					return true
+				}
+				if line := fset.Position(node.Pos()).Line; suppressed[line] || suppressed[line-1] {
+					return true
+				}
+				p := fset.Position(node.Pos())
+				diags = append(diags, checkers.Diagnostic{
+					File:    p.Filename,
+					Line:    p.Line,
+					Col:     p.Column,
+					Message: "defer inside loop accumulates until function return",
+				})
+			}
+			return true
+		})
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			walk(fn.Body, false)
+		} else {
+			// Function literals in package level variables.
+			walk(decl, false)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,80 @@
+package deferloop_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/deferloop"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package deferlooptest
+
+import "os"
+
+func Files(names []string) {
+	for _, name := range names {
+		f, _ := os.Open(name)
+		defer f.Close()
+	}
+	for i := 0; i < 3; i++ {
+		if i > 1 {
+			defer println(i)
+		}
+	}
+}
+
+func Literal(names []string) {
+	defer func() {
+		for range names {
+		}
+	}()
+	for _, name := range names {
+		func() {
+			f, _ := os.Open(name)
+			defer f.Close()
+		}()
+		go func() {
+			for {
+				defer println(name)
+			}
+		}()
+	}
+}
+
+func Ignored(names []string) {
+	for range names {
+		defer println() //nolint:deferloop
+		//nolint
+		defer println()
+	}
+}
+
+var watch = func(chs []chan int) {
+	for _, ch := range chs {
+		defer close(ch)
+	}
+}
+`
+
+func TestDeferLoop(t *testing.T) {
+	testutil.Test(t, "deferlooptest", []testutil.StaticCheckTest{
+		{
+			Checker:  deferloop.Checker{},
+			Content:  []byte("package deferlooptest\n\nfunc f() {\n\tdefer println()\n}\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: deferloop.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:3: defer inside loop accumulates until function return\n` +
+				`[^\n]*file\.go:12:4: defer inside loop accumulates until function return\n` +
+				`[^\n]*file\.go:29:5: defer inside loop accumulates until function return\n` +
+				`[^\n]*file\.go:45:3: defer inside loop accumulates until function return$`),
+		},
+		{
+			Checker:  deferloop.Checker{},
+			Content:  []byte("package deferlooptest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
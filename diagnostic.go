@@ -0,0 +1,133 @@
+package lint
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// prefixRE matches the checker prefix added by Group.Check.
+var prefixRE = regexp.MustCompile(`^([^\s:]+): (.*)$`)
+
+// Diagnostic holds the parts of a single error message. Any part that could
+// not be parsed is left empty, with the remaining text in Message.
+type Diagnostic = checkers.Diagnostic
+
+// DiagnosticChecker is implemented by checkers that can report structured
+// diagnostics instead of error messages. The error returned is for failures to
+// run the check and not for the problems found.
+type DiagnosticChecker interface {
+	CheckDiagnostics(pkgs ...string) ([]Diagnostic, error)
+}
+
+// ParseDiagnostic splits str into the parts described by Group.Check. Both
+// prefixed messages such as
+//
+//	govet.Check: file.go:23:4: err is unintentionally shadowed.
+//
+// and unprefixed messages are accepted. A severity added by WithSeverity, either
+// before or after the prefix, is also parsed.
+func ParseDiagnostic(str string) Diagnostic {
+	sev, rest, _ := cutSeverity(str)
+	if d, ok := parsePosition(rest); ok {
+		d.Severity = sev
+		return d
+	}
+	match := prefixRE.FindStringSubmatch(rest)
+	if match == nil {
+		return Diagnostic{Message: rest, Severity: sev}
+	}
+	if sev == SeverityError {
+		sev, rest, _ = cutSeverity(match[2])
+	} else {
+		rest = match[2]
+	}
+	d, ok := parsePosition(rest)
+	if !ok {
+		d.Message = rest
+	}
+	d.Checker, d.Severity = match[1], sev
+	return d
+}
+
+func parsePosition(str string) (Diagnostic, bool) { return checkers.ParsePosition(str) }
+
+// Diagnostics parses each error contained in err using ParseDiagnostic. If err
+// implements the errors interface described in Skip, each of its errors is
+// parsed, skipping any summary added by Summarized. A nil error returns an empty
+// list.
+func Diagnostics(err error) []Diagnostic {
+	switch err := err.(type) {
+	case nil:
+		return []Diagnostic{}
+	case errors:
+		diags := []Diagnostic{}
+		for _, e := range err.Errors() {
+			if !IsSummary(e) {
+				diags = append(diags, ParseDiagnostic(e))
+			}
+		}
+		return diags
+	default:
+		return []Diagnostic{ParseDiagnostic(err.Error())}
+	}
+}
+
+// MarshalJSON returns the Diagnostics for err as a JSON array of objects of the form
+//
+//	{"checker":"govet.Check","file":"file.go","line":23,"column":0,"message":"..."}
+//
+// A nil error is marshalled as [].
+func MarshalJSON(err error) ([]byte, error) {
+	return json.Marshal(Diagnostics(err))
+}
+
+// stripPrefix returns str without the checker prefix added by Group.Check, if
+// it is one of prefixes.
+func stripPrefix(str string, prefixes map[string]bool) string {
+	d := ParseDiagnostic(str)
+	if !prefixes[d.Checker] {
+		return str
+	}
+	return str[len(d.Checker)+len(": "):]
+}
+
+// GroupDiagnostics returns a DiagnosticChecker that applies checkers in order. It is
+// the same as Group(checkers).
+func GroupDiagnostics(checkers ...Checker) DiagnosticChecker {
+	return Group(checkers)
+}
+
+// CheckDiagnostics applies each of the checkers in g in order and returns all
+// diagnostics. Checkers that implement DiagnosticChecker report diagnostics
+// directly, with Checker set to the name described in Group.Check. For other
+// checkers, and for errors returned by CheckDiagnostics, the errors are parsed using
+// Diagnostics. The returned error is always nil.
+func (g Group) CheckDiagnostics(pkgs ...string) ([]Diagnostic, error) {
+	diags := []Diagnostic{}
+	for _, checker := range g.enabled() {
+		diags = append(diags, checkDiagnostics(checker, pkgs)...)
+	}
+	return diags, nil
+}
+
+func checkDiagnostics(checker Checker, pkgs []string) []Diagnostic {
+	name := prefixName(checker)
+	checker, done := untimed(checker)
+	defer done()
+	dc, ok := checker.(DiagnosticChecker)
+	if !ok {
+		return Diagnostics(checkers.Error(prefixErrors(name, checker.Check(pkgs...))...))
+	}
+	diags, err := dc.CheckDiagnostics(pkgs...)
+	if err != nil {
+		return Diagnostics(checkers.Error(prefixErrors(name, err)...))
+	}
+	if name != "" {
+		for i := range diags {
+			diags[i].Checker = name
+		}
+	}
+	return diags
+}
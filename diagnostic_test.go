@@ -0,0 +1,85 @@
+package lint_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/linelength"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestParseDiagnostic(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected lint.Diagnostic
+	}{
+		{"file.go:23: msg", lint.Diagnostic{File: "file.go", Line: 23, Message: "msg"}},
+		{"dir/file.go:23:4: msg: more", lint.Diagnostic{File: "dir/file.go", Line: 23, Col: 4, Message: "msg: more"}},
+		{"file.go: not gofmt-ed", lint.Diagnostic{File: "file.go", Message: "not gofmt-ed"}},
+		{"govet.Check: file.go:23: msg", lint.Diagnostic{Checker: "govet.Check", File: "file.go", Line: 23, Message: "msg"}},
+		{"*lint.group: failed", lint.Diagnostic{Checker: "*lint.group", Message: "failed"}},
+		{"something went wrong", lint.Diagnostic{Message: "something went wrong"}},
+		{"warning: file.go:1: msg", lint.Diagnostic{File: "file.go", Line: 1, Message: "msg", Severity: lint.SeverityWarning}},
+		{"golint.Check: info: msg", lint.Diagnostic{Checker: "golint.Check", Message: "msg", Severity: lint.SeverityInfo}},
+	}
+	for _, test := range tests {
+		d := lint.ParseDiagnostic(test.str)
+		assert(t, reflect.DeepEqual(d, test.expected), fmt.Sprintf("%s: %+v", test.str, d))
+		assert(t, d.String() == test.str, fmt.Sprintf("%s: %s", test.str, d))
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := lint.MarshalJSON(nil)
+	assert(t, err == nil && string(data) == "[]", fmt.Sprintf("%s %v", data, err))
+
+	data, err = lint.MarshalJSON(lint.Group{
+		checkFn(func(...string) error { return checkers.Error("file.go:23: err is shadowed", "bad output") }),
+	}.Check("./..."))
+	expected := `[{"checker":"lint_test.checkFn","file":"file.go","line":23,"column":0,"message":"err is shadowed","severity":"error"},` +
+		`{"checker":"lint_test.checkFn","file":"","line":0,"column":0,"message":"bad output","severity":"error"}]`
+	assert(t, err == nil && string(data) == expected, fmt.Sprintf("%s %v", data, err))
+
+	data, err = lint.MarshalJSON(fmt.Errorf("file.go:1:2: single"))
+	expected = `[{"checker":"","file":"file.go","line":1,"column":2,"message":"single","severity":"error"}]`
+	assert(t, err == nil && string(data) == expected, fmt.Sprintf("%s %v", data, err))
+}
+
+func TestGroupDiagnostics(t *testing.T) {
+	tmp, err := testutil.NewGopath("diagnostics", []testutil.SourceFile{
+		{Content: []byte("package diagnostics\n\nvar  a = 1\n"), Dest: filepath.Join("diagnostics", "file.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	file := filepath.Join(tmp.Path, "src", "diagnostics", "file.go")
+
+	g := lint.GroupDiagnostics(
+		linelength.Checker{Max: 5},
+		lint.Group{gofmt.Native{}},
+		checkFn(func(pkgs ...string) error { return checkers.Error("ungrouped: 1") }),
+	)
+	diags, err := g.CheckDiagnostics("diagnostics")
+	expected := []lint.Diagnostic{
+		{Checker: "linelength.Checker", File: file, Line: 1, Message: "line too long (19 > 5)"},
+		{Checker: "linelength.Checker", File: file, Line: 3, Message: "line too long (10 > 5)"},
+		{Checker: "gofmt.Native", File: file, Message: "not gofmt-ed"},
+		{Checker: "lint_test.checkFn", Message: "ungrouped: 1"},
+	}
+	assert(t, err == nil && reflect.DeepEqual(diags, expected), fmt.Sprintf("%v %#v", err, diags))
+
+	// Check reports the same errors.
+	var strs []string
+	for _, d := range expected {
+		strs = append(strs, d.String())
+	}
+	err = g.(lint.Checker).Check("diagnostics")
+	assert(t, err != nil && err.Error() == strings.Join(strs, "\n"), fmt.Sprintf("%v", err))
+}
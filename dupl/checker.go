@@ -0,0 +1,260 @@
+package dupl
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultThreshold is the threshold used by Checker when Threshold is 0. It
+// matches the default of dupl -t.
+const DefaultThreshold = 15
+
+// hashBase is the base of the polynomial rolling hash over token ids.
+const hashBase = 1000003
+
+// Checker implements lint.Checker and reports duplicated blocks of code in the
+// .go files of packages without running the dupl binary. Files are compared as
+// token streams, so formatting and comments are ignored.
+//
+// Windows of Threshold tokens are hashed with a rolling hash, so the time taken
+// grows linearly with the number of tokens. Blocks found in the same window are
+// extended for as long as they stay identical and reported once for the group.
+type Checker struct {
+	// Threshold is the minimum number of tokens in a duplicated block. If it is
+	// 0, DefaultThreshold is used.
+	Threshold int
+	// MatchNames requires identifiers in duplicated blocks to have the same
+	// names. By default any identifier matches any other.
+	MatchNames bool
+	// MatchLiterals requires literals in duplicated blocks to have the same
+	// values. By default any literal matches any other literal of the same kind.
+	MatchLiterals bool
+}
+
+// Check reports each group of duplicated blocks in pkgs once, at the first block
+// in the group, as
+//
+//	file.go:line: duplicate of fileB.go:line (NN tokens)
+//
+// Groups of more than two blocks list each of the other blocks, separated by
+// commas.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each group of duplicated blocks in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.GoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports duplicated blocks across files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource reports duplicated blocks within src, the contents of filename, as
+// described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	ts := &tokens{}
+	if err := c.scanSource(ts, map[string]uint64{}, filename, src); err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(ts.clones(c.threshold()), nil)
+}
+
+// tokens holds the token streams of a set of files, one after the other.
+type tokens struct {
+	ids   []uint64
+	files []int
+	lines []int
+	names []string
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	ts := &tokens{}
+	var diags []checkers.Diagnostic
+	ids := map[string]uint64{}
+	for _, file := range files {
+		if err := c.scanFile(ts, ids, file); err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+		}
+	}
+	return append(diags, ts.clones(c.threshold())...)
+}
+
+func (c Checker) threshold() int {
+	if c.Threshold <= 0 {
+		return DefaultThreshold
+	}
+	return c.Threshold
+}
+
+// scanFile appends the tokens of file to ts, assigning an id to each distinct
+// token using ids.
+func (c Checker) scanFile(ts *tokens, ids map[string]uint64, file string) error {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return c.scanSource(ts, ids, file, src)
+}
+
+// scanSource appends the tokens of src, the contents of file, to ts.
+func (c Checker) scanSource(ts *tokens, ids map[string]uint64, file string, src []byte) error {
+	fset := token.NewFileSet()
+	var errs scanner.ErrorList
+	var s scanner.Scanner
+	s.Init(fset.AddFile(file, fset.Base(), len(src)), src, errs.Add, 0)
+	index := len(ts.names)
+	ts.names = append(ts.names, file)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		key := tok.String()
+		switch {
+		case tok == token.IDENT && c.MatchNames:
+			key += " " + lit
+		case tok.IsLiteral() && tok != token.IDENT && c.MatchLiterals:
+			key += " " + lit
+		}
+		id, ok := ids[key]
+		if !ok {
+			id = uint64(len(ids) + 1)
+			ids[key] = id
+		}
+		ts.ids = append(ts.ids, id)
+		ts.files = append(ts.files, index)
+		ts.lines = append(ts.lines, fset.Position(pos).Line)
+	}
+	if len(errs) > 0 {
+		errs.Sort()
+		return errs.Err()
+	}
+	return nil
+}
+
+// clones returns a diagnostic for each group of identical blocks of at least t
+// tokens.
+func (ts *tokens) clones(t int) []checkers.Diagnostic {
+	n := len(ts.ids)
+	if n < t {
+		return nil
+	}
+	// Hash every window of t tokens that lies within a single file.
+	pow := uint64(1)
+	for i := 1; i < t; i++ {
+		pow *= hashBase
+	}
+	windows := map[uint64][]int{}
+	var h uint64
+	for i := 0; i < n; i++ {
+		if i >= t {
+			h -= ts.ids[i-t] * pow
+		}
+		h = h*hashBase + ts.ids[i]
+		if start := i - t + 1; start >= 0 && ts.files[start] == ts.files[i] {
+			windows[h] = append(windows[h], start)
+		}
+	}
+	// Groups are visited in the order of their first window, so a block is seen
+	// at its first window before any of the windows inside it. Tokens inside a
+	// reported block are consumed and a group is only reported if some of its
+	// windows have not been consumed, so each duplicate is reported once, at its
+	// longest extent.
+	consumed := make([]bool, n)
+	var hashes []uint64
+	for h, starts := range windows {
+		if len(starts) > 1 {
+			hashes = append(hashes, h)
+		}
+	}
+	sort.Slice(hashes, func(i, j int) bool { return windows[hashes[i]][0] < windows[hashes[j]][0] })
+	var diags []checkers.Diagnostic
+	for _, h := range hashes {
+		starts := windows[h]
+		for len(starts) > 1 {
+			group, fresh := []int{starts[0]}, !consumed[starts[0]]
+			var others []int
+			for _, s := range starts[1:] {
+				switch {
+				case s >= group[len(group)-1]+t && ts.equal(group[0], s, t):
+					group = append(group, s)
+					fresh = fresh || !consumed[s]
+				case !consumed[s]:
+					others = append(others, s)
+				}
+			}
+			if len(group) > 1 && fresh {
+				diags = append(diags, ts.report(group, ts.extend(group, t), consumed))
+			}
+			starts = others
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+	return diags
+}
+
+// equal reports whether the t tokens starting at a and b are identical.
+func (ts *tokens) equal(a, b, t int) bool {
+	for i := 0; i < t; i++ {
+		if ts.ids[a+i] != ts.ids[b+i] {
+			return false
+		}
+	}
+	return true
+}
+
+// extend returns the length of the longest identical blocks starting at each
+// position in group, which are known to match for t tokens. Blocks are not
+// extended past the end of their file or into the next block in the group.
+func (ts *tokens) extend(group []int, t int) int {
+	for l := t; ; l++ {
+		for i, s := range group {
+			end := s + l
+			if end >= len(ts.ids) || ts.files[end] != ts.files[s] || ts.ids[end] != ts.ids[group[0]+l] {
+				return l
+			}
+			if i+1 < len(group) && end >= group[i+1] {
+				return l
+			}
+		}
+	}
+}
+
+// report marks the blocks of length l starting at each position in group as
+// consumed and returns a diagnostic for them.
+func (ts *tokens) report(group []int, l int, consumed []bool) checkers.Diagnostic {
+	for _, s := range group {
+		for i := s; i < s+l; i++ {
+			consumed[i] = true
+		}
+	}
+	others := make([]string, len(group)-1)
+	for i, s := range group[1:] {
+		others[i] = fmt.Sprintf("%s:%d", ts.names[ts.files[s]], ts.lines[s])
+	}
+	first := group[0]
+	return checkers.Diagnostic{
+		File:    ts.names[ts.files[first]],
+		Line:    ts.lines[first],
+		Message: fmt.Sprintf("duplicate of %s (%d tokens)", strings.Join(others, ", "), l),
+	}
+}
@@ -87,3 +87,65 @@ func TestSkip(t *testing.T) {
 		{S: dupl.Skip("lint.go:1,12"), Line: "dupl.Check: found 2 clones: here\nlint.go:1,12", Skip: true},
 	})
 }
+
+const clones = `package dupltest
+
+import "fmt"
+
+func A(a, b int) {
+	if a > b {
+		fmt.Println("a is larger", a, b)
+	}
+	for i := 0; i < a; i++ {
+		fmt.Println(i)
+	}
+}
+
+func B(x, y int) {
+	if x > y {
+		fmt.Println("x is larger", x, y)
+	}
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+}
+
+func C(x, y int) {
+	if x > y {
+		fmt.Println("x is larger", x, y)
+	}
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+}
+`
+
+func TestChecker(t *testing.T) {
+	testutil.Test(t, "dupltest", []testutil.StaticCheckTest{
+		{
+			Checker:  dupl.Checker{},
+			Validate: testutil.NoError,
+			Content:  []byte("package dupltest\n\nfunc A() { println(1) }\n\nfunc B() { println(2) }\n"),
+		},
+		{
+			Checker:  dupl.Checker{},
+			Content:  []byte(clones),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:3: duplicate of .*file\.go:12, .*file\.go:21 \(50 tokens\)$`),
+		},
+		{
+			Checker:  dupl.Checker{MatchNames: true},
+			Content:  []byte(clones),
+			Validate: testutil.MatchesRegexp(`\n.*file\.go:14: duplicate of .*file\.go:23 \(48 tokens\)$`),
+		},
+		{
+			Checker:  dupl.Checker{MatchNames: true, MatchLiterals: true, Threshold: 20},
+			Content:  []byte(clones),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:14: duplicate of .*file\.go:23 \(48 tokens\)$`),
+		},
+		{
+			Checker:  dupl.Checker{Threshold: 100},
+			Content:  []byte(clones),
+			Validate: testutil.NoError,
+		},
+	})
+}
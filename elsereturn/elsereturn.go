@@ -0,0 +1,145 @@
+// Package elsereturn provides lint integration for finding else blocks that
+// can be outdented.
+package elsereturn
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports else blocks following an if
+// statement whose blocks all end with a return, break, continue or goto
+// statement, in .go files including test files. Such an else is unnecessary,
+// as its body can follow the if statement without changing behaviour, which
+// golint also reports.
+//
+// In a chain of else if statements, the final else is reported only if the if
+// block and every else if block end with one of these statements. Chains in
+// which an if statement declares variables in its init statement, as in
+// if err := f(); err != nil, are not reported, since the variables would go out
+// of scope. Reports can be suppressed with a comment on the line of the else or
+// the line before it of the form
+//
+//	//nolint:elsereturn
+type Checker struct{}
+
+// Check reports each unnecessary else block in pkgs as
+//
+//	file.go:line:col: if block ends with a return statement, so drop this else and outdent its body
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each unnecessary else block in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				diags = append(diags, checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each unnecessary else block in files as described in
+// Check.
+func (c Checker) CheckFiles(files ...string) error {
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each unnecessary else block in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return checkFile(fset, f)
+}
+
+func checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	suppressed := checkers.NoLintLines(fset, f, "elsereturn.Checker")
+	// chained holds the if statements that follow an else, which are checked
+	// as part of the chain they belong to.
+	chained := map[*ast.IfStmt]bool{}
+	var diags []checkers.Diagnostic
+	ast.Inspect(f, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.IfStmt)
+		if !ok || chained[stmt] {
+			return true
+		}
+		for next, ok := stmt.Else.(*ast.IfStmt); ok; next, ok = next.Else.(*ast.IfStmt) {
+			chained[next] = true
+		}
+		var last *ast.BranchStmt
+		var ret *ast.ReturnStmt
+		for {
+			if declares(stmt.Init) {
+				return true
+			}
+			last, ret = nil, nil
+			if len(stmt.Body.List) == 0 {
+				return true
+			}
+			switch end := stmt.Body.List[len(stmt.Body.List)-1].(type) {
+			case *ast.ReturnStmt:
+				ret = end
+			case *ast.BranchStmt:
+				if end.Tok == token.FALLTHROUGH {
+					return true
+				}
+				last = end
+			default:
+				return true
+			}
+			next, ok := stmt.Else.(*ast.IfStmt)
+			if !ok {
+				break
+			}
+			stmt = next
+		}
+		block, ok := stmt.Else.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		if line := fset.Position(block.Pos()).Line; suppressed[line] || suppressed[line-1] {
+			return true
+		}
+		kind := "return"
+		if ret == nil {
+			kind = last.Tok.String()
+		}
+		p := fset.Position(block.Pos())
+		diags = append(diags, checkers.Diagnostic{
+			File:    p.Filename,
+			Line:    p.Line,
+			Col:     p.Column,
+			Message: fmt.Sprintf("if block ends with a %s statement, so drop this else and outdent its body", kind),
+		})
+		return true
+	})
+	return diags
+}
+
+// declares reports whether init is a short variable declaration.
+func declares(init ast.Stmt) bool {
+	assign, ok := init.(*ast.AssignStmt)
+	return ok && assign.Tok == token.DEFINE
+}
@@ -0,0 +1,106 @@
+package elsereturn_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/elsereturn"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package elsereturntest
+
+func Sign(n int) int {
+	if n < 0 {
+		return -1
+	} else {
+		return 1
+	}
+}
+
+func Chain(n int) string {
+	if n < 0 {
+		return "negative"
+	} else if n == 0 {
+		return "zero"
+	} else {
+		return "positive"
+	}
+}
+
+func Partial(n int) string {
+	s := ""
+	if n < 0 {
+		s = "negative"
+	} else if n == 0 {
+		return "zero"
+	} else {
+		s = "positive"
+	}
+	return s
+}
+
+func Loop(ns []int) {
+	for _, n := range ns {
+		if n < 0 {
+			continue
+		} else {
+			println(n)
+		}
+		if n > 10 {
+			break
+		} else {
+			println(n)
+		}
+	}
+}
+
+func Scoped(m map[string]int) int {
+	if v, ok := m["a"]; ok {
+		return v
+	} else {
+		return len(m)
+	}
+}
+
+func Nested(n int) int {
+	if n > 0 {
+		if n > 1 {
+			return 2
+		}
+	} else {
+		return 0
+	}
+	return 1
+}
+
+func Ignored(n int) int {
+	if n < 0 {
+		return -1
+	} else { //nolint:elsereturn
+		return 1
+	}
+}
+`
+
+func TestElseReturn(t *testing.T) {
+	testutil.Test(t, "elsereturntest", []testutil.StaticCheckTest{
+		{
+			Checker:  elsereturn.Checker{},
+			Content:  []byte("package elsereturntest\n\nfunc f(b bool) int {\n\tif b {\n\t\treturn 1\n\t}\n\treturn 0\n}\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: elsereturn.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:6:9: if block ends with a return statement, so drop this else and outdent its body\n` +
+				`[^\n]*file\.go:16:9: if block ends with a return statement, so drop this else and outdent its body\n` +
+				`[^\n]*file\.go:37:10: if block ends with a continue statement, so drop this else and outdent its body\n` +
+				`[^\n]*file\.go:42:10: if block ends with a break statement, so drop this else and outdent its body$`),
+		},
+		{
+			Checker:  elsereturn.Checker{},
+			Content:  []byte("package elsereturntest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
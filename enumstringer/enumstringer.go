@@ -0,0 +1,155 @@
+// Package enumstringer provides lint integration for finding enum types
+// without a String method.
+package enumstringer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports named integer types, declared in
+// .go files other than test files, that have constants declared in a const
+// block using iota but no String method. Their values are printed as bare
+// integers by fmt and in logs.
+//
+// Types and method sets are resolved using go/types, so a String method
+// generated by stringer in another file of the package, or promoted from an
+// embedded type, is found. Reports can be suppressed with a comment on the line
+// of the type or the line before it of the form
+//
+//	//nolint:enumstringer
+type Checker struct {
+	// Allow holds path.Match patterns for the names of types that need no
+	// String method, such as "*Flags" for bit sets.
+	Allow []string
+}
+
+// Check reports each enum type in pkgs without a String method as
+//
+//	file.go:line:col: enum type Status has no String() method
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each enum type in pkgs without a
+// String method.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports enum types without a String method in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		pkg, info, _ := s.TypeCheck(false)
+		enums := map[*types.TypeName]bool{}
+		for _, f := range s.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST || !usesIota(info, gen) {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if obj := info.Defs[name]; obj != nil {
+							if named := enumType(pkg, obj.Type()); named != nil {
+								enums[named.Obj()] = true
+							}
+						}
+					}
+				}
+			}
+		}
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "enumstringer.Checker")
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					obj, ok := info.Defs[ts.Name].(*types.TypeName)
+					if !ok || !enums[obj] || c.allowed(obj.Name()) || hasString(obj.Type()) {
+						continue
+					}
+					if line := s.Fset.Position(ts.Pos()).Line; suppressed[line] || suppressed[line-1] {
+						continue
+					}
+					diags = append(diags, s.Diagnostic(ts.Name.Pos(), "enum type %s has no String() method", obj.Name()))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether the type called name needs no String method.
+func (c Checker) allowed(name string) bool {
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// usesIota reports whether a value in the const declaration gen uses iota.
+func usesIota(info *types.Info, gen *ast.GenDecl) bool {
+	predeclared := types.Universe.Lookup("iota")
+	found := false
+	for _, spec := range gen.Specs {
+		for _, value := range spec.(*ast.ValueSpec).Values {
+			ast.Inspect(value, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && info.Uses[id] == predeclared {
+					found = true
+				}
+				return !found
+			})
+		}
+	}
+	return found
+}
+
+// enumType returns typ if it is a named type declared in pkg whose underlying
+// type is an integer, or nil otherwise.
+func enumType(pkg *types.Package, typ types.Type) *types.Named {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != pkg {
+		return nil
+	}
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return nil
+	}
+	return named
+}
+
+// hasString reports whether typ or *typ has a method String() string.
+func hasString(typ types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	basic, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
@@ -0,0 +1,106 @@
+package enumstringer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/enumstringer"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package enumstringertest
+
+type Status int
+
+const (
+	Active Status = iota
+	Inactive
+)
+
+type Color uint8
+
+const (
+	Red Color = iota + 1
+	Green
+)
+
+func (c Color) String() string { return "color" }
+
+type Level int
+
+const (
+	Low  Level = 1
+	High Level = 2
+)
+
+type (
+	Mode     int
+	LogFlags int
+)
+
+const (
+	Read Mode = 1 << iota
+	Write
+	Verbose LogFlags = 1 << iota
+)
+
+type Name string
+
+const First Name = "first"
+
+//nolint:enumstringer
+type Quiet int
+
+const (
+	Hush Quiet = iota
+)
+`
+
+func TestEnumStringer(t *testing.T) {
+	testutil.Test(t, "enumstringertest", []testutil.StaticCheckTest{
+		{
+			Checker: enumstringer.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:3:6: enum type Status has no String\(\) method\n` +
+				`[^\n]*file\.go:27:2: enum type Mode has no String\(\) method\n` +
+				`[^\n]*file\.go:28:2: enum type LogFlags has no String\(\) method$`),
+		},
+		{
+			Checker:  enumstringer.Checker{Allow: []string{"*Flags", "Mode"}},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:3:6: enum type Status has no String\(\) method$`),
+		},
+		{
+			Checker:  enumstringer.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+		{
+			Checker:  enumstringer.Checker{},
+			Content:  []byte("package enumstringertest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
+
+func TestEnumStringerGenerated(t *testing.T) {
+	checkers.Unload("enumstringergen")
+	tmp, err := testutil.NewGopath("enumstringergen", []testutil.SourceFile{
+		{
+			Content: []byte("package enumstringergen\n\n//go:generate stringer -type=Status\n\ntype Status int\n\nconst (\n\tActive Status = iota\n\tInactive\n)\n"),
+			Dest:    filepath.Join("enumstringergen", "status.go"),
+		},
+		{
+			Content: []byte("// Code generated by \"stringer -type=Status\"; DO NOT EDIT.\n\npackage enumstringergen\n\nimport \"strconv\"\n\nfunc (i Status) String() string { return strconv.Itoa(int(i)) }\n"),
+			Dest:    filepath.Join("enumstringergen", "status_string.go"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := (enumstringer.Checker{}).Check("enumstringergen"); err != nil {
+		t.Error(err)
+	}
+}
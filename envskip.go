@@ -0,0 +1,77 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SkipEnv is the environment variable, holding a comma separated list of checker
+// names, that Group consults to skip checkers without changing code, such as to
+// temporarily disable a noisy checker in CI:
+//
+//	LINT_SKIP=golint.Check,errcheck.Check go test ./...
+//
+// Names are matched case-insensitively against the name used to prefix errors,
+// as described in Group.Check, and the type of the checker. Skipped checkers are
+// not run and report nothing.
+const SkipEnv = "LINT_SKIP"
+
+// SkipOutput is where a note is written the first time each checker is skipped
+// because of SkipEnv, so that it is obvious from logs that it was disabled.
+var SkipOutput io.Writer = os.Stderr
+
+var (
+	skipMu    sync.Mutex
+	skipNoted = map[string]bool{}
+)
+
+// envSkipped returns the names in SkipEnv in lower case.
+func envSkipped() []string {
+	var names []string
+	for _, name := range strings.Split(os.Getenv(SkipEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	return names
+}
+
+// enabled returns the checkers in g that are not skipped by SkipEnv.
+func (g Group) enabled() Group {
+	skipped := envSkipped()
+	if len(skipped) == 0 {
+		return g
+	}
+	var enabled Group
+	for _, checker := range g {
+		if !skippedBy(checker, skipped) {
+			enabled = append(enabled, checker)
+		}
+	}
+	return enabled
+}
+
+func skippedBy(checker Checker, skipped []string) bool {
+	name := checkerName(checker)
+	for _, s := range skipped {
+		if s == strings.ToLower(name) || s == strings.ToLower(reflect.TypeOf(checker).String()) {
+			noteSkipped(name)
+			return true
+		}
+	}
+	return false
+}
+
+func noteSkipped(name string) {
+	skipMu.Lock()
+	defer skipMu.Unlock()
+	if skipNoted[name] {
+		return
+	}
+	skipNoted[name] = true
+	fmt.Fprintf(SkipOutput, "lint: skipping %s because of %s\n", name, SkipEnv)
+}
@@ -0,0 +1,39 @@
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestSkipEnv(t *testing.T) {
+	var out bytes.Buffer
+	defer func(w io.Writer) { lint.SkipOutput = w }(lint.SkipOutput)
+	lint.SkipOutput = &out
+	os.Setenv(lint.SkipEnv, "GOVET.checker, lint_test.checkFn")
+	defer os.Unsetenv(lint.SkipEnv)
+
+	g := lint.Group{
+		namedCheck{},
+		checkFn(func(pkgs ...string) error { return checkers.Error("skipped") }),
+		lint.Limit(1, onlyCheck{name: "kept", fn: func(pkgs ...string) error { return checkers.Error("found") }}),
+	}
+	for i := 0; i < 2; i++ {
+		err := g.Check()
+		assert(t, err != nil && err.Error() == "kept: found", fmt.Sprintf("%v", err))
+	}
+	expected := "lint: skipping govet.Checker because of LINT_SKIP\nlint: skipping lint_test.checkFn because of LINT_SKIP\n"
+	assert(t, out.String() == expected, out.String())
+
+	diags, _ := g.CheckDiagnostics()
+	assert(t, len(diags) == 1 && diags[0].Checker == "kept", fmt.Sprint(diags))
+
+	os.Setenv(lint.SkipEnv, "")
+	err := g.Check()
+	assert(t, err != nil && err.Error() == "govet.Checker: file.go:23: shadowed\nlint_test.checkFn: skipped\nkept: found", fmt.Sprintf("%v", err))
+}
@@ -1,7 +1,12 @@
 // Package errcheck provides lint integration for the errcheck linter
 package errcheck
 
-import "github.com/surullabs/lint/checkers"
+import (
+	"regexp"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
 
 // Check runs the errcheck linter (https://github.com/kisielk/errcheck)
 type Check struct {
@@ -11,11 +16,30 @@ type Check struct {
 	Assert bool
 	// Tags is a list of space separated build tags
 	Tags string
+	// IgnorePkg is a list of package paths whose errors are ignored
+	IgnorePkg []string
 }
 
-// Check runs errcheck and returns any errors found.
+// findingRE matches the file:line:col<tab>call output of errcheck.
+var findingRE = regexp.MustCompile(`^(\S+:\d+:\d+):\s+(.*)$`)
+
+// Check runs errcheck and returns any errors found. Each unchecked call is
+// reported as
+//
+//	file.go:line:col: unchecked error: f.Close()
 func (c Check) Check(pkgs ...string) error {
-	return checkers.Lint("errcheck", "", "github.com/kisielk/errcheck", pkgs, c.Args()...)
+	err := checkers.Lint("errcheck", "", "github.com/kisielk/errcheck", pkgs, c.Args()...)
+	list, ok := err.(interface {
+		Errors() []string
+	})
+	if !ok {
+		return err
+	}
+	errs := list.Errors()
+	for i, e := range errs {
+		errs[i] = findingRE.ReplaceAllString(e, "$1: unchecked error: $2")
+	}
+	return checkers.Error(errs...)
 }
 
 // Args returns command line arguments used for errcheck
@@ -30,5 +54,8 @@ func (c Check) Args() []string {
 	if c.Tags != "" {
 		args = append(args, "-tags", c.Tags)
 	}
+	if len(c.IgnorePkg) > 0 {
+		args = append(args, "-ignorepkg", strings.Join(c.IgnorePkg, ","))
+	}
 	return args
 }
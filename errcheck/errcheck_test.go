@@ -40,7 +40,7 @@ func TestFunc() {
 	f.Close()
 }
 `),
-			Validate: testutil.HasSuffix("f.Close()"),
+			Validate: testutil.MatchesRegexp(`file\.go:8:2: unchecked error: f\.Close\(\)$`),
 		},
 		{
 			Checker: errcheck.Check{},
@@ -78,5 +78,6 @@ func TestArgs(t *testing.T) {
 		{A: errcheck.Check{Assert: true}, Expected: []string{"-asserts"}},
 		{A: errcheck.Check{Tags: "test"}, Expected: []string{"-tags", "test"}},
 		{A: errcheck.Check{Blank: true, Assert: true}, Expected: []string{"-blank", "-asserts"}},
+		{A: errcheck.Check{IgnorePkg: []string{"fmt", "io"}}, Expected: []string{"-ignorepkg", "fmt,io"}},
 	})
 }
@@ -0,0 +1,195 @@
+// Package errcontext provides lint integration for finding errors returned
+// without adding context.
+package errcontext
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// wrappers are the functions whose results already describe an error.
+var wrappers = map[string]bool{
+	"errors.Join": true,
+	"errors.New":  true,
+	"fmt.Errorf":  true,
+}
+
+// DefaultMinDepth is the MinDepth used by Checker when MinDepth is 0.
+const DefaultMinDepth = 2
+
+// Checker implements lint.Checker and reports exported functions and methods,
+// in .go files other than test files, that return an error from a call as is.
+// When every layer does this the final error has no context about where it
+// came from. Only functions with at least MinDepth calls returning an error are
+// checked, since a function making a single call adds little by wrapping its
+// error.
+//
+// A returned variable is reported if it is assigned the result of a call and
+// never the result of fmt.Errorf, errors.New or errors.Join. Types are resolved
+// using go/types, so only values of type error are reported. Reports can be
+// suppressed with a comment on the line of the return statement or the line
+// before it of the form
+//
+//	//nolint:errcontext
+type Checker struct {
+	// MinDepth is the least number of calls returning an error a function must
+	// make to be checked. If it is 0, DefaultMinDepth is used.
+	MinDepth int
+}
+
+// Check reports each error returned without context in pkgs as
+//
+//	file.go:line:col: error returned without context; consider fmt.Errorf("...: %w", err)
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each error returned without context
+// in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports errors returned without context in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	minDepth := c.minDepth()
+	errorType := types.Universe.Lookup("error").Type()
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "errcontext.Checker")
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil || !fn.Name.IsExported() {
+					continue
+				}
+				sources, wrapped, calls := assignments(info, fn.Body, errorType)
+				if calls < minDepth {
+					continue
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.FuncLit:
+						return false
+					case *ast.ReturnStmt:
+						line := s.Fset.Position(n.Pos()).Line
+						if suppressed[line] || suppressed[line-1] {
+							return true
+						}
+						for _, r := range n.Results {
+							id, ok := ast.Unparen(r).(*ast.Ident)
+							if !ok {
+								continue
+							}
+							obj := info.Uses[id]
+							if obj == nil || !sources[obj] || wrapped[obj] || !types.Identical(obj.Type(), errorType) {
+								continue
+							}
+							diags = append(diags, s.Diagnostic(r.Pos(),
+								"error returned without context; consider fmt.Errorf(\"...: %%w\", %s)", id.Name))
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+func (c Checker) minDepth() int {
+	if c.MinDepth <= 0 {
+		return DefaultMinDepth
+	}
+	return c.MinDepth
+}
+
+// assignments returns the variables assigned the result of a call in body, the
+// variables assigned the result of one of wrappers and the number of calls in
+// body, outside function literals, that return an error.
+func assignments(info *types.Info, body *ast.BlockStmt, errorType types.Type) (sources, wrapped map[types.Object]bool, calls int) {
+	sources, wrapped = map[types.Object]bool{}, map[types.Object]bool{}
+	assign := func(lhs []ast.Expr, rhs []ast.Expr) {
+		if len(rhs) != 1 && len(rhs) != len(lhs) {
+			return
+		}
+		for i, l := range lhs {
+			id, ok := l.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := info.ObjectOf(id)
+			r := rhs[0]
+			if len(rhs) == len(lhs) {
+				r = rhs[i]
+			}
+			call, ok := ast.Unparen(r).(*ast.CallExpr)
+			if obj == nil || !ok {
+				continue
+			}
+			if wrappers[funcName(info, call)] {
+				wrapped[obj] = true
+			} else {
+				sources[obj] = true
+			}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if !wrappers[funcName(info, n)] && returnsError(info, n, errorType) {
+				calls++
+			}
+		case *ast.AssignStmt:
+			assign(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			assign(lhs, n.Values)
+		}
+		return true
+	})
+	return sources, wrapped, calls
+}
+
+// returnsError reports whether call returns a value of type error.
+func returnsError(info *types.Info, call *ast.CallExpr, errorType types.Type) bool {
+	switch t := info.TypeOf(call).(type) {
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if types.Identical(t.At(i).Type(), errorType) {
+				return true
+			}
+		}
+	case nil:
+	default:
+		return types.Identical(t, errorType)
+	}
+	return false
+}
+
+// funcName returns the package name and name of the package-level function
+// called by call, as in "fmt.Errorf", or "" if it is not one.
+func funcName(info *types.Info, call *ast.CallExpr) string {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
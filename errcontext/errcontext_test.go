@@ -0,0 +1,91 @@
+package errcontext_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/errcontext"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package errcontexttest
+
+import (
+	"fmt"
+	"os"
+)
+
+func Load(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		//nolint:errcontext
+		return nil, err
+	}
+	return data, nil
+}
+
+func Single(path string) error {
+	_, err := os.Stat(path)
+	return err
+}
+
+func Wrapped(path string) error {
+	_, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("stat: %w", err)
+		return err
+	}
+	_, err = os.ReadFile(path)
+	return err
+}
+
+func load(path string) error {
+	_, err := os.Stat(path)
+	_, _ = os.ReadFile(path)
+	return err
+}
+
+func Param(err error) error {
+	_, _ = os.Stat("a")
+	_, _ = os.Stat("b")
+	return err
+}
+`
+
+func TestErrContext(t *testing.T) {
+	testutil.Test(t, "errcontexttest", []testutil.StaticCheckTest{
+		{
+			Checker:  errcontext.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:15: error returned without context; consider fmt\.Errorf\("\.\.\.: %w", err\)$`),
+		},
+		{
+			Checker:  errcontext.Checker{MinDepth: 5},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  errcontext.Checker{MinDepth: 2},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:15: error returned without context; consider fmt\.Errorf\("\.\.\.: %w", err\)$`),
+		},
+		{
+			Checker: errcontext.Checker{MinDepth: 1},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:15: error returned without context; consider fmt\.Errorf\("\.\.\.: %w", err\)\n` +
+				`[^\n]*file\.go:27:9: error returned without context; consider fmt\.Errorf\("\.\.\.: %w", err\)$`),
+		},
+		{
+			Checker:  errcontext.Checker{MinDepth: 2},
+			Content:  []byte("package errcontexttest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,154 @@
+// Package errorwrap provides lint integration for checking that errors passed
+// to fmt.Errorf are wrapped with %w.
+package errorwrap
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports calls to fmt.Errorf, in .go files
+// including test files, that format a value implementing error with %v or %s
+// instead of wrapping it with %w. Only arguments whose static type implements
+// error are reported, and calls with a format that is not a constant or that
+// uses explicit argument indexes are ignored.
+type Checker struct {
+	// AllowMultipleWrap reports every error formatted without %w, since Go 1.20
+	// allows a call to wrap several errors. By default only one error per call
+	// is expected to be wrapped, so calls that already use %w are not reported
+	// and only the first error of other calls is.
+	AllowMultipleWrap bool
+}
+
+// Check reports each error in pkgs that should be wrapped as
+//
+//	file.go:line:col: error formatted with %v; use %w to wrap
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each error in pkgs that should be
+// wrapped.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports errors that should be wrapped in the packages of l, which
+// may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				ast.Inspect(f, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok || !isErrorf(info, call) {
+						return true
+					}
+					for _, arg := range c.unwrapped(info, errorType, call) {
+						diags = append(diags, s.Diagnostic(arg.expr.Pos(), "error formatted with %%%c; use %%w to wrap", arg.verb))
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// isErrorf reports whether call calls fmt.Errorf.
+func isErrorf(info *types.Info, call *ast.CallExpr) bool {
+	var id *ast.Ident
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		id = fn.Sel
+	case *ast.Ident:
+		id = fn
+	default:
+		return false
+	}
+	obj, ok := info.Uses[id].(*types.Func)
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == "fmt" && obj.Name() == "Errorf"
+}
+
+type formatted struct {
+	expr ast.Expr
+	verb rune
+}
+
+// unwrapped returns the arguments of call that implement error and are
+// formatted with %v or %s.
+func (c Checker) unwrapped(info *types.Info, errorType *types.Interface, call *ast.CallExpr) []formatted {
+	if len(call.Args) == 0 || call.Ellipsis.IsValid() {
+		return nil
+	}
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+	verbs, ok := parseVerbs(constant.StringVal(tv.Value))
+	if !ok {
+		return nil
+	}
+	args := call.Args[1:]
+	var found []formatted
+	wraps := false
+	for i, verb := range verbs {
+		if verb == 'w' {
+			wraps = true
+		}
+		if i >= len(args) || (verb != 'v' && verb != 's') {
+			continue
+		}
+		if t := info.TypeOf(args[i]); t != nil && types.Implements(t, errorType) {
+			found = append(found, formatted{args[i], verb})
+		}
+	}
+	if c.AllowMultipleWrap || len(found) == 0 {
+		return found
+	}
+	if wraps {
+		return nil
+	}
+	return found[:1]
+}
+
+// parseVerbs returns the verb used for each argument consumed by format. A *
+// width or precision consumes an argument and is returned as '*'. It returns
+// false if format uses explicit argument indexes.
+func parseVerbs(format string) ([]rune, bool) {
+	var verbs []rune
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		// Skip flags, width and precision up to the verb.
+		for i++; i < len(format); i++ {
+			ch := format[i]
+			if ch == '[' {
+				return nil, false
+			}
+			if ch == '*' {
+				verbs = append(verbs, '*')
+				continue
+			}
+			if strings.IndexByte("+-# 0.123456789", ch) < 0 {
+				if ch != '%' {
+					verbs = append(verbs, rune(ch))
+				}
+				break
+			}
+		}
+	}
+	return verbs, true
+}
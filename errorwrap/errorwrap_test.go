@@ -0,0 +1,57 @@
+package errorwrap_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/errorwrap"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package errorwraptest
+
+import (
+	"errors"
+	"fmt"
+)
+
+type myErr struct{}
+
+func (myErr) Error() string { return "" }
+
+func f(err error, name string, n int) {
+	_ = fmt.Errorf("failed: %w", err)
+	_ = fmt.Errorf("failed %s: %v", name, err)
+	_ = fmt.Errorf("failed %*d: %100.2s", n, n, myErr{})
+	_ = fmt.Errorf("%d%%: %v %v", n, err, errors.New("b"))
+	_ = fmt.Errorf("%w: %v", err, err)
+	_ = fmt.Errorf("%[1]v", err)
+	_ = fmt.Errorf("%v", name)
+	_ = fmt.Errorf("%v", err.Error())
+}
+`
+
+func TestErrorWrap(t *testing.T) {
+	testutil.Test(t, "errorwraptest", []testutil.StaticCheckTest{
+		{
+			Checker:  errorwrap.Checker{},
+			Content:  []byte("package errorwraptest\n\nimport \"fmt\"\n\nvar _ = fmt.Errorf(\"%w\", fmt.Errorf(\"%d\", 1))\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: errorwrap.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:14:40: error formatted with %v; use %w to wrap\n` +
+				`.*file\.go:15:46: error formatted with %s; use %w to wrap\n` +
+				`.*file\.go:16:35: error formatted with %v; use %w to wrap$`),
+		},
+		{
+			Checker: errorwrap.Checker{AllowMultipleWrap: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:14:40: error formatted with %v; use %w to wrap\n` +
+				`.*file\.go:15:46: error formatted with %s; use %w to wrap\n` +
+				`.*file\.go:16:35: error formatted with %v; use %w to wrap\n` +
+				`.*file\.go:16:40: error formatted with %v; use %w to wrap\n` +
+				`.*file\.go:17:32: error formatted with %v; use %w to wrap$`),
+		},
+	})
+}
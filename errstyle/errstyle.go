@@ -0,0 +1,119 @@
+// Package errstyle provides lint integration for checking the style of error
+// strings.
+package errstyle
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports error strings in .go files, which
+// are the constant messages passed to errors.New and the constant formats passed
+// to fmt.Errorf, that are capitalized or end with punctuation. Error strings are
+// usually printed following other context, so they read best as they would in
+// the middle of a sentence. Calls are resolved using go/types and messages that
+// are not constants are not checked.
+//
+// A string starting with a word in capitals, such as an acronym like "EOF", is
+// not considered capitalized.
+type Checker struct {
+	// Allow holds prefixes of error strings that may be capitalized, such as
+	// proper nouns.
+	Allow []string
+}
+
+// Check reports each error string in pkgs that breaks the conventions as
+//
+//	file.go:line:col: error string should not be capitalized
+//	file.go:line:col: error string should not end with punctuation
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each error string in pkgs that
+// breaks the conventions.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports error strings that break the conventions in the packages
+// of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 || !isErrorFunc(info, call) {
+					return true
+				}
+				value := info.Types[call.Args[0]].Value
+				if value == nil || value.Kind() != constant.String {
+					return true
+				}
+				msg := constant.StringVal(value)
+				if c.capitalized(msg) {
+					diags = append(diags, s.Diagnostic(call.Args[0].Pos(), "error string should not be capitalized"))
+				}
+				if endsWithPunct(msg) {
+					diags = append(diags, s.Diagnostic(call.Args[0].Pos(), "error string should not end with punctuation"))
+				}
+				return true
+			})
+		}
+		return diags
+	})
+}
+
+// isErrorFunc reports whether call calls errors.New or fmt.Errorf.
+func isErrorFunc(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	name := fn.Pkg().Path() + "." + fn.Name()
+	return name == "errors.New" || name == "fmt.Errorf"
+}
+
+// capitalized reports whether msg starts with a capital letter, other than as
+// part of a word in capitals or an allowed prefix.
+func (c Checker) capitalized(msg string) bool {
+	first, _ := utf8.DecodeRuneInString(msg)
+	if !unicode.IsUpper(first) {
+		return false
+	}
+	for _, prefix := range c.Allow {
+		if strings.HasPrefix(msg, prefix) {
+			return false
+		}
+	}
+	word := msg
+	if i := strings.IndexFunc(msg, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }); i >= 0 {
+		word = msg[:i]
+	}
+	if utf8.RuneCountInString(word) < 2 {
+		return true
+	}
+	return strings.ToUpper(word) != word
+}
+
+// endsWithPunct reports whether msg ends with punctuation or a newline.
+func endsWithPunct(msg string) bool {
+	last, _ := utf8.DecodeLastRuneInString(msg)
+	return strings.ContainsRune(".,:;!?\n", last)
+}
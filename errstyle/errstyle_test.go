@@ -0,0 +1,67 @@
+package errstyle_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/errstyle"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package errstyletest
+
+import (
+	"errors"
+	"fmt"
+)
+
+const prefix = "Unexpected"
+
+var (
+	errCapital = errors.New("Something failed")
+	errPunct   = fmt.Errorf("something failed: %v.", 1)
+	errBoth    = errors.New("Failed!")
+	errConst   = errors.New(prefix + " value")
+	errAcronym = errors.New("EOF reached")
+	errLetter  = errors.New("A thing failed")
+	errGoogle  = fmt.Errorf("Google returned %d", 500)
+	errFine    = errors.New("something failed")
+)
+
+func dynamic(msg string) error { return errors.New(msg) }
+
+type other struct{}
+
+func (other) New(msg string) error { return nil }
+
+var _ = other{}.New("Not an error string.")
+`
+
+func TestErrStyle(t *testing.T) {
+	testutil.Test(t, "errstyletest", []testutil.StaticCheckTest{
+		{
+			Checker: errstyle.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:26: error string should not be capitalized\n` +
+				`.*file\.go:12:26: error string should not end with punctuation\n` +
+				`.*file\.go:13:26: error string should not be capitalized\n` +
+				`.*file\.go:13:26: error string should not end with punctuation\n` +
+				`.*file\.go:14:26: error string should not be capitalized\n` +
+				`.*file\.go:16:26: error string should not be capitalized\n` +
+				`.*file\.go:17:26: error string should not be capitalized$`),
+		},
+		{
+			Checker: errstyle.Checker{Allow: []string{"Google", "Unexpected"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:26: error string should not be capitalized\n` +
+				`.*file\.go:12:26: error string should not end with punctuation\n` +
+				`.*file\.go:13:26: error string should not be capitalized\n` +
+				`.*file\.go:13:26: error string should not end with punctuation\n` +
+				`.*file\.go:16:26: error string should not be capitalized$`),
+		},
+		{
+			Checker:  errstyle.Checker{},
+			Content:  []byte("package errstyletest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package lint
+
+import "github.com/surullabs/lint/checkers"
+
+// ExpandPackages resolves patterns, such as ./... or an import path, into the
+// directories of the packages they match, as described in
+// checkers.ExpandPackages. Built-in checkers resolve ... wildcards the same way,
+// so all checkers in a Group see the same packages. Results are cached for 10
+// seconds or until ClearPackageCache is called.
+func ExpandPackages(patterns ...string) ([]string, error) {
+	return checkers.ExpandPackages(patterns...)
+}
+
+// ClearPackageCache removes all patterns resolved by ExpandPackages from the
+// cache, so that packages added or removed since are seen. Watch clears the
+// cache before each run.
+func ClearPackageCache() {
+	checkers.ClearPackageCache()
+}
+
+// ExpandOptions controls how ExpandPackagesWith resolves patterns.
+type ExpandOptions = checkers.ExpandOptions
+
+// ExpandPackagesWith is like ExpandPackages but uses opts to control which
+// packages wildcards match. Built-in checkers always use the default options;
+// to lint vendored code, expand the patterns first and pass the directories to
+// Check.
+//
+//	dirs, err := lint.ExpandPackagesWith(lint.ExpandOptions{IncludeVendor: true}, "./...")
+//	if err != nil {
+//		return err
+//	}
+//	return group.Check(dirs...)
+func ExpandPackagesWith(opts ExpandOptions, patterns ...string) ([]string, error) {
+	return checkers.ExpandPackagesWith(opts, patterns...)
+}
+
+// NormalizeSpec classifies spec, which may be a .go file, a directory or an
+// import path, as described in checkers.NormalizeSpec. It returns the import
+// path of the package and, if spec names a single file, the file. Built-in
+// checkers given a single file only report problems in that file.
+func NormalizeSpec(spec string) (pkgImportPath string, singleFile string, err error) {
+	return checkers.NormalizeSpec(spec)
+}
@@ -0,0 +1,131 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/gocyclo"
+	"github.com/surullabs/lint/linelength"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestExpandPackages(t *testing.T) {
+	tmp, err := testutil.NewGopath("expand", []testutil.SourceFile{
+		{Content: []byte("package a\n"), Dest: filepath.Join("expand", "a", "a.go")},
+		{Content: []byte("package b\n"), Dest: filepath.Join("expand", "a", "b", "b.go")},
+		{Content: []byte("package x\n"), Dest: filepath.Join("expand", "a", "testdata", "x.go")},
+		{Content: []byte("package x\n"), Dest: filepath.Join("expand", "a", "_x", "x.go")},
+		{Content: []byte("package x\n"), Dest: filepath.Join("expand", "a", ".x", "x.go")},
+		{Content: []byte("package v\n"), Dest: filepath.Join("expand", "a", "vendor", "v", "v.go")},
+		{Content: []byte("package w\n"), Dest: filepath.Join("expand", "a", "b", "vendor", "w", "w.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	src := filepath.Join(tmp.Path, "src", "expand")
+	expected := filepath.Join(src, "a") + "," + filepath.Join(src, "a", "b")
+
+	dirs, err := lint.ExpandPackages("expand/a/...")
+	assert(t, err == nil && strings.Join(dirs, ",") == expected, fmt.Sprintf("%v %v", err, dirs))
+
+	t.Chdir(filepath.Join(src, "a"))
+	dirs, err = lint.ExpandPackages("./...", "expand/a")
+	assert(t, err == nil && strings.Join(dirs, ",") == expected, fmt.Sprintf("%v %v", err, dirs))
+
+	defer checkers.Unload("./...")
+	p, err := checkers.Load("./...")
+	assert(t, err == nil && strings.Join(p.Pkgs, ",") == "expand/a,expand/a/b", fmt.Sprintf("%v %v", err, p))
+
+	dirs, err = lint.ExpandPackagesWith(lint.ExpandOptions{IncludeVendor: true}, "./...")
+	expected += "," + filepath.Join(src, "a", "b", "vendor", "w") + "," + filepath.Join(src, "a", "vendor", "v")
+	assert(t, err == nil && strings.Join(dirs, ",") == expected, fmt.Sprintf("%v %v", err, dirs))
+
+	dirs, err = lint.ExpandPackages("./vendor/v")
+	assert(t, err == nil && strings.Join(dirs, ",") == filepath.Join(src, "a", "vendor", "v"), fmt.Sprintf("%v %v", err, dirs))
+
+	_, err = lint.ExpandPackages("expand/nosuchpackage")
+	assert(t, err != nil && strings.Contains(err.Error(), "nosuchpackage"), fmt.Sprintf("%v", err))
+}
+
+func TestExpandPackagesCache(t *testing.T) {
+	tmp, err := testutil.NewGopath("expandcache", []testutil.SourceFile{
+		{Content: []byte("package a\n"), Dest: filepath.Join("expandcache", "a", "a.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	defer lint.ClearPackageCache()
+	src := filepath.Join(tmp.Path, "src", "expandcache")
+
+	dirs, err := lint.ExpandPackages("expandcache/...")
+	assert(t, err == nil && strings.Join(dirs, ",") == filepath.Join(src, "a"), fmt.Sprintf("%v %v", err, dirs))
+
+	assert(t, os.MkdirAll(filepath.Join(src, "b"), 0755) == nil, "mkdir failed")
+	assert(t, os.WriteFile(filepath.Join(src, "b", "b.go"), []byte("package b\n"), 0644) == nil, "write failed")
+	dirs, err = lint.ExpandPackages("expandcache/...")
+	assert(t, err == nil && strings.Join(dirs, ",") == filepath.Join(src, "a"), fmt.Sprintf("cached: %v %v", err, dirs))
+
+	t.Setenv("GOFLAGS", "-tags=expandcache")
+	dirs, err = lint.ExpandPackages("expandcache/...")
+	expected := filepath.Join(src, "a") + "," + filepath.Join(src, "b")
+	assert(t, err == nil && strings.Join(dirs, ",") == expected, fmt.Sprintf("GOFLAGS: %v %v", err, dirs))
+
+	assert(t, os.RemoveAll(filepath.Join(src, "b")) == nil, "remove failed")
+	lint.ClearPackageCache()
+	dirs, err = lint.ExpandPackages("expandcache/...")
+	assert(t, err == nil && strings.Join(dirs, ",") == filepath.Join(src, "a"), fmt.Sprintf("cleared: %v %v", err, dirs))
+}
+
+func TestNormalizeSpec(t *testing.T) {
+	long := "package a\n\n// " + strings.Repeat("x", 100) + "\n"
+	complex := "package a\n\nfunc F(a, b bool) {\n\tif a {\n\t}\n\tif b {\n\t}\n}\n"
+	tmp, err := testutil.NewGopath("normalize", []testutil.SourceFile{
+		{Content: []byte(long + strings.Replace(complex[len("package a\n"):], "F", "G", 1)), Dest: filepath.Join("normalize", "a", "a.go")},
+		{Content: []byte(long + complex[len("package a\n"):]), Dest: filepath.Join("normalize", "a", "b.go")},
+		{Content: []byte("text\n"), Dest: filepath.Join("normalize", "a", "notes.txt")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	dir := filepath.Join(tmp.Path, "src", "normalize", "a")
+	t.Chdir(dir)
+
+	tests := []struct {
+		spec, pkg, file, err string
+	}{
+		{"normalize/a", "normalize/a", "", ""},
+		{".", "normalize/a", "", ""},
+		{dir, "normalize/a", "", ""},
+		{"./b.go", "normalize/a", filepath.Join(dir, "b.go"), ""},
+		{"b.go", "normalize/a", filepath.Join(dir, "b.go"), ""},
+		{"./...", "./...", "", ""},
+		{"./fooo", "", "", "no such file or directory: ./fooo"},
+		{"./fooo.go", "", "", "no such file or directory: ./fooo.go"},
+		{"./notes.txt", "", "", "not a .go file or directory: ./notes.txt"},
+		{"normalize/nosuchpackage", "", "", `cannot find package "normalize/nosuchpackage"`},
+	}
+	for _, test := range tests {
+		pkg, file, err := lint.NormalizeSpec(test.spec)
+		if test.err != "" {
+			assert(t, err != nil && strings.Contains(err.Error(), test.err), fmt.Sprintf("%s: %v", test.spec, err))
+			continue
+		}
+		assert(t, err == nil && pkg == test.pkg && file == test.file, fmt.Sprintf("%s: %v %s %s", test.spec, err, pkg, file))
+	}
+
+	defer checkers.Unload("./b.go")
+	err = linelength.Checker{Max: 80}.Check("./b.go")
+	assert(t, err != nil && !strings.Contains(err.Error(), "a.go") && strings.Contains(err.Error(), "b.go:3"), fmt.Sprintf("%v", err))
+	err = gocyclo.Checker{Max: 2}.Check("./b.go")
+	assert(t, err != nil && !strings.Contains(err.Error(), "a.go") && strings.Contains(err.Error(), "b.go"), fmt.Sprintf("%v", err))
+	err = linelength.Checker{Max: 80}.Check("./fooo")
+	assert(t, err != nil && strings.Contains(err.Error(), "no such file or directory: ./fooo"), fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,25 @@
+package lint
+
+import "github.com/surullabs/lint/checkers"
+
+// FailFast returns a Checker that applies checkers in order and stops at the first
+// one that returns an error. The error is prefixed as described in Group.Check.
+//
+// This trades completeness for speed: checkers after the first failure are not
+// run, so fixing the reported errors may reveal more on the next run. Use Group
+// to see every error at once.
+func FailFast(checkers ...Checker) Checker {
+	return failFast(checkers)
+}
+
+type failFast []Checker
+
+// Check runs each checker in f until one fails.
+func (f failFast) Check(pkgs ...string) error {
+	for _, checker := range f {
+		if errs := prefixErrors(prefixName(checker), checker.Check(pkgs...)); len(errs) > 0 {
+			return checkers.Error(errs...)
+		}
+	}
+	return nil
+}
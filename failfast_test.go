@@ -0,0 +1,33 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestFailFast(t *testing.T) {
+	var ran []string
+	check := func(name string, errs ...string) lint.Checker {
+		return checkFn(func(pkgs ...string) error {
+			ran = append(ran, name)
+			return checkers.Error(errs...)
+		})
+	}
+
+	err := lint.FailFast(check("a"), check("b")).Check()
+	assert(t, err == nil && fmt.Sprint(ran) == "[a b]", fmt.Sprintf("%v %v", err, ran))
+
+	ran = nil
+	err = lint.FailFast(check("a"), check("b", "one", "two"), check("c", "three")).Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: one\nlint_test.checkFn: two", fmt.Sprintf("%v", err))
+	assert(t, fmt.Sprint(ran) == "[a b]", fmt.Sprint(ran))
+	_, isList := err.(interface{ Errors() []string })
+	assert(t, isList, fmt.Sprintf("%T", err))
+
+	// Nested in a Group the errors are not prefixed again.
+	err = lint.Group{lint.FailFast(check("a", "one"))}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: one", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,125 @@
+// Package fielddoc provides lint integration for checking that exported struct
+// fields are documented.
+package fielddoc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported fields of exported
+// struct types, declared at package level in .go files, that have neither a
+// doc comment nor a trailing comment on their line. A single comment documents
+// every name of a field declaration, as in
+//
+//	X, Y int // Coordinates of the point.
+//
+// Embedded fields and fields of types declared in functions are not checked.
+// Test files are checked along with the package unless SkipTests is set.
+type Checker struct {
+	// SkipTests does not check _test.go files.
+	SkipTests bool
+	// SkipGenerated ignores files with a "// Code generated ... DO NOT EDIT."
+	// comment.
+	SkipGenerated bool
+}
+
+// Check reports each undocumented exported field in pkgs as
+//
+//	file.go:line:col: exported field ID of type User should have a doc comment
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each undocumented exported field in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		groups := [][]*ast.File{s.Files}
+		if !c.SkipTests {
+			groups = append(groups, s.TestFiles, s.XTestFiles)
+		}
+		var diags []checkers.Diagnostic
+		for _, files := range groups {
+			for _, f := range files {
+				diags = append(diags, c.checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each undocumented exported field in files as described in
+// Check.
+func (c Checker) CheckFiles(files ...string) error {
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if c.SkipTests && strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		diags = append(diags, c.checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each undocumented exported field in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.SkipTests && strings.HasSuffix(filename, "_test.go") {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return c.checkFile(fset, f)
+}
+
+func (c Checker) checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	if c.SkipGenerated && ast.IsGenerated(f) {
+		return nil
+	}
+	var diags []checkers.Diagnostic
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || !ts.Name.IsExported() {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if field.Doc != nil || field.Comment != nil {
+					continue
+				}
+				for _, name := range field.Names {
+					if !name.IsExported() {
+						continue
+					}
+					p := fset.Position(name.Pos())
+					diags = append(diags, checkers.Diagnostic{
+						File:    p.Filename,
+						Line:    p.Line,
+						Col:     p.Column,
+						Message: "exported field " + name.Name + " of type " + ts.Name.Name + " should have a doc comment",
+					})
+				}
+			}
+		}
+	}
+	return diags
+}
@@ -0,0 +1,81 @@
+package fielddoc_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/fielddoc"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package fielddoctest
+
+import "io"
+
+// User is a user.
+type User struct {
+	ID   int
+	Name string // The name of the user.
+	// Email is where to write.
+	Email string
+	X, Y  int // Position of the user.
+	A, b  int
+	io.Reader
+	age int
+}
+
+type internal struct {
+	ID int
+}
+
+func Local() {
+	type Local struct {
+		ID int
+	}
+}
+`
+
+const generated = `// Code generated by stringer. DO NOT EDIT.
+
+package fielddoctest
+
+type User struct {
+	ID int
+}
+`
+
+func TestFieldDoc(t *testing.T) {
+	testutil.Test(t, "fielddoctest", []testutil.StaticCheckTest{
+		{
+			Checker: fielddoc.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:7:2: exported field ID of type User should have a doc comment\n` +
+				`[^\n]*file\.go:12:2: exported field A of type User should have a doc comment$`),
+		},
+		{
+			Checker:  fielddoc.Checker{},
+			Content:  []byte(generated),
+			Validate: testutil.Contains("exported field ID of type User should have a doc comment"),
+		},
+		{
+			Checker:  fielddoc.Checker{SkipGenerated: true},
+			Content:  []byte(generated),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  fielddoc.Checker{},
+			Content:  []byte("package fielddoctest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
+
+func TestFieldDocSource(t *testing.T) {
+	c := fielddoc.Checker{SkipTests: true}
+	if err := c.CheckSource("user_test.go", []byte("package x\n\ntype T struct{ A int }\n")); err != nil {
+		t.Error(err)
+	}
+	err := c.CheckSource("user.go", []byte("package x\n\ntype T struct{ A int }\n"))
+	if err := testutil.HasSuffix("user.go:3:16: exported field A of type T should have a doc comment")(err); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// FileChecker is implemented by checkers that can check a list of .go files
+// directly, such as gofmt.Check or linelength.Checker. This is useful for tools,
+// such as editors, that already know which files changed.
+type FileChecker interface {
+	CheckFiles(files ...string) error
+}
+
+// CheckFiles checks files using c. If c implements FileChecker it is passed files
+// directly. Otherwise c is run for the packages containing files.
+func CheckFiles(c Checker, files ...string) error {
+	c, done := untimed(c)
+	defer done()
+	if fc, ok := c.(FileChecker); ok {
+		return fc.CheckFiles(files...)
+	}
+	pkgs, err := filePackages(files)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return nil
+	}
+	return c.Check(pkgs...)
+}
+
+// GroupFiles returns a FileChecker that applies checkers to files in order. It is
+// the same as Group(checkers).
+func GroupFiles(checkers ...Checker) FileChecker {
+	return Group(checkers)
+}
+
+// CheckFiles applies each of the checkers in g to files as described in the
+// function CheckFiles. Errors are prefixed as they are by Check.
+func (g Group) CheckFiles(files ...string) error {
+	var errs []string
+	for _, checker := range g.enabled() {
+		errs = append(errs, prefixErrors(prefixName(checker), CheckFiles(checker, files...))...)
+	}
+	return checkers.Error(errs...)
+}
+
+// filePackages returns the packages containing files, in the order they are first
+// seen. Packages outside GOPATH are returned as paths relative to the current
+// directory.
+func filePackages(files []string) ([]string, error) {
+	var pkgs []string
+	seen := map[string]bool{}
+	for _, file := range files {
+		dir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			return nil, err
+		}
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		pkg, err := dirPackage(dir)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func dirPackage(dir string) (string, error) {
+	if p, err := build.ImportDir(dir, build.FindOnly); err == nil && !build.IsLocalImport(p.ImportPath) && !strings.HasPrefix(p.ImportPath, "_") {
+		return p.ImportPath, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot find package for %s: %v", dir, err)
+	}
+	return "./" + filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,46 @@
+package lint_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/gocyclo"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/linelength"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestCheckFiles(t *testing.T) {
+	tmp, err := testutil.NewGopath("files", []testutil.SourceFile{
+		{Content: []byte("package a\n\nvar  a = 1\n"), Dest: filepath.Join("files", "a", "a.go")},
+		{Content: []byte("package a\n\nvar  b = 1\n"), Dest: filepath.Join("files", "a", "b.go")},
+		{Content: []byte("package c\n"), Dest: filepath.Join("files", "c", "c.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	src := filepath.Join(tmp.Path, "src", "files")
+	a, b, c := filepath.Join(src, "a", "a.go"), filepath.Join(src, "a", "b.go"), filepath.Join(src, "c", "c.go")
+
+	var checked []string
+	record := checkFn(func(pkgs ...string) error {
+		checked = append(checked, pkgs...)
+		return nil
+	})
+	g := lint.GroupFiles(gofmt.Native{}, linelength.Checker{Max: 9}, gocyclo.Checker{Max: 1}, record)
+	err = g.CheckFiles(a, c)
+	expected := []string{
+		"gofmt.Native: " + a + ": not gofmt-ed",
+		"linelength.Checker: " + a + ":3: line too long (10 > 9)",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+	assert(t, fmt.Sprint(checked) == "[files/a files/c]", fmt.Sprint(checked))
+
+	checked = nil
+	err = lint.CheckFiles(record, a, b)
+	assert(t, err == nil && fmt.Sprint(checked) == "[files/a]", fmt.Sprintf("%v %v", err, checked))
+}
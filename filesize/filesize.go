@@ -0,0 +1,160 @@
+// Package filesize provides lint integration for checking the size of source
+// files.
+package filesize
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports .go files, including test files,
+// that have more than MaxLines lines or declare more than MaxFuncs functions.
+type Checker struct {
+	// MaxLines is the largest allowed number of lines in a file. If it is 0 the
+	// number of lines is not checked.
+	MaxLines int
+	// MaxFuncs is the largest allowed number of functions and methods declared
+	// in a file. If it is 0 the number of functions is not checked.
+	MaxFuncs int
+	// CodeLinesOnly counts only lines containing code, ignoring blank lines and
+	// lines that only contain comments.
+	CodeLinesOnly bool
+}
+
+// Check reports each file in pkgs that is too large as
+//
+//	file.go: 812 lines exceeds limit of 500
+//	file.go: 41 functions exceeds limit of 30
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each limit exceeded by a file in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.MaxLines <= 0 && c.MaxFuncs <= 0 {
+		return nil, nil
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports files that are too large as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.MaxLines <= 0 && c.MaxFuncs <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		found, err := c.checkFile(file)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		diags = append(diags, found...)
+	}
+	return diags
+}
+
+// CheckSource reports src, the contents of filename, if it is too large as
+// described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.checkSource(filename, src))
+}
+
+func (c Checker) checkFile(file string) ([]checkers.Diagnostic, error) {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return c.checkSource(file, src)
+}
+
+func (c Checker) checkSource(file string, src []byte) ([]checkers.Diagnostic, error) {
+	var diags []checkers.Diagnostic
+	if c.MaxLines > 0 {
+		n, err := c.lines(file, src)
+		if err != nil {
+			return nil, err
+		}
+		if n > c.MaxLines {
+			diags = append(diags, checkers.Diagnostic{File: file, Message: fmt.Sprintf("%d lines exceeds limit of %d", n, c.MaxLines)})
+		}
+	}
+	if c.MaxFuncs > 0 {
+		n, err := funcs(file, src)
+		if err != nil {
+			return nil, err
+		}
+		if n > c.MaxFuncs {
+			diags = append(diags, checkers.Diagnostic{File: file, Message: fmt.Sprintf("%d functions exceeds limit of %d", n, c.MaxFuncs)})
+		}
+	}
+	return diags, nil
+}
+
+// lines returns the number of lines in src, or the number of lines containing a
+// token other than a comment if CodeLinesOnly is set.
+func (c Checker) lines(file string, src []byte) (int, error) {
+	if !c.CodeLinesOnly {
+		n := bytes.Count(src, []byte("\n"))
+		if len(src) > 0 && src[len(src)-1] != '\n' {
+			n++
+		}
+		return n, nil
+	}
+	fset := token.NewFileSet()
+	var errs scanner.ErrorList
+	var s scanner.Scanner
+	s.Init(fset.AddFile(file, fset.Base(), len(src)), src, errs.Add, 0)
+	code := map[int]bool{}
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			// Semicolons inserted at the end of a line are not code.
+			continue
+		}
+		// Raw strings may span several lines, all of which are code.
+		line := fset.Position(pos).Line
+		for i := 0; i <= strings.Count(lit, "\n"); i++ {
+			code[line+i] = true
+		}
+	}
+	if len(errs) > 0 {
+		errs.Sort()
+		return 0, errs.Err()
+	}
+	return len(code), nil
+}
+
+// funcs returns the number of functions and methods declared in src.
+func funcs(file string, src []byte) (int, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, src, 0)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, decl := range f.Decls {
+		if _, ok := decl.(*ast.FuncDecl); ok {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,55 @@
+package filesize_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/filesize"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = "package filesizetest\n\n" +
+	"// A is documented.\n" +
+	"func A() {}\n" +
+	"\n" +
+	"/*\n" +
+	"B is documented.\n" +
+	"*/\n" +
+	"func B() string {\n" +
+	"\treturn `a\n" +
+	"b`\n" +
+	"}\n" +
+	"\n" +
+	"type t struct{}\n" +
+	"\n" +
+	"func (t) c() {}"
+
+func TestFileSize(t *testing.T) {
+	testutil.Test(t, "filesizetest", []testutil.StaticCheckTest{
+		{
+			Checker:  filesize.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  filesize.Checker{MaxLines: 16, MaxFuncs: 3},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: filesize.Checker{MaxLines: 15, MaxFuncs: 2},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go: 16 lines exceeds limit of 15\n` +
+				`.*file\.go: 3 functions exceeds limit of 2$`),
+		},
+		{
+			Checker:  filesize.Checker{MaxLines: 8, CodeLinesOnly: true},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  filesize.Checker{MaxLines: 7, CodeLinesOnly: true},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go: 8 lines exceeds limit of 7$`),
+		},
+	})
+}
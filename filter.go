@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// PathFilter is a Checker that only keeps errors for some files. It is created
+// using FilterPaths.
+type PathFilter struct {
+	// DropUnknown drops errors that do not start with a file name. By default
+	// they are kept.
+	DropUnknown bool
+
+	checker          Checker
+	include, exclude []string
+	err              error
+}
+
+// FilterPaths returns a Checker that runs c and keeps an error only if its file,
+// parsed as described in ParseDiagnostic, matches at least one of include and none
+// of exclude. An empty include matches every file.
+//
+// Patterns use the syntax of path.Match with forward slashes, and a path element
+// of ** matches any number of directories, so
+//
+//	**/*.pb.go
+//
+// matches generated protobuf files in any directory. Patterns without a slash are
+// matched against the base name of the file. Patterns are matched against the file
+// as reported and, for absolute paths, against the path relative to the current
+// directory. If a pattern is malformed Check returns an error without running c.
+//
+// Errors that do not implement the errors interface described in Skip are returned
+// unmodified.
+func FilterPaths(c Checker, include, exclude []string) PathFilter {
+	err := validatePatterns(append(append([]string{}, include...), exclude...))
+	return PathFilter{checker: c, include: include, exclude: exclude, err: err}
+}
+
+// validatePatterns returns an error for the first malformed pattern in patterns.
+func validatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		for _, elem := range strings.Split(p, "/") {
+			if _, err := path.Match(elem, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f PathFilter) unwrap() interface{} { return f.checker }
+
+// Name returns the name of the wrapped checker.
+func (f PathFilter) Name() string { return checkerName(f.checker) }
+
+// Check runs the wrapped checker and filters its errors.
+func (f PathFilter) Check(pkgs ...string) error {
+	if f.err != nil {
+		return f.err
+	}
+	err := f.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok {
+		return err
+	}
+	var errs []string
+	for _, e := range list.Errors() {
+		if f.keep(ParseDiagnostic(e).File) {
+			errs = append(errs, e)
+		}
+	}
+	return checkers.Error(errs...)
+}
+
+func (f PathFilter) keep(file string) bool {
+	if file == "" {
+		return !f.DropUnknown
+	}
+	names := []string{filepath.ToSlash(file)}
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				names = append(names, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return (len(f.include) == 0 || anyGlob(f.include, names)) && !anyGlob(f.exclude, names)
+}
+
+func anyGlob(patterns, names []string) bool {
+	for _, p := range patterns {
+		for _, name := range names {
+			if !strings.Contains(p, "/") {
+				name = path.Base(name)
+			}
+			if matchGlob(p, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchGlob matches name against pattern as described in FilterPaths.
+func matchGlob(pattern, name string) bool {
+	return matchElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchElems(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchElems(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
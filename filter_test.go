@@ -0,0 +1,45 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestFilterPaths(t *testing.T) {
+	errs := checkFn(func(pkgs ...string) error {
+		return checkers.Error(
+			"/src/pkg/a.go:1: a",
+			"/src/pkg/a.pb.go:2:3: generated",
+			"/src/pkg/sub/b_gen.go:3: generated",
+			"/src/vendor/c.go:4: vendored",
+			"no file here",
+		)
+	})
+	for i, test := range []struct {
+		include, exclude []string
+		expected         string
+	}{
+		{nil, nil, "/src/pkg/a.go:1: a\n/src/pkg/a.pb.go:2:3: generated\n/src/pkg/sub/b_gen.go:3: generated\n/src/vendor/c.go:4: vendored\nno file here"},
+		{nil, []string{"**/*.pb.go", "*_gen.go"}, "/src/pkg/a.go:1: a\n/src/vendor/c.go:4: vendored\nno file here"},
+		{[]string{"/src/pkg/**"}, []string{"**/sub/*"}, "/src/pkg/a.go:1: a\n/src/pkg/a.pb.go:2:3: generated\nno file here"},
+		{[]string{"**/vendor/**/*.go"}, nil, "/src/vendor/c.go:4: vendored\nno file here"},
+		{[]string{"a.go"}, nil, "/src/pkg/a.go:1: a\nno file here"},
+	} {
+		err := lint.FilterPaths(errs, test.include, test.exclude).Check()
+		assert(t, err != nil && err.Error() == test.expected, fmt.Sprintf("%d: %v", i, err))
+	}
+
+	f := lint.FilterPaths(errs, []string{"**/c.go"}, nil)
+	f.DropUnknown = true
+	err := f.Check()
+	assert(t, err != nil && err.Error() == "/src/vendor/c.go:4: vendored", fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.FilterPaths(errs, nil, []string{"/src/**"})}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: no file here", fmt.Sprintf("%v", err))
+
+	err = lint.FilterPaths(errs, nil, []string{"**/[.go"}).Check()
+	assert(t, err != nil && err.Error() == `invalid pattern "**/[.go": syntax error in pattern`, fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Fixer is implemented by checkers that can correct the problems they report,
+// such as gofmt.Check, gofmt.Native and goimports.Check. Fix rewrites the files
+// in pkgs that Check would report, replacing each file atomically so that an
+// interrupted run never leaves a file partially written.
+type Fixer interface {
+	Fix(pkgs ...string) error
+}
+
+// Fix fixes the problems c reports in pkgs, such as to format code from a test
+// run with a flag:
+//
+//	if *fix {
+//		err = lint.Fix(c, "./...")
+//	}
+//
+// Checkers that wrap a single checker, such as those returned by WithSeverity,
+// fix using the checker they wrap. Wrappers that filter errors, such as those
+// returned by Skip and FilterPaths, do not limit the files that are fixed. Groups
+// fix using each of their checkers that support fixing and ignore the rest. Fix
+// fails with "<name>: checker does not support fixing" if no checker in c
+// supports fixing.
+func Fix(c Checker, pkgs ...string) error {
+	if f, ok := fixer(c); ok {
+		return f.Fix(pkgs...)
+	}
+	return fmt.Errorf("%s: checker does not support fixing", checkerName(c))
+}
+
+// fixer returns the Fixer for c, looking through wrappers and groups.
+func fixer(c interface{}) (Fixer, bool) {
+	switch c := c.(type) {
+	case Fixer:
+		return c, true
+	case composite:
+		var fixers fixGroup
+		for _, child := range c.children() {
+			if f, ok := fixer(child); ok {
+				fixers = append(fixers, namedFixer{prefixName(child), f})
+			}
+		}
+		return fixers, len(fixers) > 0
+	case timed:
+		if f, ok := fixer(c.checker); ok {
+			return timedFixer{timed: c, fixer: f}, true
+		}
+	case wrapper:
+		return fixer(c.unwrap())
+	}
+	return nil, false
+}
+
+// namedFixer holds a Fixer along with the prefix for its errors.
+type namedFixer struct {
+	prefix string
+	fixer  Fixer
+}
+
+type fixGroup []namedFixer
+
+// Fix runs each fixer in g in turn, prefixing errors as described in
+// Group.Check.
+func (g fixGroup) Fix(pkgs ...string) error {
+	var errs []string
+	for _, f := range g {
+		errs = append(errs, prefixErrors(f.prefix, f.fixer.Fix(pkgs...))...)
+	}
+	return checkers.Error(errs...)
+}
@@ -0,0 +1,55 @@
+package lint_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/goimports"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestFix(t *testing.T) {
+	const pkg = "fixtest"
+	checkers.Unload(pkg)
+	defer checkers.Unload(pkg)
+	tmp, err := testutil.NewGopath(pkg, []testutil.SourceFile{
+		{Content: []byte("package fixtest\n\nimport (\n\t\"os\"\n\t\"fmt\"\n)\n\nvar  _, _ = fmt.Println, os.Exit\n"), Dest: filepath.Join(pkg, "a.go")},
+		{Content: []byte("package fixtest\n"), Dest: filepath.Join(pkg, "b.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	file := filepath.Join(tmp.Path, "src", pkg, "a.go")
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	g := lint.Group{lint.WithSeverity(lint.SeverityWarning, gofmt.Native{}), goimports.Check{}, namedCheck{}}
+	assert(t, g.Check(pkg) != nil, "expected errors before fixing")
+	err = lint.Fix(g, pkg)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	data, err := ioutil.ReadFile(file)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	expected := "package fixtest\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nvar _, _ = fmt.Println, os.Exit\n"
+	assert(t, string(data) == expected, string(data))
+	info, err := os.Stat(file)
+	assert(t, err == nil && info.Mode().Perm() == 0600, fmt.Sprintf("%v %v", err, info.Mode()))
+	err = lint.Group{gofmt.Native{}, goimports.Check{}}.Check(pkg)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	// Nothing is left behind in the package directory.
+	entries, err := ioutil.ReadDir(filepath.Dir(file))
+	assert(t, err == nil && len(entries) == 2, fmt.Sprintf("%v %d", err, len(entries)))
+
+	err = lint.Fix(namedCheck{}, pkg)
+	assert(t, err != nil && err.Error() == "govet.Checker: checker does not support fixing", fmt.Sprintf("%v", err))
+	err = lint.Fix(lint.Group{namedCheck{}}, pkg)
+	assert(t, err != nil && err.Error() == "lint.Group: checker does not support fixing", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,94 @@
+// Package floateq provides lint integration for finding floating point values
+// compared for equality.
+package floateq
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports == and != comparisons of two
+// floating point values, in .go files including test files. Rounding errors
+// make such comparisons fail for values that are equal in exact arithmetic, as
+// in 0.1+0.2 == 0.3, so values should be compared within a tolerance. Types are
+// resolved using go/types, and comparisons of two constants are not reported.
+//
+// NaN is not handled specially. It is unequal to every value including itself,
+// so x != x, the traditional test for NaN, is reported and is clearer written
+// as math.IsNaN(x).
+type Checker struct {
+	// AllowZero does not report comparisons to a constant 0, often used to check
+	// for an unset value or before dividing.
+	AllowZero bool
+	// SkipTests does not check _test.go files, in which exact results are often
+	// expected.
+	SkipTests bool
+}
+
+// Check reports each comparison of floats for equality in pkgs as
+//
+//	file.go:line:col: comparing floats with ==; use an epsilon tolerance
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each comparison of floats for
+// equality in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports comparisons of floats for equality in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(!c.SkipTests)
+		files := [][]*ast.File{s.Files}
+		if !c.SkipTests {
+			files = append(files, s.TestFiles, s.XTestFiles)
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range files {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					bin, ok := n.(*ast.BinaryExpr)
+					if !ok || (bin.Op != token.EQL && bin.Op != token.NEQ) {
+						return true
+					}
+					x, y := info.Types[bin.X], info.Types[bin.Y]
+					if !isFloat(x.Type) || !isFloat(y.Type) || (x.Value != nil && y.Value != nil) {
+						return true
+					}
+					if c.AllowZero && (isZero(x.Value) || isZero(y.Value)) {
+						return true
+					}
+					diags = append(diags, s.Diagnostic(bin.OpPos, "comparing floats with %s; use an epsilon tolerance", bin.Op))
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// isFloat reports whether typ is a floating point type.
+func isFloat(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsFloat != 0
+}
+
+// isZero reports whether v is a constant equal to 0.
+func isZero(v constant.Value) bool {
+	return v != nil && v.Kind() != constant.Unknown && constant.Sign(v) == 0
+}
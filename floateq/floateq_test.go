@@ -0,0 +1,83 @@
+package floateq_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/floateq"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package floateqtest
+
+type Celsius float64
+
+const Pi = 3.14159
+
+func Compare(a, b float64, c Celsius, f float32, n int) bool {
+	if a == b {
+		return true
+	}
+	if c != 36.6 {
+		return false
+	}
+	if a == 0 || f != 0.0 {
+		return false
+	}
+	if n == 0 || Pi == 3.14159 {
+		return true
+	}
+	return a != a
+}
+`
+
+func TestFloatEq(t *testing.T) {
+	testutil.Test(t, "floateqtest", []testutil.StaticCheckTest{
+		{
+			Checker: floateq.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:7: comparing floats with ==; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:11:7: comparing floats with !=; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:14:7: comparing floats with ==; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:14:17: comparing floats with !=; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:20:11: comparing floats with !=; use an epsilon tolerance$`),
+		},
+		{
+			Checker: floateq.Checker{AllowZero: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:7: comparing floats with ==; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:11:7: comparing floats with !=; use an epsilon tolerance\n` +
+				`[^\n]*file\.go:20:11: comparing floats with !=; use an epsilon tolerance$`),
+		},
+		{
+			Checker:  floateq.Checker{},
+			Content:  []byte("package floateqtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
+
+func TestFloatEqSkipTests(t *testing.T) {
+	checkers.Unload("floateqskip")
+	tmp, err := testutil.NewGopath("floateqskip", []testutil.SourceFile{
+		{
+			Content: []byte("package floateqskip\n\nfunc Half(x float64) float64 { return x / 2 }\n"),
+			Dest:    filepath.Join("floateqskip", "half.go"),
+		},
+		{
+			Content: []byte("package floateqskip\n\nimport \"testing\"\n\nfunc TestHalf(t *testing.T) {\n\tif Half(1) != 0.5 {\n\t\tt.Fail()\n\t}\n}\n"),
+			Dest:    filepath.Join("floateqskip", "half_test.go"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := testutil.MatchesRegexp(`^[^\n]*half_test\.go:6:13: comparing floats with !=; use an epsilon tolerance$`)(floateq.Checker{}.Check("floateqskip")); err != nil {
+		t.Error(err)
+	}
+	if err := (floateq.Checker{SkipTests: true}).Check("floateqskip"); err != nil {
+		t.Error(err)
+	}
+}
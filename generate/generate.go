@@ -0,0 +1,209 @@
+// Package generate provides lint integration for checking that the output of
+// go generate is up to date.
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultTimeout is the time each invocation of go generate is allowed to take
+// if Checker.Timeout is 0.
+const DefaultTimeout = time.Minute
+
+// Checker implements lint.Checker and reports files whose content differs from
+// what go generate produces. The files of each package directory are copied to a
+// temporary directory inside it and go generate is run there for each file with
+// an allowed //go:generate directive, so the files being checked are never
+// modified. The copy stays inside the same module or GOPATH tree, so generators
+// that load the package, such as stringer, work as they do in the original. Its
+// name starts with an underscore, so ... wildcards do not match it. Generators
+// that read files outside the package directory using relative paths will not
+// work in the copy.
+//
+// Generators are arbitrary commands, so Checker only runs directives whose
+// command is one of Commands and a zero Checker runs nothing.
+type Checker struct {
+	// Commands holds the generator commands that may be run, such as
+	// "stringer". A directive is run if its command, the first word after
+	// //go:generate, is exactly one of Commands, so "stringer" does not allow
+	// /tmp/stringer. Other directives are ignored.
+	Commands []string
+	// Timeout is the time each invocation of go generate, one for each file with
+	// allowed directives, is allowed to take. If it is 0, DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Check reports each file in pkgs that is not up to date as
+//
+//	file.go: generated output is stale; run go generate
+//
+// Files created by go generate that do not exist in the package are reported
+// the same way.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each file in pkgs that is not up to
+// date.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if len(c.Commands) == 0 {
+		return nil, nil
+	}
+	directive := c.directive()
+	var diags []checkers.Diagnostic
+	for _, pkg := range pkgs {
+		p, err := checkers.Load(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load go files for %s: %v", pkg, err)
+		}
+		var dirs []string
+		files := map[string][]string{}
+		for _, file := range p.Files {
+			dir := filepath.Dir(file)
+			if _, ok := files[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			files[dir] = append(files[dir], file)
+		}
+		for _, dir := range dirs {
+			d, err := c.dirDiagnostics(directive, dir, files[dir])
+			if err != nil {
+				return nil, err
+			}
+			diags = append(diags, d...)
+		}
+	}
+	return diags, nil
+}
+
+// directive returns a regexp matching the allowed //go:generate directives. It is
+// also passed to go generate -run, which matches the full text of a directive.
+func (c Checker) directive() *regexp.Regexp {
+	commands := make([]string, len(c.Commands))
+	for i, command := range c.Commands {
+		commands[i] = regexp.QuoteMeta(command)
+	}
+	return regexp.MustCompile(`^//go:generate\s+(?:` + strings.Join(commands, "|") + `)(?:\s|$)`)
+}
+
+// dirDiagnostics runs go generate for a copy of files, the files of dir, and
+// returns a diagnostic for each file that differs from its copy.
+func (c Checker) dirDiagnostics(directive *regexp.Regexp, dir string, files []string) ([]checkers.Diagnostic, error) {
+	var generators []string
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".go") {
+			continue
+		}
+		ok, err := hasDirective(directive, file)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			generators = append(generators, filepath.Base(file))
+		}
+	}
+	if len(generators) == 0 {
+		return nil, nil
+	}
+	tmp, err := ioutil.TempDir(dir, "_lint-generate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	for _, file := range files {
+		if err := copyFile(file, filepath.Join(tmp, filepath.Base(file))); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range generators {
+		if err := c.run(directive, tmp, name); err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Join(dir, name), err)
+		}
+	}
+	entries, err := ioutil.ReadDir(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dir %s: %v", tmp, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	var diags []checkers.Diagnostic
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		generated, err := ioutil.ReadFile(filepath.Join(tmp, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		file := filepath.Join(dir, entry.Name())
+		if committed, err := ioutil.ReadFile(file); err != nil || !bytes.Equal(committed, generated) {
+			diags = append(diags, checkers.Diagnostic{File: file, Message: "generated output is stale; run go generate"})
+		}
+	}
+	return diags, nil
+}
+
+// run runs the directives of file in dir that match directive.
+func (c Checker) run(directive *regexp.Regexp, dir, file string) error {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "generate", "-run", directive.String(), file)
+	cmd.Dir, cmd.Stderr = dir, &stderr
+	// Generators started by go generate are not killed with it and may keep its
+	// output open, so stop waiting for them soon after the timeout.
+	cmd.WaitDelay = time.Second
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("go generate timed out after %v", timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("go generate failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// hasDirective reports whether file contains a line matching directive.
+func hasDirective(directive *regexp.Regexp, file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		if directive.MatchString(strings.TrimRight(s.Text(), " \t\r")) {
+			return true, nil
+		}
+	}
+	return false, s.Err()
+}
+
+func copyFile(src, dst string) error {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, data, info.Mode().Perm())
+}
@@ -0,0 +1,68 @@
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/generate"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package generatetest
+
+//go:generate cp template.txt gen.go
+//go:generate touch ignored.go
+`
+
+func TestGenerate(t *testing.T) {
+	tmp, err := testutil.NewGopath("generatetest", []testutil.SourceFile{
+		{Content: []byte(src), Dest: filepath.Join("generatetest", "fresh", "file.go")},
+		{Content: []byte("package generatetest\n"), Dest: filepath.Join("generatetest", "fresh", "template.txt")},
+		{Content: []byte("package generatetest\n"), Dest: filepath.Join("generatetest", "fresh", "gen.go")},
+		{Content: []byte(src), Dest: filepath.Join("generatetest", "stale", "file.go")},
+		{Content: []byte("package generatetest\n\nconst X = 1\n"), Dest: filepath.Join("generatetest", "stale", "template.txt")},
+		{Content: []byte("package generatetest\n"), Dest: filepath.Join("generatetest", "stale", "gen.go")},
+		{Content: []byte(src), Dest: filepath.Join("generatetest", "missing", "file.go")},
+		{Content: []byte("package generatetest\n"), Dest: filepath.Join("generatetest", "missing", "template.txt")},
+		{Content: []byte("package generatetest\n\n//go:generate sleep 10\n"), Dest: filepath.Join("generatetest", "slow", "file.go")},
+		{Content: []byte("package generatetest\n\n//go:generate /bin/cp template.txt gen.go\n"), Dest: filepath.Join("generatetest", "path", "file.go")},
+		{Content: []byte("package generatetest\n"), Dest: filepath.Join("generatetest", "path", "template.txt")},
+		{Content: []byte("package generatetest\n\n//go:generate sh -c \"go list -f {{.Root}} > root.txt\"\n"), Dest: filepath.Join("generatetest", "gopath", "file.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	// The copy go generate runs in is inside GOPATH, like the package.
+	if err := os.WriteFile(filepath.Join(tmp.Path, "src", "generatetest", "gopath", "root.txt"), []byte(tmp.Path+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range []string{"fresh", "stale", "missing", "slow", "path", "gopath"} {
+		checkers.Unload("generatetest/" + pkg)
+		defer checkers.Unload("generatetest/" + pkg)
+	}
+	checker := generate.Checker{Commands: []string{"cp"}}
+	tests := []struct {
+		checker  generate.Checker
+		pkg      string
+		validate func(error) error
+	}{
+		{generate.Checker{}, "generatetest/stale", testutil.NoError},
+		{checker, "generatetest/fresh", testutil.NoError},
+		{checker, "generatetest/stale", testutil.MatchesRegexp(`^[^\n]*stale/gen\.go: generated output is stale; run go generate$`)},
+		{checker, "generatetest/missing", testutil.MatchesRegexp(`^[^\n]*missing/gen\.go: generated output is stale; run go generate$`)},
+		{checker, "generatetest/slow", testutil.NoError},
+		{generate.Checker{Commands: []string{"sleep"}, Timeout: 100 * time.Millisecond}, "generatetest/slow", testutil.Contains("go generate timed out after 100ms")},
+		{checker, "generatetest/path", testutil.NoError},
+		{generate.Checker{Commands: []string{"sh"}}, "generatetest/gopath", testutil.NoError},
+	}
+	for i, test := range tests {
+		if err := test.validate(test.checker.Check(test.pkg)); err != nil {
+			t.Errorf("%d: %s: %v", i, strings.TrimPrefix(test.pkg, "generatetest/"), err)
+		}
+	}
+}
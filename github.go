@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteGitHubActions writes err, as returned by Group.Check, to w as GitHub
+// Actions workflow commands, so that each error is shown as an annotation of the
+// line it refers to. Errors are parsed using Diagnostics and written one per
+// line as
+//
+//	::error file=file.go,line=23,col=4,title=govet.Check::err is unintentionally shadowed.
+//
+// Errors tagged by WithSeverity are written as ::warning or ::notice commands.
+// Parts that could not be parsed are left out, and errors that do not refer to
+// a file are written as ::error::message, including the checker prefix. Messages
+// and properties are escaped as the workflow command syntax requires. A nil error
+// writes nothing.
+func WriteGitHubActions(w io.Writer, err error) error {
+	for _, d := range Diagnostics(err) {
+		var props []string
+		msg := d.Message
+		if d.File == "" {
+			if d.Checker != "" {
+				msg = d.Checker + ": " + msg
+			}
+		} else {
+			props = append(props, "file="+escapeProperty(d.File))
+			if d.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", d.Line))
+			}
+			if d.Col > 0 {
+				props = append(props, fmt.Sprintf("col=%d", d.Col))
+			}
+			if d.Checker != "" {
+				props = append(props, "title="+escapeProperty(d.Checker))
+			}
+		}
+		command := "error"
+		switch d.Severity {
+		case SeverityWarning:
+			command = "warning"
+		case SeverityInfo:
+			command = "notice"
+		}
+		if len(props) > 0 {
+			command += " " + strings.Join(props, ",")
+		}
+		if _, werr := fmt.Fprintf(w, "::%s::%s\n", command, escapeData(msg)); werr != nil {
+			return werr
+		}
+	}
+	return nil
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes the value of a workflow command property, which also
+// cannot contain the separators of properties.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,36 @@
+package lint_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestWriteGitHubActions(t *testing.T) {
+	err := checkers.Error(
+		"govet.Check: file.go:23:4: err is shadowed, 100% sure",
+		"golint.Check: warning: dir/a,b.go:7: exported func F should have comment",
+		"info: pkg/file.go: too long",
+		"errcheck.Check: build failed",
+		"load failed:\nline two\r",
+	)
+	var buf bytes.Buffer
+	werr := lint.WriteGitHubActions(&buf, err)
+	expected := "::error file=file.go,line=23,col=4,title=govet.Check::err is shadowed, 100%25 sure\n" +
+		"::warning file=dir/a%2Cb.go,line=7,title=golint.Check::exported func F should have comment\n" +
+		"::notice file=pkg/file.go::too long\n" +
+		"::error::errcheck.Check: build failed\n" +
+		"::error::load failed:%0Aline two%0D\n"
+	assert(t, werr == nil && buf.String() == expected, buf.String())
+
+	buf.Reset()
+	werr = lint.WriteGitHubActions(&buf, nil)
+	assert(t, werr == nil && buf.Len() == 0, buf.String())
+
+	buf.Reset()
+	werr = lint.WriteGitHubActions(&buf, errors.New("file.go:1: single"))
+	assert(t, werr == nil && buf.String() == "::error file=file.go,line=1::single\n", buf.String())
+}
@@ -0,0 +1,13 @@
+module github.com/surullabs/lint
+
+go 1.24
+
+require (
+	github.com/fsnotify/fsnotify v1.7.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/mibk/dupl v1.1.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+)
@@ -0,0 +1,185 @@
+// Package gocyclo provides lint integration for checking the cyclomatic
+// complexity of functions.
+package gocyclo
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports functions with a cyclomatic
+// complexity above Max. The complexity of a function is one plus the number of
+// if, for and range statements, non-default case and select clauses, and && and ||
+// operators in its body. Function literals are measured on their own and do not
+// add to the complexity of the enclosing function.
+//
+// Test files are checked along with the package.
+type Checker struct {
+	// Max is the highest allowed complexity. If it is 0 nothing is reported.
+	Max int
+}
+
+// Check reports each function in pkgs that is too complex as
+//
+//	file.go:line:col: function Foo has cyclomatic complexity 15 (> 10)
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each function in pkgs that is too
+// complex.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.Max <= 0 {
+		return nil, nil
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, c.checkPackage)
+}
+
+// CheckStream is like Check but calls out with each message as soon as the
+// package it belongs to has been checked.
+func (c Checker) CheckStream(pkgs []string, out func(msg string)) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(checkers.NewLoader(pkgs...).StreamDiagnostics(c.checkPackage, func(d checkers.Diagnostic) {
+		out(d.String())
+	}))
+}
+
+// CheckProgress is like Check but reports each package to p once it has been
+// checked.
+func (c Checker) CheckProgress(pkgs []string, p checkers.Progress) error {
+	if c.Max <= 0 {
+		p.Start(0)
+		return nil
+	}
+	return checkers.DiagnosticsError(checkers.NewLoader(pkgs...).ProgressDiagnostics(c.checkPackage, p))
+}
+
+func (c Checker) checkPackage(s *checkers.Source) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+		for _, f := range files {
+			diags = append(diags, c.checkFile(s.Fset, f)...)
+		}
+	}
+	return diags
+}
+
+// CheckFiles reports each function in files that is too complex as described in
+// Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, c.checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each function in src, the contents of filename, that is
+// too complex as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return c.checkFile(fset, f)
+}
+
+func (c Checker) checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	report := func(pos token.Pos, name string, body *ast.BlockStmt) {
+		if cx := complexity(body); cx > c.Max {
+			p := fset.Position(pos)
+			diags = append(diags, checkers.Diagnostic{
+				File:    p.Filename,
+				Line:    p.Line,
+				Col:     p.Column,
+				Message: fmt.Sprintf("function %s has cyclomatic complexity %d (> %d)", name, cx, c.Max),
+			})
+		}
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		name := funcName(fn)
+		report(fn.Pos(), name, fn.Body)
+		lits := 0
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.FuncLit); ok {
+				lits++
+				report(lit.Pos(), fmt.Sprintf("%s.func%d", name, lits), lit.Body)
+			}
+			return true
+		})
+	}
+	return diags
+}
+
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+func complexity(body *ast.BlockStmt) int {
+	cx := 1
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			cx++
+		case *ast.CaseClause:
+			if n.List != nil {
+				cx++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				cx++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				cx++
+			}
+		}
+		return true
+	})
+	return cx
+}
@@ -0,0 +1,87 @@
+package gocyclo_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint/gocyclo"
+	"github.com/surullabs/lint/testutil"
+)
+
+const complex = `package gocyclotest
+
+type T struct{}
+
+func (*T) Method(a, b int) int {
+	if a > 0 && b > 0 {
+		return 1
+	}
+	for i := 0; i < a; i++ {
+		switch {
+		case i == b:
+			return i
+		case i > b || i < 0:
+			return -i
+		default:
+		}
+	}
+	return 0
+}
+
+func Simple() func(int) bool {
+	return func(a int) bool {
+		if a > 0 {
+			return a > 1 || a < 10 && a != 5
+		}
+		return false
+	}
+}
+`
+
+// streamed runs Checker with CheckStream and fails if the streamed messages do
+// not match the returned error.
+type streamed struct{ gocyclo.Checker }
+
+func (s streamed) Check(pkgs ...string) error {
+	var msgs []string
+	err := s.CheckStream(pkgs, func(msg string) { msgs = append(msgs, msg) })
+	if err != nil && strings.Join(msgs, "\n") != err.Error() {
+		return fmt.Errorf("streamed %q, returned %v", msgs, err)
+	}
+	return err
+}
+
+func TestGocyclo(t *testing.T) {
+	testutil.Test(t, "gocyclotest", []testutil.StaticCheckTest{
+		{
+			Checker:  gocyclo.Checker{Max: 10},
+			Content:  []byte(complex),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  gocyclo.Checker{},
+			Content:  []byte(complex),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: gocyclo.Checker{Max: 3},
+			Content: []byte(complex),
+			Validate: testutil.MatchesRegexp(`file\.go:5:1: function T\.Method has cyclomatic complexity 7 \(> 3\)\n` +
+				`.*file\.go:22:9: function Simple\.func1 has cyclomatic complexity 4 \(> 3\)$`),
+		},
+		{
+			Checker: streamed{gocyclo.Checker{Max: 3}},
+			Content: []byte(complex),
+			Validate: testutil.MatchesRegexp(`file\.go:5:1: function T\.Method has cyclomatic complexity 7 \(> 3\)\n` +
+				`.*file\.go:22:9: function Simple\.func1 has cyclomatic complexity 4 \(> 3\)$`),
+		},
+		{
+			Checker: gocyclo.Checker{Max: 1},
+			Content: []byte(`package gocyclotest
+sfsff
+`),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
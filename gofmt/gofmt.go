@@ -15,11 +15,25 @@ type Check struct {
 //   gofmt -d <files>
 //
 // for all files in pkgs.
-func (Check) Check(pkgs ...string) error {
+func (c Check) Check(pkgs ...string) error {
 	files, err := checkers.GoFiles(pkgs...)
 	if err != nil {
 		return err
 	}
+	return c.CheckFiles(files...)
+}
+
+// Fix formats each file in pkgs that is not formatted, replacing it in place. It
+// formats files in process, as Native does, so the gofmt binary is not needed.
+func (Check) Fix(pkgs ...string) error {
+	return Native{}.Fix(pkgs...)
+}
+
+// CheckFiles runs gofmt -d for files.
+func (Check) CheckFiles(files ...string) error {
+	if len(files) == 0 {
+		return nil
+	}
 	data, err := exec.Command("gofmt", append([]string{"-d"}, files...)...).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%v: %s", err, string(data))
@@ -52,6 +52,76 @@ type A struct {
 	})
 }
 
+func TestNative(t *testing.T) {
+	testutil.Test(t, "gofmttest", []testutil.StaticCheckTest{
+		{
+			Checker: gofmt.Native{},
+			Content: []byte(`package gofmttest
+
+import (
+	"fmt"
+)
+
+func TestFunc() {
+	fmt.Println("This is a properly formatted file")
+}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: gofmt.Native{},
+			Content: []byte(`package gofmttest
+
+import (
+	"fmt"
+)
+
+func TestFunc() {
+  fmt.Println("This is a poorly formatted file")
+}
+`),
+			Validate: testutil.HasSuffix("gofmttest/file.go: not gofmt-ed"),
+		},
+		{
+			Checker: gofmt.Native{},
+			Content: []byte(`package gofmttest
+
+		blah`),
+			Validate: testutil.Contains("expected declaration, found blah"),
+		},
+		{
+			Checker: gofmt.Native{},
+			Content: []byte(`package gofmttest
+
+type T struct{ A int }
+
+var ts = []T{T{A: 1}}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: gofmt.Native{Simplify: true},
+			Content: []byte(`package gofmttest
+
+type T struct{ A int }
+
+var ts = []T{T{A: 1}}
+`),
+			Validate: testutil.HasSuffix("gofmttest/file.go: not gofmt-ed"),
+		},
+		{
+			Checker: gofmt.Native{Simplify: true},
+			Content: []byte(`package gofmttest
+
+type T struct{ A int }
+
+var ts = []T{{A: 1}}
+`),
+			Validate: testutil.NoError,
+		},
+	})
+}
+
 const expectedUnformatted = `File not formatted: diff GOFMT_TMP_FOLDER
 --- GOFMT_TMP_FOLDER
 +++ GOFMT_TMP_FOLDER
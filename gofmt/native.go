@@ -0,0 +1,119 @@
+package gofmt
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Native implements lint.Checker for gofmt without running the gofmt binary.
+// Files are formatted in process using go/format.
+type Native struct {
+	// Simplify applies the simplifications made by gofmt -s before comparing.
+	Simplify bool
+}
+
+// Check formats all files in pkgs and reports each file that differs from its
+// formatted version as
+//
+//	file.go: not gofmt-ed
+func (n Native) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(n.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each file in pkgs that is not
+// formatted.
+func (n Native) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.GoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return n.fileDiagnostics(files)
+}
+
+// CheckFiles formats files and reports those that are not formatted as described
+// in Check.
+func (n Native) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(n.fileDiagnostics(files))
+}
+
+// CheckSource reports src, the contents of filename, if it is not formatted as
+// described in Check.
+func (n Native) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(n.sourceDiagnostics(filename, src), nil)
+}
+
+// Fix formats each file in pkgs that is not formatted, replacing it in place.
+// Files that cannot be parsed are left alone and reported.
+func (n Native) Fix(pkgs ...string) error {
+	files, err := checkers.GoFiles(pkgs...)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, file := range files {
+		if err := n.fixFile(file); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return checkers.Error(errs...)
+}
+
+func (n Native) fixFile(file string) error {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", file, err)
+	}
+	formatted, err := n.format(file, src)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(src, formatted) {
+		return nil
+	}
+	return checkers.WriteFileAtomic(file, formatted)
+}
+
+func (n Native) fileDiagnostics(files []string) ([]checkers.Diagnostic, error) {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		diags = append(diags, n.sourceDiagnostics(file, src)...)
+	}
+	return diags, nil
+}
+
+func (n Native) sourceDiagnostics(filename string, src []byte) []checkers.Diagnostic {
+	formatted, err := n.format(filename, src)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	if !bytes.Equal(src, formatted) {
+		return []checkers.Diagnostic{{File: filename, Message: "not gofmt-ed"}}
+	}
+	return nil
+}
+
+func (n Native) format(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if n.Simplify {
+		simplify(f)
+	}
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, f); err != nil {
+		return nil, fmt.Errorf("%s: failed to format: %v", filename, err)
+	}
+	return buf.Bytes(), nil
+}
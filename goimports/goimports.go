@@ -0,0 +1,259 @@
+// Package goimports provides lint integration for checking that imports are
+// organized the way goimports organizes them.
+package goimports
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Check implements lint.Checker and verifies that imports are grouped and sorted
+// as goimports would produce them. Standard library imports come first, followed
+// by third-party imports and then any imports matching LocalPrefix. Each group is
+// sorted and separated from the next by a blank line. As with goimports, imports
+// of one kind may be split into several groups as long as no group mixes kinds.
+//
+// Only import blocks are checked, so Check can be used alongside gofmt.Check
+// without reporting the same problem twice.
+type Check struct {
+	// LocalPrefix is a comma-separated list of import path prefixes that are
+	// placed in their own group after third-party imports, like goimports -local.
+	LocalPrefix string
+}
+
+// Check reports each file in pkgs with imports that are not organized as
+//
+//	file.go: imports not organized
+func (c Check) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each file in pkgs with imports that
+// are not organized.
+func (c Check) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.GoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports each file in files with imports that are not organized as
+// described in Check.
+func (c Check) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource reports src, the contents of filename, if its imports are not
+// organized as described in Check.
+func (c Check) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.sourceDiagnostics(filename, src), nil)
+}
+
+// Fix organizes the imports of each file in pkgs whose imports are not organized,
+// replacing it in place. Comments attached to an import are moved with it. Import
+// blocks holding comments that are not attached to an import cannot be organized
+// and are reported instead.
+func (c Check) Fix(pkgs ...string) error {
+	files, err := checkers.GoFiles(pkgs...)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, file := range files {
+		if err := c.fixFile(file); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return checkers.Error(errs...)
+}
+
+func (c Check) fixFile(file string) error {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", file, err)
+	}
+	fixed, err := c.organize(file, src)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(src, fixed) {
+		return nil
+	}
+	return checkers.WriteFileAtomic(file, fixed)
+}
+
+// organize returns src, the contents of filename, with each import block that is
+// not organized rewritten in the order described in Check.
+func (c Check) organize(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	out := src
+	// Rewrite blocks from the end so that earlier offsets stay valid.
+	for i := len(f.Decls) - 1; i >= 0; i-- {
+		gen, ok := f.Decls[i].(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() || c.organizedDecl(fset, gen) {
+			continue
+		}
+		body, err := c.organizedBody(f, gen, src, offset)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fset.Position(gen.Pos()), err)
+		}
+		out = append(append(append([]byte{}, out[:offset(gen.Lparen)+1]...), body...), out[offset(gen.Rparen):]...)
+	}
+	if bytes.Equal(out, src) {
+		return src, nil
+	}
+	return format.Source(out)
+}
+
+// organizedBody returns the text that belongs between the parentheses of gen
+// for its imports to be organized.
+func (c Check) organizedBody(f *ast.File, gen *ast.GenDecl, src []byte, offset func(token.Pos) int) ([]byte, error) {
+	attached := map[*ast.CommentGroup]bool{}
+	sections := make([][]*ast.ImportSpec, 3)
+	for _, spec := range gen.Specs {
+		s := spec.(*ast.ImportSpec)
+		path, _ := strconv.Unquote(s.Path.Value)
+		i := c.section(path)
+		sections[i] = append(sections[i], s)
+		attached[s.Doc], attached[s.Comment] = true, true
+	}
+	for _, cg := range f.Comments {
+		if cg.Pos() > gen.Lparen && cg.End() < gen.Rparen && !attached[cg] {
+			return nil, fmt.Errorf("cannot organize imports with unattached comments")
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString("\n")
+	for _, section := range sections {
+		if len(section) == 0 {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteString("\n")
+		}
+		sort.SliceStable(section, func(i, j int) bool {
+			pi, _ := strconv.Unquote(section[i].Path.Value)
+			pj, _ := strconv.Unquote(section[j].Path.Value)
+			return pi < pj
+		})
+		for _, s := range section {
+			start, end := s.Pos(), s.End()
+			if s.Doc != nil {
+				start = s.Doc.Pos()
+			}
+			if s.Comment != nil {
+				end = s.Comment.End()
+			}
+			buf.WriteString("\t")
+			buf.Write(src[offset(start):offset(end)])
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (c Check) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.sourceDiagnostics(file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Check) sourceDiagnostics(filename string, src interface{}) []checkers.Diagnostic {
+	ok, err := c.organized(filename, src)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	if !ok {
+		return []checkers.Diagnostic{{File: filename, Message: "imports not organized"}}
+	}
+	return nil
+}
+
+func (c Check) organized(filename string, src interface{}) (bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if !c.organizedDecl(fset, gen) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c Check) organizedDecl(fset *token.FileSet, gen *ast.GenDecl) bool {
+	var groups [][]string
+	last := -1
+	for _, spec := range gen.Specs {
+		s := spec.(*ast.ImportSpec)
+		path, err := strconv.Unquote(s.Path.Value)
+		if err != nil || path == "C" {
+			// Leave cgo imports alone, like goimports does.
+			return true
+		}
+		start := s.Pos()
+		if s.Doc != nil {
+			start = s.Doc.Pos()
+		}
+		if line := fset.Position(start).Line; last < 0 || line > last+1 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], path)
+		last = fset.Position(s.End()).Line
+	}
+	section := 0
+	for _, group := range groups {
+		if !sort.StringsAreSorted(group) || c.section(group[0]) < section {
+			return false
+		}
+		section = c.section(group[0])
+		for _, path := range group {
+			if c.section(path) != section {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c Check) section(path string) int {
+	if c.LocalPrefix != "" {
+		for _, prefix := range strings.Split(c.LocalPrefix, ",") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(path, prefix) {
+				return 2
+			}
+		}
+	}
+	// goimports considers any path whose first element has no dot to be
+	// part of the standard library.
+	if !strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,189 @@
+package goimports_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/goimports"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestGoImports(t *testing.T) {
+	testutil.Test(t, "goimportstest", []testutil.StaticCheckTest{
+		{
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/surullabs/lint"
+)
+
+var _, _, _ = fmt.Println, os.Exit, lint.Default
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"os"
+	"fmt"
+)
+
+var _, _ = fmt.Println, os.Exit
+`),
+			Validate: testutil.HasSuffix("goimportstest/file.go: imports not organized"),
+		},
+		{
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+	"github.com/surullabs/lint"
+)
+
+var _, _ = fmt.Println, lint.Default
+`),
+			Validate: testutil.HasSuffix("goimportstest/file.go: imports not organized"),
+		},
+		{
+			// Other formatting problems are left to gofmt.
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+)
+
+func TestFunc() {
+  fmt.Println("This is a poorly formatted file")
+}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: goimports.Check{LocalPrefix: "github.com/surullabs"},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+
+	"github.com/sridharv/fakegopath"
+	"github.com/surullabs/lint"
+)
+
+var _, _, _ = fmt.Println, fakegopath.NewTemporary, lint.Default
+`),
+			Validate: testutil.HasSuffix("goimportstest/file.go: imports not organized"),
+		},
+		{
+			Checker: goimports.Check{LocalPrefix: "github.com/surullabs"},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+
+	"github.com/sridharv/fakegopath"
+
+	// lint is local
+	"github.com/surullabs/lint"
+)
+
+var _, _, _ = fmt.Println, fakegopath.NewTemporary, lint.Default
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			// goimports leaves imports of one kind in several groups alone.
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+
+	"os"
+
+	"github.com/sridharv/fakegopath"
+
+	"github.com/surullabs/lint"
+)
+
+var _, _, _, _ = fmt.Println, os.Exit, fakegopath.NewTemporary, lint.Default
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"github.com/surullabs/lint"
+
+	"fmt"
+)
+
+var _, _ = fmt.Println, lint.Default
+`),
+			Validate: testutil.HasSuffix("goimportstest/file.go: imports not organized"),
+		},
+		{
+			Checker: goimports.Check{},
+			Content: []byte(`package goimportstest
+
+import (
+	"fmt"
+	fmt
+)
+`),
+			Validate: testutil.Contains("missing import path"),
+		},
+	})
+}
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		src, expected string
+	}{
+		{
+			src:      "package goimportstest\n\nimport (\n\t\"github.com/surullabs/lint\" // lint\n\t\"os\"\n\n\t// fmt formats.\n\t\"fmt\"\n\tx \"example.com/x\"\n)\n\nimport \"strings\"\n",
+			expected: "package goimportstest\n\nimport (\n\t// fmt formats.\n\t\"fmt\"\n\t\"os\"\n\n\tx \"example.com/x\"\n\t\"github.com/surullabs/lint\" // lint\n)\n\nimport \"strings\"\n",
+		},
+		{
+			src:      "package goimportstest\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+			expected: "package goimportstest\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+		},
+		{
+			src: "package goimportstest\n\nimport (\n\t\"os\"\n\t\"fmt\"\n\t// free-standing\n)\n",
+		},
+	}
+	for i, test := range tests {
+		checkers.Unload("goimportstest")
+		tmp, err := testutil.NewGopath("goimportstest", []testutil.SourceFile{
+			{Content: []byte(test.src), Dest: filepath.Join("goimportstest", "file.go")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = goimports.Check{}.Fix("goimportstest")
+		data, rerr := ioutil.ReadFile(filepath.Join(tmp.Path, "src", "goimportstest", "file.go"))
+		tmp.Reset()
+		switch {
+		case rerr != nil:
+			t.Fatal(rerr)
+		case test.expected == "":
+			if err == nil || !strings.Contains(err.Error(), "cannot organize imports with unattached comments") || string(data) != test.src {
+				t.Errorf("%d: expected an error leaving the file alone, got %v\n%s", i, err, data)
+			}
+		case err != nil || string(data) != test.expected:
+			t.Errorf("%d: %v: expected\n%s\ngot\n%s", i, err, test.expected, data)
+		}
+	}
+}
@@ -68,3 +68,132 @@ func TestFunc() {
 	},
 	)
 }
+
+func TestNative(t *testing.T) {
+	testutil.Test(t, "golinttest", []testutil.StaticCheckTest{
+		{
+			Checker: golint.Native{},
+			Content: []byte(`// Package golinttest is a test package
+package golinttest
+
+import (
+	"fmt"
+)
+
+// TestFunc is a test function
+func TestFunc() {
+	fmt.Println("This is a properly formatted file")
+}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: golint.Native{},
+			Content: []byte(`package golinttest
+
+import (
+	"fmt"
+)
+sfsff
+
+func TestFunc() {
+	fmt.Println("undocumented")
+}
+`),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+		{
+			Checker: golint.Native{},
+			Content: []byte(`package golinttest
+import (
+	"fmt"
+)
+
+func TestFunc() {
+	fmt.Println("This is a properly formatted file")
+}
+`),
+			Validate: testutil.HasSuffix(
+				"file.go:6:1: exported function TestFunc should have comment or be unexported"),
+		},
+		{
+			Checker: golint.Native{},
+			Content: []byte(`package golinttest
+
+// Type does things
+type T struct{}
+
+// does things
+func (T) Do() {}
+
+const (
+	A = 1
+)
+
+var B_c = 1
+
+func (t *T) userId() {}
+`),
+			Validate: testutil.MatchesRegexp(
+				`file\.go:3:1: comment on exported type T should be of the form "T ..." \(with optional leading article\)\n` +
+					`.*file\.go:6:1: comment on exported method T.Do should be of the form "Do ..."\n` +
+					`.*file\.go:10:2: exported const A should have comment \(or a comment on this block\) or be unexported\n` +
+					`.*file\.go:13:5: exported var B_c should have comment or be unexported\n` +
+					`.*file\.go:13:5: don't use underscores in Go names; var B_c should be BC\n` +
+					`.*file\.go:15:13: method userId should be userID$`),
+		},
+		{
+			Checker: golint.Native{Kinds: []golint.SymbolKind{golint.KindType, golint.KindVar}},
+			Content: []byte(`package golinttest
+
+// Type does things
+type T struct{}
+
+// does things
+func (T) Do() {}
+
+func Run() {}
+
+const A = 1
+
+var B = 1
+`),
+			Validate: testutil.MatchesRegexp(
+				`^[^\n]*file\.go:3:1: comment on exported type T should be of the form "T ..." \(with optional leading article\)\n` +
+					`[^\n]*file\.go:13:5: exported var B should have comment or be unexported$`),
+		},
+		{
+			Checker: golint.Native{Kinds: []golint.SymbolKind{golint.KindFunc}},
+			Content: []byte(`package golinttest
+
+type T struct{}
+
+func (T) Do() {}
+
+func Run() {}
+`),
+			Validate: testutil.MatchesRegexp(
+				`^[^\n]*file\.go:7:1: exported function Run should have comment or be unexported$`),
+		},
+		{
+			Checker: golint.Native{Confidence: 0.1},
+			Content: []byte(`package golinttest
+`),
+			Validate: testutil.HasSuffix(
+				"file.go:1:1: should have a package comment, unless it's in another file for this package"),
+		},
+		{
+			Checker: golint.Native{Confidence: 0.85},
+			Content: []byte(`// Package golinttest is a test package
+package golinttest
+
+func f() {
+	userId, user_name := 1, 2
+	_, _ = userId, user_name
+}
+`),
+			Validate: testutil.MatchesRegexp(
+				`^[^\n]*file\.go:5:10: don't use underscores in Go names; var user_name should be userName$`),
+		},
+	})
+}
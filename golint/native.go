@@ -0,0 +1,343 @@
+package golint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultConfidence is the confidence used by Native when Confidence is 0. It
+// matches the default of golint -min_confidence.
+const DefaultConfidence = 0.8
+
+// Native implements a lint.Checker for a subset of the golint rules without running
+// the golint binary. It reports
+//
+//   - exported identifiers without doc comments (confidence 1)
+//   - doc comments on exported identifiers that don't start with the name (confidence 1)
+//   - names that are not MixedCaps or misuse initialisms (confidence 0.8 - 0.9)
+//   - packages without a package comment in any file (confidence 0.2)
+//
+// using the same messages as golint. Packages are parsed as a whole, so a package
+// comment in any file of the package is sufficient.
+type Native struct {
+	// Confidence is the minimum confidence of reported problems. If it is 0,
+	// DefaultConfidence is used.
+	Confidence float64
+	// Kinds limits the doc comment rules to declarations of the given kinds, so
+	// that they can be enforced incrementally, such as for types first and for
+	// functions later. If it is empty, declarations of all kinds are checked.
+	// Naming and package comment rules are not affected.
+	Kinds []SymbolKind
+}
+
+// SymbolKind is a kind of top level declaration.
+type SymbolKind int
+
+// Symbol kinds used by Native.Kinds.
+const (
+	KindFunc SymbolKind = iota
+	KindType
+	KindConst
+	KindVar
+	KindMethod
+)
+
+var kindNames = []string{"func", "type", "const", "var", "method"}
+
+// String returns the lower case name of k.
+func (k SymbolKind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+type problem struct {
+	pos        token.Pos
+	confidence float64
+	msg        string
+}
+
+// Check runs the golint rules for pkgs. Problems are reported as
+//
+//	file.go:line:col: message
+func (n Native) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(n.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics runs the golint rules for pkgs and returns a diagnostic for
+// each problem.
+func (n Native) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.NewLoader(pkgs...).AnalyzeDiagnostics(n.check)
+}
+
+// CheckStream is like Check but calls out with each problem as soon as the
+// package it belongs to has been checked.
+func (n Native) CheckStream(pkgs []string, out func(msg string)) error {
+	return checkers.DiagnosticsError(checkers.NewLoader(pkgs...).StreamDiagnostics(n.check, func(d checkers.Diagnostic) {
+		out(d.String())
+	}))
+}
+
+// CheckProgress is like Check but reports each package to p once it has been
+// checked.
+func (n Native) CheckProgress(pkgs []string, p checkers.Progress) error {
+	return checkers.DiagnosticsError(checkers.NewLoader(pkgs...).ProgressDiagnostics(n.check, p))
+}
+
+// CheckLoaded runs the golint rules for the packages of l, which may be shared
+// with other checkers.
+func (n Native) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(l.AnalyzeDiagnostics(n.check))
+}
+
+func (n Native) check(s *checkers.Source) []checkers.Diagnostic {
+	min := n.Confidence
+	if min == 0 {
+		min = DefaultConfidence
+	}
+	l := &linter{}
+	if len(n.Kinds) > 0 {
+		l.kinds = map[SymbolKind]bool{}
+		for _, k := range n.Kinds {
+			l.kinds[k] = true
+		}
+	}
+	l.lintPackageComment(s)
+	for _, f := range s.Files {
+		l.lintExported(f)
+		l.lintNames(f)
+	}
+	sort.SliceStable(l.problems, func(i, j int) bool { return l.problems[i].pos < l.problems[j].pos })
+	var diags []checkers.Diagnostic
+	for _, p := range l.problems {
+		if p.confidence >= min {
+			diags = append(diags, s.Diagnostic(p.pos, "%s", p.msg))
+		}
+	}
+	return diags
+}
+
+type linter struct {
+	problems []problem
+	// kinds holds the kinds of declarations checked by lintExported, or nil if
+	// all are checked.
+	kinds map[SymbolKind]bool
+}
+
+// checks reports whether declarations of kind are checked by lintExported.
+func (l *linter) checks(kind SymbolKind) bool {
+	return l.kinds == nil || l.kinds[kind]
+}
+
+func (l *linter) errorf(pos token.Pos, confidence float64, format string, args ...interface{}) {
+	l.problems = append(l.problems, problem{pos: pos, confidence: confidence, msg: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lintPackageComment(s *checkers.Source) {
+	if len(s.Files) == 0 {
+		return
+	}
+	for _, f := range s.Files {
+		if f.Doc != nil {
+			return
+		}
+	}
+	l.errorf(s.Files[0].Package, 0.2, "should have a package comment, unless it's in another file for this package")
+}
+
+func (l *linter) lintExported(f *ast.File) {
+	if f.Name.Name == "main" {
+		return
+	}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			kind := KindFunc
+			if d.Recv != nil {
+				kind = KindMethod
+			}
+			if l.checks(kind) {
+				l.lintFuncDoc(d)
+			}
+		case *ast.GenDecl:
+			var kind SymbolKind
+			switch d.Tok {
+			case token.TYPE:
+				kind = KindType
+			case token.CONST:
+				kind = KindConst
+			case token.VAR:
+				kind = KindVar
+			default:
+				continue
+			}
+			if !l.checks(kind) {
+				continue
+			}
+			for _, spec := range d.Specs {
+				l.lintSpecDoc(d, spec)
+			}
+		}
+	}
+}
+
+func (l *linter) lintFuncDoc(fn *ast.FuncDecl) {
+	if !ast.IsExported(fn.Name.Name) {
+		return
+	}
+	kind, name := "function", fn.Name.Name
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		recv := receiverType(fn.Recv.List[0].Type)
+		if !ast.IsExported(recv) {
+			return
+		}
+		kind, name = "method", recv+"."+name
+	}
+	if fn.Doc == nil {
+		l.errorf(fn.Pos(), 1, "exported %s %s should have comment or be unexported", kind, name)
+		return
+	}
+	if prefix := fn.Name.Name + " "; !strings.HasPrefix(fn.Doc.Text(), prefix) {
+		l.errorf(fn.Doc.Pos(), 1, "comment on exported %s %s should be of the form \"%s...\"", kind, name, prefix)
+	}
+}
+
+func receiverType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverType(t.X)
+	case *ast.IndexExpr:
+		return receiverType(t.X)
+	case *ast.IndexListExpr:
+		return receiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+func (l *linter) lintSpecDoc(gen *ast.GenDecl, spec ast.Spec) {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		if !ast.IsExported(s.Name.Name) {
+			return
+		}
+		doc := s.Doc
+		if doc == nil && !gen.Lparen.IsValid() {
+			doc = gen.Doc
+		}
+		if doc == nil {
+			l.errorf(s.Pos(), 1, "exported type %s should have comment or be unexported", s.Name.Name)
+			return
+		}
+		text := doc.Text()
+		for _, article := range []string{"A ", "An ", "The "} {
+			if strings.HasPrefix(text, article) {
+				text = text[len(article):]
+				break
+			}
+		}
+		if !strings.HasPrefix(text, s.Name.Name+" ") {
+			l.errorf(doc.Pos(), 1, "comment on exported type %s should be of the form \"%s ...\" (with optional leading article)", s.Name.Name, s.Name.Name)
+		}
+	case *ast.ValueSpec:
+		kind := "var"
+		if gen.Tok == token.CONST {
+			kind = "const"
+		}
+		for _, name := range s.Names {
+			if !ast.IsExported(name.Name) {
+				continue
+			}
+			if s.Doc != nil || gen.Doc != nil {
+				return
+			}
+			block := ""
+			if gen.Lparen.IsValid() {
+				block = " (or a comment on this block)"
+			}
+			l.errorf(name.Pos(), 1, "exported %s %s should have comment%s or be unexported", kind, name.Name, block)
+			return
+		}
+	}
+}
+
+func (l *linter) lintNames(f *ast.File) {
+	check := func(id *ast.Ident, thing string) {
+		if id == nil || id.Name == "_" {
+			return
+		}
+		l.lintName(id, thing)
+	}
+	checkList := func(fl *ast.FieldList, thing string) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, id := range field.Names {
+				check(id, thing)
+			}
+		}
+	}
+	ast.Inspect(f, func(node ast.Node) bool {
+		switch v := node.(type) {
+		case *ast.AssignStmt:
+			if v.Tok != token.DEFINE {
+				return true
+			}
+			for _, expr := range v.Lhs {
+				if id, ok := expr.(*ast.Ident); ok {
+					check(id, "var")
+				}
+			}
+		case *ast.FuncDecl:
+			thing := "func"
+			if v.Recv != nil {
+				thing = "method"
+			}
+			check(v.Name, thing)
+			checkList(v.Type.Params, thing+" parameter")
+			checkList(v.Type.Results, thing+" result")
+		case *ast.GenDecl:
+			if v.Tok == token.IMPORT {
+				return true
+			}
+			thing := v.Tok.String()
+			for _, spec := range v.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					check(s.Name, thing)
+				case *ast.ValueSpec:
+					for _, id := range s.Names {
+						check(id, thing)
+					}
+				}
+			}
+		case *ast.InterfaceType:
+			for _, m := range v.Methods.List {
+				for _, id := range m.Names {
+					check(id, "interface method")
+				}
+			}
+		case *ast.RangeStmt:
+			if v.Tok != token.DEFINE {
+				return true
+			}
+			if id, ok := v.Key.(*ast.Ident); ok {
+				check(id, "range var")
+			}
+			if id, ok := v.Value.(*ast.Ident); ok {
+				check(id, "range var")
+			}
+		case *ast.StructType:
+			checkList(v.Fields, "struct field")
+		}
+		return true
+	})
+}
@@ -0,0 +1,133 @@
+// Package gorecover provides lint integration for checking that goroutines
+// recover from panics.
+package gorecover
+
+import (
+	"go/ast"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports go statements, in .go files
+// including test files, that start a goroutine which does not recover from
+// panics. A panic in such a goroutine crashes the whole program.
+//
+// A function literal recovers if a statement directly in its body defers a call
+// to recover, to a function literal calling recover or to one of Helpers. The
+// check is syntactic, so a goroutine running a named function or method cannot
+// be inspected and is always reported unless SkipNamed is set.
+type Checker struct {
+	// Helpers holds the names of functions that recover when deferred, such as
+	// "handlePanic". Calls qualified by a package or receiver, as in
+	// log.Recover(), match by the final name.
+	Helpers []string
+	// SkipNamed does not report goroutines running a named function or method.
+	SkipNamed bool
+	// SkipTests does not check _test.go files.
+	SkipTests bool
+	// SkipPackages holds path.Match patterns for the import paths of packages
+	// that are not checked.
+	SkipPackages []string
+}
+
+// Check reports each goroutine in pkgs that does not recover as
+//
+//	file.go:line:col: goroutine without recover
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each goroutine in pkgs that does not
+// recover.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.SkipPackages {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	helpers := make(map[string]bool, len(c.Helpers))
+	for _, name := range c.Helpers {
+		helpers[name] = true
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		if c.skipped(s.ImportPath) {
+			return nil
+		}
+		files := [][]*ast.File{s.Files}
+		if !c.SkipTests {
+			files = append(files, s.TestFiles, s.XTestFiles)
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range files {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					stmt, ok := n.(*ast.GoStmt)
+					if !ok {
+						return true
+					}
+					lit, isLit := ast.Unparen(stmt.Call.Fun).(*ast.FuncLit)
+					switch {
+					case !isLit && c.SkipNamed:
+					case !isLit || !recovers(helpers, lit.Body):
+						diags = append(diags, s.Diagnostic(stmt.Pos(), "goroutine without recover"))
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+func (c Checker) skipped(importPath string) bool {
+	for _, pattern := range c.SkipPackages {
+		if ok, _ := path.Match(pattern, importPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recovers reports whether a statement in body defers a call that recovers.
+func recovers(helpers map[string]bool, body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		d, ok := stmt.(*ast.DeferStmt)
+		if !ok {
+			continue
+		}
+		switch fn := ast.Unparen(d.Call.Fun).(type) {
+		case *ast.Ident:
+			if fn.Name == "recover" || helpers[fn.Name] {
+				return true
+			}
+		case *ast.SelectorExpr:
+			if helpers[fn.Sel.Name] {
+				return true
+			}
+		case *ast.FuncLit:
+			if callsRecover(fn.Body) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callsRecover reports whether body calls recover outside nested function
+// literals, where recover would not stop the panic.
+func callsRecover(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if id, ok := ast.Unparen(n.Fun).(*ast.Ident); ok && id.Name == "recover" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,75 @@
+package gorecover_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/gorecover"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package gorecovertest
+
+func handlePanic() { recover() }
+
+type logger struct{}
+
+func (logger) Recover() { recover() }
+
+func run() {}
+
+func f(log logger) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+			}
+		}()
+	}()
+	go func() {
+		defer handlePanic()
+	}()
+	go func() {
+		defer log.Recover()
+	}()
+	go func() {
+		if true {
+			defer func() { recover() }()
+		}
+	}()
+	go func() {
+		defer func() {
+			func() { recover() }()
+		}()
+	}()
+	go run()
+}
+`
+
+func TestGoRecover(t *testing.T) {
+	testutil.Test(t, "gorecovertest", []testutil.StaticCheckTest{
+		{
+			Checker: gorecover.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:18:2: goroutine without recover\n` +
+				`.*file\.go:21:2: goroutine without recover\n` +
+				`.*file\.go:24:2: goroutine without recover\n` +
+				`.*file\.go:29:2: goroutine without recover\n` +
+				`.*file\.go:34:2: goroutine without recover$`),
+		},
+		{
+			Checker: gorecover.Checker{Helpers: []string{"handlePanic", "Recover"}, SkipNamed: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:24:2: goroutine without recover\n` +
+				`.*file\.go:29:2: goroutine without recover$`),
+		},
+		{
+			Checker:  gorecover.Checker{SkipPackages: []string{"gorecover*"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  gorecover.Checker{SkipPackages: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+	})
+}
@@ -2,10 +2,11 @@ package gosimple
 
 import (
 	"github.com/surullabs/lint/checkers"
-	_ "honnef.co/go/simple" // Ensure the gosimple bin is downloaded.
 )
 
-// Check implements a gosimple Checker (https://github.com/dominikh/go-simple)
+// Check implements a gosimple Checker (https://github.com/dominikh/go-simple).
+// gosimple is installed when it is first run if it is not found, since it cannot
+// be required by a module.
 type Check struct {
 }
 
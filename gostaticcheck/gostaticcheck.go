@@ -1,15 +1,60 @@
 package gostaticcheck
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/surullabs/lint/checkers"
-	_ "honnef.co/go/staticcheck" // Ensure the staticcheck bin is downloaded.
 )
 
-// Check implements a gostaticcheck Checker (https://github.com/dominikh/go-staticcheck)
+// Check implements a gostaticcheck Checker (https://github.com/dominikh/go-staticcheck).
+// staticcheck is installed when it is first run if it is not found, since it
+// cannot be required by a module.
 type Check struct {
+	// Checks is a list of check selectors passed to staticcheck using -checks
+	Checks []string
+	// Exclude is a list of check codes, such as SA1000, whose findings are ignored
+	Exclude []string
 }
 
+// codeRE matches the check code at the end of a staticcheck finding.
+var codeRE = regexp.MustCompile(`\((\w+)\)$`)
+
 // Check runs gostaticcheck for pkgs
-func (Check) Check(pkgs ...string) error {
-	return checkers.Lint("staticcheck", "", "honnef.co/go/staticcheck/cmd/staticcheck", pkgs)
+func (c Check) Check(pkgs ...string) error {
+	err := checkers.Lint("staticcheck", "", "honnef.co/go/staticcheck/cmd/staticcheck", pkgs, c.Args()...)
+	list, ok := err.(interface {
+		Errors() []string
+	})
+	if !ok || len(c.Exclude) == 0 {
+		return err
+	}
+	var errs []string
+	for _, e := range list.Errors() {
+		if !c.excluded(e) {
+			errs = append(errs, e)
+		}
+	}
+	return checkers.Error(errs...)
+}
+
+func (c Check) excluded(finding string) bool {
+	match := codeRE.FindStringSubmatch(finding)
+	if match == nil {
+		return false
+	}
+	for _, code := range c.Exclude {
+		if code == match[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Args returns command line arguments used for staticcheck
+func (c Check) Args() []string {
+	if len(c.Checks) == 0 {
+		return nil
+	}
+	return []string{"-checks", strings.Join(c.Checks, ",")}
 }
@@ -52,6 +52,27 @@ func TestFunc() {
 			Validate: testutil.Contains(
 				" error parsing regexp: missing closing ): `foo(`"),
 		},
+		{
+			Checker: gostaticcheck.Check{Exclude: []string{"SA1000"}},
+			Content: []byte(`package gostaticchecktest
+import (
+	"regexp"
+)
+
+func TestFunc() {
+	regexp.Compile("foo(")
+}
+`),
+			Validate: testutil.NoError,
+		},
 	},
 	)
 }
+
+func TestArgs(t *testing.T) {
+	testutil.TestArgs(t, []testutil.ArgTest{
+		{A: gostaticcheck.Check{}, Expected: nil},
+		{A: gostaticcheck.Check{Exclude: []string{"SA1000"}}, Expected: nil},
+		{A: gostaticcheck.Check{Checks: []string{"all", "-ST1000"}}, Expected: []string{"-checks", "all,-ST1000"}},
+	})
+}
@@ -0,0 +1,304 @@
+package govet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/surullabs/lint/checkers"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/appends"
+	"golang.org/x/tools/go/analysis/passes/asmdecl"
+	"golang.org/x/tools/go/analysis/passes/assign"
+	"golang.org/x/tools/go/analysis/passes/atomic"
+	"golang.org/x/tools/go/analysis/passes/bools"
+	"golang.org/x/tools/go/analysis/passes/buildtag"
+	"golang.org/x/tools/go/analysis/passes/cgocall"
+	"golang.org/x/tools/go/analysis/passes/composite"
+	"golang.org/x/tools/go/analysis/passes/copylock"
+	"golang.org/x/tools/go/analysis/passes/defers"
+	"golang.org/x/tools/go/analysis/passes/directive"
+	"golang.org/x/tools/go/analysis/passes/errorsas"
+	"golang.org/x/tools/go/analysis/passes/framepointer"
+	"golang.org/x/tools/go/analysis/passes/httpresponse"
+	"golang.org/x/tools/go/analysis/passes/ifaceassert"
+	"golang.org/x/tools/go/analysis/passes/loopclosure"
+	"golang.org/x/tools/go/analysis/passes/lostcancel"
+	"golang.org/x/tools/go/analysis/passes/nilfunc"
+	"golang.org/x/tools/go/analysis/passes/printf"
+	"golang.org/x/tools/go/analysis/passes/shift"
+	"golang.org/x/tools/go/analysis/passes/sigchanyzer"
+	"golang.org/x/tools/go/analysis/passes/slog"
+	"golang.org/x/tools/go/analysis/passes/stdmethods"
+	"golang.org/x/tools/go/analysis/passes/stringintconv"
+	"golang.org/x/tools/go/analysis/passes/structtag"
+	"golang.org/x/tools/go/analysis/passes/testinggoroutine"
+	"golang.org/x/tools/go/analysis/passes/tests"
+	"golang.org/x/tools/go/analysis/passes/timeformat"
+	"golang.org/x/tools/go/analysis/passes/unmarshal"
+	"golang.org/x/tools/go/analysis/passes/unreachable"
+	"golang.org/x/tools/go/analysis/passes/unsafeptr"
+	"golang.org/x/tools/go/analysis/passes/unusedresult"
+)
+
+// DefaultAnalyzers returns the analyzers run by go vet. The shadow analyzer
+// (golang.org/x/tools/go/analysis/passes/shadow) is not included, as with go vet.
+func DefaultAnalyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		appends.Analyzer,
+		asmdecl.Analyzer,
+		assign.Analyzer,
+		atomic.Analyzer,
+		bools.Analyzer,
+		buildtag.Analyzer,
+		cgocall.Analyzer,
+		composite.Analyzer,
+		copylock.Analyzer,
+		defers.Analyzer,
+		directive.Analyzer,
+		errorsas.Analyzer,
+		framepointer.Analyzer,
+		httpresponse.Analyzer,
+		ifaceassert.Analyzer,
+		loopclosure.Analyzer,
+		lostcancel.Analyzer,
+		nilfunc.Analyzer,
+		printf.Analyzer,
+		shift.Analyzer,
+		sigchanyzer.Analyzer,
+		slog.Analyzer,
+		stdmethods.Analyzer,
+		stringintconv.Analyzer,
+		structtag.Analyzer,
+		testinggoroutine.Analyzer,
+		tests.Analyzer,
+		timeformat.Analyzer,
+		unmarshal.Analyzer,
+		unreachable.Analyzer,
+		unsafeptr.Analyzer,
+		unusedresult.Analyzer,
+	}
+}
+
+// Checker implements a lint.Checker that runs go/analysis analyzers in process
+// instead of running go vet. To check for shadowed variables in addition to the
+// go vet checks use
+//
+//	govet.Checker{Analyzers: append(govet.DefaultAnalyzers(), shadow.Analyzer)}
+//
+// Each package is analyzed along with its in-package tests. Type errors are reported
+// and only analyzers that set RunDespiteErrors are run for packages with type errors.
+type Checker struct {
+	// Analyzers are the analyzers to run. If empty, DefaultAnalyzers is used.
+	Analyzers []*analysis.Analyzer
+	// Disable holds the names of analyzers that should not be run.
+	Disable []string
+}
+
+// Check runs the analyzers for pkgs. Diagnostics are reported as
+//
+//	file.go:line:col: message
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics runs the analyzers for pkgs and returns their diagnostics,
+// along with any type errors.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...), func(checkers.Diagnostic) {})
+}
+
+// CheckStream is like Check but calls out with each diagnostic as soon as the
+// package it belongs to has been analyzed.
+func (c Checker) CheckStream(pkgs []string, out func(msg string)) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(checkers.NewLoader(pkgs...), func(d checkers.Diagnostic) {
+		out(d.String())
+	}))
+}
+
+// CheckLoaded runs the analyzers for the packages of l, which may be shared with
+// other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l, func(checkers.Diagnostic) {}))
+}
+
+// CheckProgress is like Check but reports each package to p once it has been
+// analyzed.
+func (c Checker) CheckProgress(pkgs []string, p checkers.Progress) error {
+	analyzers := c.analyzers()
+	if err := analysis.Validate(analyzers); err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(checkers.NewLoader(pkgs...).ProgressDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		return newRunner(s).run(analyzers)
+	}, p))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader, out func(d checkers.Diagnostic)) ([]checkers.Diagnostic, error) {
+	analyzers := c.analyzers()
+	if err := analysis.Validate(analyzers); err != nil {
+		return nil, err
+	}
+	return l.StreamDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		return newRunner(s).run(analyzers)
+	}, out)
+}
+
+func (c Checker) analyzers() []*analysis.Analyzer {
+	all := c.Analyzers
+	if len(all) == 0 {
+		all = DefaultAnalyzers()
+	}
+	disabled := make(map[string]bool, len(c.Disable))
+	for _, name := range c.Disable {
+		disabled[name] = true
+	}
+	var analyzers []*analysis.Analyzer
+	for _, a := range all {
+		if !disabled[a.Name] {
+			analyzers = append(analyzers, a)
+		}
+	}
+	return analyzers
+}
+
+type factKey struct {
+	obj types.Object // nil for package facts
+	typ reflect.Type
+}
+
+// runner runs analyzers for a single package. Facts are only tracked within the
+// package, so facts about imported objects are never found.
+type runner struct {
+	src     *checkers.Source
+	pkg     *types.Package
+	info    *types.Info
+	errs    []string
+	results map[*analysis.Analyzer]interface{}
+	failed  map[*analysis.Analyzer]error
+	facts   map[factKey]analysis.Fact
+	diags   []analysis.Diagnostic
+}
+
+func newRunner(s *checkers.Source) *runner {
+	pkg, info, errs := s.TypeCheck(true)
+	return &runner{
+		src:     s,
+		pkg:     pkg,
+		info:    info,
+		errs:    errs,
+		results: map[*analysis.Analyzer]interface{}{},
+		failed:  map[*analysis.Analyzer]error{},
+		facts:   map[factKey]analysis.Fact{},
+	}
+}
+
+func (r *runner) run(analyzers []*analysis.Analyzer) []checkers.Diagnostic {
+	diags := checkers.ErrorDiagnostics(checkers.Error(r.errs...))
+	for _, a := range analyzers {
+		if len(r.errs) > 0 && !a.RunDespiteErrors {
+			continue
+		}
+		if err := r.exec(a, true); err != nil {
+			diags = append(diags, checkers.Diagnostic{Message: fmt.Sprintf("%s: %s: %v", r.src.ImportPath, a.Name, err)})
+		}
+	}
+	sort.SliceStable(r.diags, func(i, j int) bool { return r.diags[i].Pos < r.diags[j].Pos })
+	for _, d := range r.diags {
+		diags = append(diags, r.src.Diagnostic(d.Pos, "%s", d.Message))
+	}
+	return diags
+}
+
+func (r *runner) exec(a *analysis.Analyzer, report bool) error {
+	if err, ok := r.failed[a]; ok {
+		return err
+	}
+	if _, ok := r.results[a]; ok {
+		return nil
+	}
+	resultOf := make(map[*analysis.Analyzer]interface{}, len(a.Requires))
+	for _, req := range a.Requires {
+		if err := r.exec(req, false); err != nil {
+			return err
+		}
+		resultOf[req] = r.results[req]
+	}
+	pass := &analysis.Pass{
+		Analyzer:   a,
+		Fset:       r.src.Fset,
+		Files:      append(append([]*ast.File{}, r.src.Files...), r.src.TestFiles...),
+		OtherFiles: r.otherFiles(),
+		Pkg:        r.pkg,
+		TypesInfo:  r.info,
+		TypesSizes: types.SizesFor("gc", build.Default.GOARCH),
+		ResultOf:   resultOf,
+		ReadFile:   os.ReadFile,
+		Report: func(d analysis.Diagnostic) {
+			if report {
+				r.diags = append(r.diags, d)
+			}
+		},
+		ImportObjectFact:  func(obj types.Object, fact analysis.Fact) bool { return r.importFact(obj, fact) },
+		ImportPackageFact: func(_ *types.Package, fact analysis.Fact) bool { return r.importFact(nil, fact) },
+		ExportObjectFact:  func(obj types.Object, fact analysis.Fact) { r.exportFact(obj, fact) },
+		ExportPackageFact: func(fact analysis.Fact) { r.exportFact(nil, fact) },
+		AllObjectFacts:    r.allObjectFacts,
+		AllPackageFacts:   r.allPackageFacts,
+	}
+	res, err := a.Run(pass)
+	if err != nil {
+		r.failed[a] = err
+		return err
+	}
+	r.results[a] = res
+	return nil
+}
+
+func (r *runner) otherFiles() []string {
+	b := r.src.Build
+	var files []string
+	for _, names := range [][]string{b.CFiles, b.CXXFiles, b.MFiles, b.HFiles, b.FFiles, b.SFiles, b.SwigFiles, b.SwigCXXFiles, b.SysoFiles} {
+		for _, name := range names {
+			files = append(files, filepath.Join(b.Dir, name))
+		}
+	}
+	return files
+}
+
+func (r *runner) importFact(obj types.Object, fact analysis.Fact) bool {
+	f, ok := r.facts[factKey{obj, reflect.TypeOf(fact)}]
+	if ok {
+		reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(f).Elem())
+	}
+	return ok
+}
+
+func (r *runner) exportFact(obj types.Object, fact analysis.Fact) {
+	r.facts[factKey{obj, reflect.TypeOf(fact)}] = fact
+}
+
+func (r *runner) allObjectFacts() []analysis.ObjectFact {
+	var facts []analysis.ObjectFact
+	for k, f := range r.facts {
+		if k.obj != nil {
+			facts = append(facts, analysis.ObjectFact{Object: k.obj, Fact: f})
+		}
+	}
+	return facts
+}
+
+func (r *runner) allPackageFacts() []analysis.PackageFact {
+	var facts []analysis.PackageFact
+	for k, f := range r.facts {
+		if k.obj == nil {
+			facts = append(facts, analysis.PackageFact{Package: r.pkg, Fact: f})
+		}
+	}
+	return facts
+}
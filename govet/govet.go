@@ -2,6 +2,7 @@ package govet
 
 import (
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/surullabs/lint/checkers"
@@ -28,6 +29,25 @@ func (c Check) Check(pkgs ...string) error {
 	return checkers.Error(errs...)
 }
 
+// CheckFiles runs go tool vet for files. Files are grouped by directory and each
+// directory is checked separately, as described in Check.
+func (c Check) CheckFiles(files ...string) error {
+	var dirs []string
+	byDir := map[string][]string{}
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], file)
+	}
+	var errs []string
+	for _, dir := range dirs {
+		errs = append(errs, c.runVet(byDir[dir])...)
+	}
+	return checkers.Error(errs...)
+}
+
 func (c Check) checkPackage(pkg string) []string {
 	if strings.HasSuffix(pkg, "...") {
 		return c.checkDir(pkg)
@@ -61,8 +81,8 @@ func (c Check) checkDir(pkg string) []string {
 	if err != nil {
 		return []string{err.Error()}
 	}
-	// Loop through each package since we'd like to ignore directories which have
-	// an _ prefix. In the future this allows us to skip vendor directories as well.
+	// Loop through each package since we'd like to ignore vendor and testdata
+	// directories and directories which have an _ or . prefix.
 	var errs []string
 	for _, pkg := range p.Pkgs {
 		errs = append(errs, c.checkPackage(pkg)...)
@@ -8,9 +8,9 @@ import (
 
 	"path/filepath"
 
-	"github.com/sridharv/fakegopath"
 	"github.com/surullabs/lint/govet"
 	"github.com/surullabs/lint/testutil"
+	"golang.org/x/tools/go/analysis/passes/shadow"
 )
 
 func testVetError(err error) error {
@@ -68,7 +68,7 @@ func main() {
 }`
 
 func TestGoVetMultiPackage_Issue7(t *testing.T) {
-	tmp, err := fakegopath.NewTemporaryWithFiles("multipkg", []fakegopath.SourceFile{
+	tmp, err := testutil.NewGopath("multipkg", []testutil.SourceFile{
 		{Content: []byte(file1), Dest: filepath.Join("root", "package1", "main.go")},
 		{Content: []byte(file2), Dest: filepath.Join("root", "package2", "main.go")},
 		{Content: []byte(file3), Dest: filepath.Join("root", "package3", "main.go")},
@@ -156,3 +156,87 @@ func TestFunc() (err error) {
 	})
 
 }
+
+func TestChecker(t *testing.T) {
+	unreachable := []byte(`package govettest
+
+import (
+	"fmt"
+)
+
+func TestFunc() {
+	a := "test"
+	b := a
+	fmt.Sprintf("test")
+	return
+	fmt.Println("This is a poorly formatted file", b)
+}
+`)
+	testutil.Test(t, "govettest", []testutil.StaticCheckTest{
+		{
+			Checker: govet.Checker{},
+			Content: []byte(`package govettest
+import (
+	"fmt"
+)
+
+// TestFunc is a test function
+func TestFunc() {
+	fmt.Println("This is a properly formatted file")
+}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: govet.Checker{},
+			Content: []byte(`package govettest
+
+import (
+	"fmt"
+)
+sfsff
+`),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+		{
+			Checker: govet.Checker{},
+			Content: []byte(`package govettest
+
+func TestFunc() {
+	a := 1
+}
+`),
+			Validate: testutil.HasSuffix("file.go:4:2: declared and not used: a"),
+		},
+		{
+			Checker: govet.Checker{},
+			Content: unreachable,
+			Validate: testutil.MatchesRegexp(`file\.go:10:\d+: result of fmt\.Sprintf call not used\n` +
+				`.*file\.go:12:2: unreachable code$`),
+		},
+		{
+			Checker:  govet.Checker{Disable: []string{"unusedresult", "unreachable"}},
+			Content:  unreachable,
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: govet.Checker{Analyzers: append(govet.DefaultAnalyzers(), shadow.Analyzer)},
+			Content: []byte(`package govettest
+
+import (
+	"fmt"
+)
+
+func TestFunc() (err error) {
+	_, err = fmt.Println("another")
+	if err != nil {
+		err := fmt.Errorf("some error: %v", err)
+		fmt.Println(err)
+	}
+	return err
+}
+`),
+			Validate: testutil.HasSuffix(`file.go:10:3: declaration of "err" shadows declaration at line 7`),
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"go/scanner"
+	"go/token"
+	"os"
+	"regexp"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// GrepChecker is a Checker that reports lines of Go source matching a regular
+// expression. It is created using Grep.
+type GrepChecker struct {
+	// CodeOnly ignores matches inside comments and string and character literals.
+	CodeOnly bool
+
+	re      *regexp.Regexp
+	message string
+}
+
+// Grep returns a Checker that reports each line of every .go file, including
+// test files, matching pattern as
+//
+//	file.go:line: message
+//
+// Grep panics if pattern is not a valid regular expression.
+func Grep(pattern, message string) GrepChecker {
+	return GrepChecker{re: regexp.MustCompile(pattern), message: message}
+}
+
+// Check searches all .go files in pkgs.
+func (g GrepChecker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(g.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics searches all .go files in pkgs and returns a diagnostic for
+// each match.
+func (g GrepChecker) CheckDiagnostics(pkgs ...string) ([]Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return g.fileDiagnostics(files), nil
+}
+
+// CheckFiles searches files.
+func (g GrepChecker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(g.fileDiagnostics(files), nil)
+}
+
+// CheckSource searches src, the contents of filename.
+func (g GrepChecker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(g.grepSource(filename, src))
+}
+
+func (g GrepChecker) fileDiagnostics(files []string) []Diagnostic {
+	var diags []Diagnostic
+	for _, file := range files {
+		matches, err := g.grep(file)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		diags = append(diags, matches...)
+	}
+	return diags
+}
+
+func (g GrepChecker) grep(file string) ([]Diagnostic, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return g.grepSource(file, src)
+}
+
+func (g GrepChecker) grepSource(file string, src []byte) ([]Diagnostic, error) {
+	if g.CodeOnly {
+		src = codeOnly(src)
+	}
+	var diags []Diagnostic
+	s := bufio.NewScanner(bytes.NewReader(src))
+	s.Buffer(nil, len(src)+1)
+	for line := 1; s.Scan(); line++ {
+		if g.re.Match(s.Bytes()) {
+			diags = append(diags, Diagnostic{File: file, Line: line, Message: g.message})
+		}
+	}
+	return diags, s.Err()
+}
+
+// codeOnly returns a copy of src with comments and literals replaced by spaces.
+// Newlines are kept so line numbers are unchanged.
+func codeOnly(src []byte) []byte {
+	code := append([]byte{}, src...)
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", fset.Base(), len(src)), src, func(token.Position, string) {}, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch tok {
+		case token.COMMENT, token.STRING, token.CHAR:
+		default:
+			continue
+		}
+		start := fset.Position(pos).Offset
+		end := start + len(lit)
+		if end > len(code) {
+			end = len(code)
+		}
+		for i := start; i < end; i++ {
+			if code[i] != '\n' {
+				code[i] = ' '
+			}
+		}
+	}
+	return code
+}
@@ -0,0 +1,46 @@
+package lint_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestGrep(t *testing.T) {
+	src := "package grep\n\nimport \"fmt\"\n\n// fmt.Println is banned\nfunc f() {\n\tfmt.Println(\"fmt.Println\")\n\t_ = `\nfmt.Println`\n}"
+	tmp, err := testutil.NewGopath("grep", []testutil.SourceFile{
+		{Content: []byte(src), Dest: filepath.Join("grep", "file.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	dir, file := "grep", filepath.Join(tmp.Path, "src", "grep", "file.go")
+
+	grep := lint.Grep(`fmt\.Println`, "use a logger")
+	err = grep.Check(dir)
+	expected := []string{
+		file + ":5: use a logger",
+		file + ":7: use a logger",
+		file + ":9: use a logger",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	grep.CodeOnly = true
+	err = grep.Check(dir)
+	assert(t, err != nil && err.Error() == file+":7: use a logger", fmt.Sprintf("%v", err))
+
+	err = lint.Grep(`time\.Sleep`, "don't sleep").Check(dir)
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.Grep(`^import`, "one"), lint.Grep(`^func`, "two")}.Check(dir)
+	expected = []string{
+		"lint.GrepChecker: " + file + ":3: one",
+		"lint.GrepChecker: " + file + ":6: two",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,210 @@
+// Package httpctx provides lint integration for finding HTTP handlers that do
+// not propagate the context of their request.
+package httpctx
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultCalls holds the functions and methods reported by Checker if Calls is
+// empty. Each has a variant taking a context.Context.
+var DefaultCalls = []string{
+	"database/sql.DB.Exec",
+	"database/sql.DB.Ping",
+	"database/sql.DB.Prepare",
+	"database/sql.DB.Query",
+	"database/sql.DB.QueryRow",
+	"database/sql.Stmt.Exec",
+	"database/sql.Stmt.Query",
+	"database/sql.Stmt.QueryRow",
+	"database/sql.Tx.Exec",
+	"database/sql.Tx.Prepare",
+	"database/sql.Tx.Query",
+	"database/sql.Tx.QueryRow",
+	"net.Dial",
+	"net.DialTimeout",
+	"net/http.Client.Get",
+	"net/http.Client.Head",
+	"net/http.Client.Post",
+	"net/http.Client.PostForm",
+	"net/http.Get",
+	"net/http.Head",
+	"net/http.NewRequest",
+	"net/http.Post",
+	"net/http.PostForm",
+}
+
+// Checker implements lint.Checker and reports calls in HTTP handlers, in .go
+// files other than test files, that do not use the context of the request, so
+// work started for a request is not cancelled when the client goes away. A
+// handler is a function or method with the signature of http.HandlerFunc,
+// including ServeHTTP methods, and calls in function literals it declares are
+// part of it. A call is reported if it calls one of Calls, or passes
+// context.Background() or context.TODO() as an argument.
+//
+// Signatures are resolved using go/types, so functions of other packages with
+// the same names are not reported. Reports can be suppressed with a comment on
+// the line of the call or the line before it of the form
+//
+//	//nolint:httpctx
+type Checker struct {
+	// Calls holds the functions and methods that do not take a context, as the
+	// import path of their package and their name, optionally qualified by the
+	// receiver type, such as "net/http.Get" or "database/sql.DB.Query". If it
+	// is empty, DefaultCalls is used.
+	Calls []string
+}
+
+// Check reports each call in a handler in pkgs that does not propagate the
+// context of the request as
+//
+//	file.go:line:col: handler makes a call without propagating r.Context()
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each call in a handler in pkgs that
+// does not propagate the context of the request.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports calls in handlers that do not propagate the context of the
+// request in the packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	calls := map[string]bool{}
+	list := c.Calls
+	if len(list) == 0 {
+		list = DefaultCalls
+	}
+	for _, name := range list {
+		calls[name] = true
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "httpctx.Checker")
+			ast.Inspect(f, func(n ast.Node) bool {
+				r, body := handler(info, n)
+				if body == nil {
+					return true
+				}
+				ast.Inspect(body, func(n ast.Node) bool {
+					if _, nested := handler(info, n); nested != nil {
+						return false
+					}
+					call, ok := n.(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					line := s.Fset.Position(call.Pos()).Line
+					if suppressed[line] || suppressed[line-1] {
+						return true
+					}
+					if fn := calledFunc(info, call); (fn != nil && calls[funcName(fn)]) || passesBackground(info, call) {
+						diags = append(diags, s.Diagnostic(call.Pos(), "handler makes a call without propagating %s.Context()", r))
+					}
+					return true
+				})
+				return true
+			})
+		}
+		return diags
+	})
+}
+
+// handler returns the name of the request parameter and the body of n if n is a
+// function with the signature of http.HandlerFunc, and a nil body otherwise.
+func handler(info *types.Info, n ast.Node) (string, *ast.BlockStmt) {
+	var typ *ast.FuncType
+	var body *ast.BlockStmt
+	switch fn := n.(type) {
+	case *ast.FuncDecl:
+		typ, body = fn.Type, fn.Body
+	case *ast.FuncLit:
+		typ, body = fn.Type, fn.Body
+	default:
+		return "", nil
+	}
+	var params []*ast.Ident
+	for _, field := range typ.Params.List {
+		params = append(params, field.Names...)
+	}
+	if body == nil || len(params) != 2 || typ.Results != nil && len(typ.Results.List) > 0 {
+		return "", nil
+	}
+	w, r := info.Defs[params[0]], info.Defs[params[1]]
+	if w == nil || r == nil || !isHTTP(w.Type(), "ResponseWriter") {
+		return "", nil
+	}
+	if ptr, ok := r.Type().(*types.Pointer); !ok || !isHTTP(ptr.Elem(), "Request") {
+		return "", nil
+	}
+	return r.Name(), body
+}
+
+// isHTTP reports whether typ is the type name of package net/http.
+func isHTTP(typ types.Type, name string) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "net/http" && named.Obj().Name() == name
+}
+
+// passesBackground reports whether call passes context.Background() or
+// context.TODO() as an argument.
+func passesBackground(info *types.Info, call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		inner, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if fn := calledFunc(info, inner); fn != nil {
+			if name := funcName(fn); name == "context.Background" || name == "context.TODO" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// calledFunc returns the function or method called by call, or nil.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	return fn
+}
+
+// funcName returns the name of fn as used in Calls.
+func funcName(fn *types.Func) string {
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		named, ok := types.Unalias(typ).(*types.Named)
+		if !ok {
+			return ""
+		}
+		name = named.Obj().Name() + "." + name
+	}
+	return fn.Pkg().Path() + "." + name
+}
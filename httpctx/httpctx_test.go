@@ -0,0 +1,67 @@
+package httpctx_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/httpctx"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package httpctxtest
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+type server struct{ db *sql.DB }
+
+func (s server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rows, _ := s.db.Query("SELECT 1")
+	rows.Close()
+	rows, _ = s.db.QueryContext(req.Context(), "SELECT 1")
+	rows.Close()
+}
+
+func Fetch(w http.ResponseWriter, r *http.Request) {
+	resp, _ := http.Get("http://example.com")
+	resp.Body.Close()
+	go func() {
+		http.NewRequestWithContext(context.Background(), "GET", "/", nil)
+	}()
+	//nolint:httpctx
+	http.Head("http://example.com")
+}
+
+func Register(mux *http.ServeMux) {
+	http.Get("/")
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Post("/", "text/plain", nil)
+	})
+}
+`
+
+func TestHTTPCtx(t *testing.T) {
+	testutil.Test(t, "httpctxtest", []testutil.StaticCheckTest{
+		{
+			Checker: httpctx.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:12:13: handler makes a call without propagating req\.Context\(\)\n` +
+				`[^\n]*file\.go:19:13: handler makes a call without propagating r\.Context\(\)\n` +
+				`[^\n]*file\.go:22:3: handler makes a call without propagating r\.Context\(\)\n` +
+				`[^\n]*file\.go:31:3: handler makes a call without propagating r\.Context\(\)$`),
+		},
+		{
+			Checker: httpctx.Checker{Calls: []string{"net/http.Post"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:22:3: handler makes a call without propagating r\.Context\(\)\n` +
+				`[^\n]*file\.go:31:3: handler makes a call without propagating r\.Context\(\)$`),
+		},
+		{
+			Checker:  httpctx.Checker{},
+			Content:  []byte("package httpctxtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,130 @@
+// Package hugeparam provides lint integration for finding large parameters
+// passed by value.
+package hugeparam
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultMaxBytes is the MaxBytes used by Checker when MaxBytes is 0.
+const DefaultMaxBytes = 128
+
+// Checker implements lint.Checker and reports parameters of functions and
+// function literals in .go files whose type is a struct or array larger than
+// MaxBytes. Sizes are computed using go/types for the gc compiler, so they match
+// the layout of the compiled program. Pointers, slices, maps and other reference
+// types are never reported, nor are parameters whose type is a type parameter.
+type Checker struct {
+	// MaxBytes is the size in bytes above which a parameter is reported. If it is
+	// 0, DefaultMaxBytes is used.
+	MaxBytes int
+	// CheckReceivers also checks the receivers of methods.
+	CheckReceivers bool
+	// GOARCH is the architecture sizes are computed for. If it is empty,
+	// build.Default.GOARCH is used.
+	GOARCH string
+}
+
+// Check reports each large parameter in pkgs as
+//
+//	file.go:line:col: parameter cfg passes 512-byte struct by value (> 128); consider a pointer
+//
+// Unnamed parameters are reported by position, as in "parameter 2".
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each large parameter in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports large parameters in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultMaxBytes
+	}
+	arch := c.GOARCH
+	if arch == "" {
+		arch = build.Default.GOARCH
+	}
+	sizes := types.SizesFor("gc", arch)
+	if sizes == nil {
+		return nil, fmt.Errorf("unknown GOARCH %q", arch)
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		check := func(kind string, fields *ast.FieldList) {
+			if fields == nil {
+				return
+			}
+			index := 0
+			for _, field := range fields.List {
+				names := field.Names
+				if len(names) == 0 {
+					names = []*ast.Ident{nil}
+				}
+				for _, name := range names {
+					index++
+					desc, size, ok := c.large(sizes, info.TypeOf(field.Type))
+					if !ok {
+						continue
+					}
+					label, pos := fmt.Sprintf("%s %d", kind, index), field.Pos()
+					if name != nil {
+						label, pos = kind+" "+name.Name, name.Pos()
+					}
+					diags = append(diags, s.Diagnostic(pos, "%s passes %d-byte %s by value (> %d); consider a pointer", label, size, desc, c.MaxBytes))
+				}
+			}
+		}
+		for _, f := range s.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					if c.CheckReceivers {
+						check("receiver", n.Recv)
+					}
+					check("parameter", n.Type.Params)
+				case *ast.FuncLit:
+					check("parameter", n.Type.Params)
+				}
+				return true
+			})
+		}
+		return diags
+	})
+}
+
+// large returns a description and the size of typ if it is a struct or array
+// larger than MaxBytes.
+func (c Checker) large(sizes types.Sizes, typ types.Type) (string, int64, bool) {
+	if typ == nil {
+		return "", 0, false
+	}
+	if _, ok := typ.(*types.TypeParam); ok {
+		return "", 0, false
+	}
+	var desc string
+	switch typ.Underlying().(type) {
+	case *types.Struct:
+		desc = "struct"
+	case *types.Array:
+		desc = "array"
+	default:
+		return "", 0, false
+	}
+	size := sizes.Sizeof(typ)
+	return desc, size, size > int64(c.MaxBytes)
+}
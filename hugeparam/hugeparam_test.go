@@ -0,0 +1,70 @@
+package hugeparam_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/hugeparam"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package hugeparamtest
+
+type Config struct {
+	Names [16]string
+	Ports [8]int
+}
+
+type Small struct{ A, B int }
+
+func (c Config) Get() string { return c.Names[0] }
+
+func (c *Config) Set(name string) { c.Names[0] = name }
+
+func Run(cfg Config, small Small, ptr *Config, list []Config, m map[string]Config, rest ...Config) {}
+
+func Unnamed(int, [64]byte) {}
+
+var _ = func(cfg Config) {}
+
+func Generic[T any](v T) {}
+`
+
+func TestHugeParam(t *testing.T) {
+	testutil.Test(t, "hugeparamtest", []testutil.StaticCheckTest{
+		{
+			Checker: hugeparam.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:14:10: parameter cfg passes 320-byte struct by value \(> 128\); consider a pointer\n` +
+				`.*file\.go:18:14: parameter cfg passes 320-byte struct by value \(> 128\); consider a pointer$`),
+		},
+		{
+			Checker:  hugeparam.Checker{MaxBytes: 512},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: hugeparam.Checker{MaxBytes: 32},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:14:10: parameter cfg passes 320-byte struct by value \(> 32\); consider a pointer\n` +
+				`.*file\.go:16:19: parameter 2 passes 64-byte array by value \(> 32\); consider a pointer\n` +
+				`.*file\.go:18:14: parameter cfg passes 320-byte struct by value \(> 32\); consider a pointer$`),
+		},
+		{
+			Checker: hugeparam.Checker{MaxBytes: 100, CheckReceivers: true, GOARCH: "386"},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:10:7: receiver c passes 160-byte struct by value \(> 100\); consider a pointer\n` +
+				`.*file\.go:14:10: parameter cfg passes 160-byte struct by value \(> 100\); consider a pointer\n` +
+				`.*file\.go:18:14: parameter cfg passes 160-byte struct by value \(> 100\); consider a pointer$`),
+		},
+		{
+			Checker:  hugeparam.Checker{MaxBytes: 32, GOARCH: "nosucharch"},
+			Content:  []byte(src),
+			Validate: testutil.Contains(`unknown GOARCH "nosucharch"`),
+		},
+		{
+			Checker:  hugeparam.Checker{MaxBytes: 32},
+			Content:  []byte("package hugeparamtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,183 @@
+// Package importorder provides lint integration for checking that imports are
+// grouped into sections in a fixed order.
+package importorder
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Std is the prefix in Checker.Sections that matches standard library imports,
+// which are the imports whose first path element contains no dot.
+const Std = "std"
+
+// Checker implements lint.Checker and verifies that the imports in each import
+// block of .go files, including test files, are grouped into Sections. Imports
+// must appear in the order of their sections and the imports of different
+// sections must be separated by a blank line. Imports of the same section may be
+// split into several groups and are not required to be sorted. A comment
+// attached to an import is considered part of it.
+//
+// For example, to require standard library imports, then third-party imports
+// and then the imports of example.com/project
+//
+//	importorder.Checker{
+//		Sections: [][]string{{importorder.Std}, nil, {"example.com/project"}},
+//		Default:  1,
+//	}
+type Checker struct {
+	// Sections holds the import path prefixes of each section, in order. An
+	// import belongs to the section with the longest matching prefix, or to the
+	// Default section if no prefix matches. If Sections is empty, nothing is
+	// checked.
+	Sections [][]string
+	// Default is the index in Sections of the section of imports that match no
+	// prefix.
+	Default int
+}
+
+// Check reports each misplaced import in pkgs as
+//
+//	file.go:line: import "github.com/x/y" is in the wrong section
+//
+// and each import that starts a new section without a blank line before it as
+//
+//	file.go:line: missing blank line between import sections
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each problem with the imports of
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if len(c.Sections) == 0 {
+		return nil, nil
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles checks the imports of files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if len(c.Sections) == 0 {
+		return nil
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource checks the imports of src, the contents of filename, as described
+// in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if len(c.Sections) == 0 {
+		return nil
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(c.sourceDiagnostics(filename, src), nil)
+}
+
+func (c Checker) validate() error {
+	if c.Default < 0 || c.Default >= len(c.Sections) {
+		return fmt.Errorf("default section %d is not one of the %d sections", c.Default, len(c.Sections))
+	}
+	return nil
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.sourceDiagnostics(file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of file if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) sourceDiagnostics(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	var diags []checkers.Diagnostic
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, d := range c.declDiagnostics(fset, gen) {
+			d.File = file
+			diags = append(diags, d)
+		}
+	}
+	return diags
+}
+
+// declDiagnostics checks the imports of gen. The diagnostics returned have no
+// file.
+func (c Checker) declDiagnostics(fset *token.FileSet, gen *ast.GenDecl) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	highest, prev, last := -1, -1, -1
+	for _, spec := range gen.Specs {
+		s := spec.(*ast.ImportSpec)
+		path, err := strconv.Unquote(s.Path.Value)
+		if err != nil || path == "C" {
+			// cgo imports have to stay next to their preamble.
+			continue
+		}
+		start := s.Pos()
+		if s.Doc != nil {
+			start = s.Doc.Pos()
+		}
+		line := fset.Position(s.Pos()).Line
+		grouped := last >= 0 && fset.Position(start).Line <= last+1
+		last = fset.Position(s.End()).Line
+		section := c.section(path)
+		switch {
+		case section < highest:
+			diags = append(diags, checkers.Diagnostic{Line: line, Message: fmt.Sprintf("import %q is in the wrong section", path)})
+		case grouped && section != prev:
+			diags = append(diags, checkers.Diagnostic{Line: line, Message: "missing blank line between import sections"})
+		}
+		if section > highest {
+			highest = section
+		}
+		prev = section
+	}
+	return diags
+}
+
+// section returns the index of the section path belongs to.
+func (c Checker) section(path string) int {
+	section, longest := c.Default, -1
+	for i, prefixes := range c.Sections {
+		for _, prefix := range prefixes {
+			if prefix == Std {
+				if longest < 0 && !strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+					section, longest = i, 0
+				}
+				continue
+			}
+			if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+				section, longest = i, len(prefix)
+			}
+		}
+	}
+	return section
+}
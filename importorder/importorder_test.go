@@ -0,0 +1,69 @@
+package importorder_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/importorder"
+	"github.com/surullabs/lint/testutil"
+)
+
+const ordered = `package importordertest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/other/lib"
+	// Comments are part of the import they are attached to.
+	"golang.org/x/tools"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+`
+
+const unordered = `package importordertest
+
+import (
+	"fmt"
+	"github.com/other/lib"
+
+	"github.com/surullabs/lint"
+	"os"
+
+	"golang.org/x/tools"
+)
+`
+
+func TestImportOrder(t *testing.T) {
+	sections := [][]string{{importorder.Std}, nil, {"github.com/surullabs/"}}
+	testutil.Test(t, "importordertest", []testutil.StaticCheckTest{
+		{
+			Checker:  importorder.Checker{},
+			Content:  []byte(unordered),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  importorder.Checker{Sections: sections, Default: 1},
+			Content:  []byte(ordered),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: importorder.Checker{Sections: sections, Default: 1},
+			Content: []byte(unordered),
+			Validate: testutil.MatchesRegexp(`file\.go:5: missing blank line between import sections\n` +
+				`.*file\.go:8: import "os" is in the wrong section\n` +
+				`.*file\.go:10: import "golang.org/x/tools" is in the wrong section$`),
+		},
+		{
+			Checker:  importorder.Checker{Sections: sections, Default: 3},
+			Content:  []byte(ordered),
+			Validate: testutil.Contains("default section 3 is not one of the 3 sections"),
+		},
+		{
+			Checker:  importorder.Checker{Sections: sections, Default: 1},
+			Content:  []byte("package importordertest\nimport sfsff\n"),
+			Validate: testutil.Contains("missing import path"),
+		},
+	})
+}
@@ -0,0 +1,91 @@
+// Package imports provides lint integration for forbidding imports.
+package imports
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports imports of banned packages in .go
+// files, including test files. Named, dot and blank imports are all reported.
+type Checker struct {
+	// Banned maps the import path of each banned package to the reason it is
+	// banned, which is included verbatim in the message. The reason may be empty.
+	Banned map[string]string
+}
+
+// Check reports each import of a banned package in pkgs as
+//
+//	file.go:line: import "io/ioutil" is banned: use io and os instead
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each import of a banned package in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if len(c.Banned) == 0 {
+		return nil, nil
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports imports of banned packages in files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if len(c.Banned) == 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource reports imports of banned packages in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if len(c.Banned) == 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.sourceDiagnostics(filename, src), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.sourceDiagnostics(file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of file if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) sourceDiagnostics(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ImportsOnly)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	var diags []checkers.Diagnostic
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		reason, banned := c.Banned[path]
+		if !banned {
+			continue
+		}
+		msg := fmt.Sprintf("import %q is banned", path)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		diags = append(diags, checkers.Diagnostic{File: file, Line: fset.Position(spec.Pos()).Line, Message: msg})
+	}
+	return diags
+}
@@ -0,0 +1,50 @@
+package imports_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/imports"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package importstest
+
+import (
+	"fmt"
+	iu "io/ioutil"
+	. "log"
+	_ "net/http/pprof"
+)
+
+var (
+	_ = fmt.Sprint("io/ioutil")
+	_ = iu.ReadFile
+	_ = Print
+)
+`
+
+func TestImports(t *testing.T) {
+	testutil.Test(t, "importstest", []testutil.StaticCheckTest{
+		{
+			Checker:  imports.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  imports.Checker{Banned: map[string]string{"os": "not used"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: imports.Checker{Banned: map[string]string{
+				"io/ioutil":      "use io and os instead",
+				"log":            "use example.com/internal/log",
+				"net/http/pprof": "",
+			}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5: import "io/ioutil" is banned: use io and os instead\n` +
+				`.*file\.go:6: import "log" is banned: use example.com/internal/log\n` +
+				`.*file\.go:7: import "net/http/pprof" is banned$`),
+		},
+	})
+}
@@ -0,0 +1,163 @@
+// Package inlineerr provides lint integration for finding errors created inline
+// in exported functions.
+package inlineerr
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports calls to errors.New and
+// fmt.Errorf, in exported functions and methods in .go files other than test
+// files, whose result is returned to the caller. Callers cannot match such
+// errors with errors.Is, so an error that is part of the API of a package
+// should be a package-level sentinel instead.
+//
+// A call is returned if it is a result of a return statement, or is assigned to
+// a variable that is. Calls to fmt.Errorf wrapping another error with %w are
+// not reported, since they add context to an error the caller can still match.
+// Calls are resolved using go/types, so functions of other packages named New
+// or Errorf are not reported. Returns in function literals belong to the literal
+// and are not checked. Reports can be suppressed with a comment on the line of
+// the call or the line before it of the form
+//
+//	//nolint:inlineerr
+type Checker struct{}
+
+// Check reports each error created inline in an exported function in pkgs as
+//
+//	file.go:line:col: error created inline in exported function; consider a package-level sentinel
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each error created inline in an
+// exported function in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports errors created inline in exported functions in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "inlineerr.Checker")
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil || !fn.Name.IsExported() {
+					continue
+				}
+				assigned := assignments(info, fn.Body)
+				reported := map[*ast.CallExpr]bool{}
+				report := func(call *ast.CallExpr) {
+					line := s.Fset.Position(call.Pos()).Line
+					if reported[call] || suppressed[line] || suppressed[line-1] {
+						return
+					}
+					reported[call] = true
+					diags = append(diags, s.Diagnostic(call.Pos(),
+						"error created inline in exported function; consider a package-level sentinel"))
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.FuncLit:
+						return false
+					case *ast.ReturnStmt:
+						for _, r := range n.Results {
+							switch r := ast.Unparen(r).(type) {
+							case *ast.CallExpr:
+								if inline(info, r) {
+									report(r)
+								}
+							case *ast.Ident:
+								for _, call := range assigned[info.Uses[r]] {
+									report(call)
+								}
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// assignments returns the calls creating an error inline assigned to each
+// variable in body, outside function literals.
+func assignments(info *types.Info, body *ast.BlockStmt) map[types.Object][]*ast.CallExpr {
+	assigned := map[types.Object][]*ast.CallExpr{}
+	assign := func(lhs []ast.Expr, rhs []ast.Expr) {
+		if len(rhs) != len(lhs) {
+			return
+		}
+		for i, l := range lhs {
+			id, ok := l.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			call, ok := ast.Unparen(rhs[i]).(*ast.CallExpr)
+			if obj := info.ObjectOf(id); obj != nil && ok && inline(info, call) {
+				assigned[obj] = append(assigned[obj], call)
+			}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			assign(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			assign(lhs, n.Values)
+		}
+		return true
+	})
+	return assigned
+}
+
+// inline reports whether call is a call to errors.New, or to fmt.Errorf with a
+// format that does not wrap an error using %w.
+func inline(info *types.Info, call *ast.CallExpr) bool {
+	switch funcName(info, call) {
+	case "errors.New":
+		return true
+	case "fmt.Errorf":
+		if len(call.Args) == 0 {
+			return false
+		}
+		format := info.Types[call.Args[0]].Value
+		return format == nil || format.Kind() != constant.String || !strings.Contains(constant.StringVal(format), "%w")
+	}
+	return false
+}
+
+// funcName returns the package path and name of the package-level function
+// called by call, as in "fmt.Errorf", or "" if it is not one.
+func funcName(info *types.Info, call *ast.CallExpr) string {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
@@ -0,0 +1,76 @@
+package inlineerr_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/inlineerr"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package inlineerrtest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrMissing = errors.New("missing")
+
+type Store struct{}
+
+func (Store) Get(key string) (string, error) {
+	if key == "" {
+		return "", errors.New("empty key")
+	}
+	err := fmt.Errorf("key %q not found", key)
+	return "", err
+}
+
+func Open(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	if path == "-" {
+		return ErrMissing
+	}
+	//nolint:inlineerr
+	return errors.New("not implemented")
+}
+
+func Logged() error {
+	err := errors.New("logged")
+	fmt.Println(err)
+	f := func() error { return errors.New("literal") }
+	return f()
+}
+
+func open() error {
+	return errors.New("unexported")
+}
+
+type errorsT struct{}
+
+func (errorsT) New(string) error { return nil }
+
+func Shadowed() error {
+	var errors errorsT
+	return errors.New("method")
+}
+`
+
+func TestInlineErr(t *testing.T) {
+	testutil.Test(t, "inlineerrtest", []testutil.StaticCheckTest{
+		{
+			Checker: inlineerr.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:14: error created inline in exported function; consider a package-level sentinel\n` +
+				`[^\n]*file\.go:17:9: error created inline in exported function; consider a package-level sentinel$`),
+		},
+		{
+			Checker:  inlineerr.Checker{},
+			Content:  []byte("package inlineerrtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,238 @@
+// Package jsoncase provides lint integration for checking the casing of JSON
+// struct tags.
+package jsoncase
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// styles holds the supported values of Checker.Style, in the order used to
+// break ties when the style of a package is inferred.
+var styles = []string{"snake", "camel", "kebab"}
+
+// styleNames holds the names of styles used in messages.
+var styleNames = map[string]string{
+	"snake": "snake_case",
+	"camel": "camelCase",
+	"kebab": "kebab-case",
+}
+
+// Checker implements lint.Checker and reports json struct tags, in .go files
+// including test files, whose name does not follow Style. Tags are read using
+// reflect.StructTag, options such as omitempty are ignored and fields tagged
+// "-" or with an empty name are skipped.
+//
+// A name is split into words at underscores, hyphens and changes of case, so
+// userID, user_id and UserId are all made of the words user and ID. It follows
+// a style if joining its words in that style gives back the name: lower case
+// words joined by underscores for snake, or by hyphens for kebab, and for camel
+// a lower case first word followed by capitalized words. Words in Acronyms are
+// written in upper case after the first word in camel style, so with Acronyms
+// holding ID the camel form of user_id is userID rather than userId.
+type Checker struct {
+	// Style is the style tags must follow, one of snake, camel or kebab. If it
+	// is empty, the style followed by the most tags in each package is used, so
+	// that only tags inconsistent with the rest of their package are reported.
+	Style string
+	// Allow holds tag names that are never reported, such as "_id".
+	Allow []string
+	// Acronyms holds words, such as ID or URL, written in upper case in camel
+	// style.
+	Acronyms []string
+}
+
+// Check reports each json tag in pkgs that does not follow the style as
+//
+//	file.go:line:col: json tag "userId" is not snake_case
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each json tag in pkgs that does not
+// follow the style.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files, nil)
+}
+
+// CheckFiles reports json tags in files that do not follow the style as
+// described in Check. Files in the same directory are treated as a package.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files, nil))
+}
+
+// CheckSource reports json tags in src, the contents of filename, that do not
+// follow the style as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics([]string{filename}, src))
+}
+
+// tag is the name of a json tag and its position.
+type tag struct {
+	pos  token.Position
+	name string
+}
+
+// fileDiagnostics checks files, grouped into packages by directory. If src is
+// not nil it holds the contents of the only file and is passed to
+// parser.ParseFile.
+func (c Checker) fileDiagnostics(files []string, src interface{}) ([]checkers.Diagnostic, error) {
+	if c.Style != "" && styleNames[c.Style] == "" {
+		return nil, fmt.Errorf("unknown Style %q, expected one of %s", c.Style, strings.Join(styles, ", "))
+	}
+	allowed := make(map[string]bool, len(c.Allow))
+	for _, name := range c.Allow {
+		allowed[name] = true
+	}
+	acronyms := make(map[string]bool, len(c.Acronyms))
+	for _, word := range c.Acronyms {
+		acronyms[strings.ToUpper(word)] = true
+	}
+	var dirs []string
+	pkgs := map[string][]tag{}
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		tags, err := parseTags(file, src)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		dir := filepath.Dir(file)
+		if _, ok := pkgs[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		for _, t := range tags {
+			if !allowed[t.name] {
+				pkgs[dir] = append(pkgs[dir], t)
+			}
+		}
+	}
+	for _, dir := range dirs {
+		tags := pkgs[dir]
+		style := c.Style
+		if style == "" {
+			style = inferStyle(tags, acronyms)
+		}
+		for _, t := range tags {
+			if convert(t.name, style, acronyms) != t.name {
+				diags = append(diags, checkers.Diagnostic{
+					File:    t.pos.Filename,
+					Line:    t.pos.Line,
+					Col:     t.pos.Column,
+					Message: fmt.Sprintf("json tag %q is not %s", t.name, styleNames[style]),
+				})
+			}
+		}
+	}
+	return diags, nil
+}
+
+// parseTags returns the json tag names in src, or the contents of file if src is
+// nil.
+func parseTags(file string, src interface{}) ([]tag, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	var tags []tag
+	ast.Inspect(f, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				// Reported by structtags.
+				continue
+			}
+			name, _, _ := strings.Cut(reflect.StructTag(value).Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			tags = append(tags, tag{pos: fset.Position(field.Tag.Pos()), name: name})
+		}
+		return true
+	})
+	return tags, nil
+}
+
+// inferStyle returns the style followed by the most tags, preferring earlier
+// entries of styles on a tie.
+func inferStyle(tags []tag, acronyms map[string]bool) string {
+	best, max := styles[0], -1
+	for _, style := range styles {
+		n := 0
+		for _, t := range tags {
+			if convert(t.name, style, acronyms) == t.name {
+				n++
+			}
+		}
+		if n > max {
+			best, max = style, n
+		}
+	}
+	return best
+}
+
+// convert returns name written in style.
+func convert(name, style string, acronyms map[string]bool) string {
+	parts := words(name)
+	for i, w := range parts {
+		switch {
+		case style != "camel", i == 0:
+			parts[i] = strings.ToLower(w)
+		case acronyms[strings.ToUpper(w)]:
+			parts[i] = strings.ToUpper(w)
+		default:
+			parts[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+		}
+	}
+	switch style {
+	case "snake":
+		return strings.Join(parts, "_")
+	case "kebab":
+		return strings.Join(parts, "-")
+	}
+	return strings.Join(parts, "")
+}
+
+// words splits name into words at underscores, hyphens and changes of case. A
+// run of upper case letters is a single word, except for its last letter if it
+// is followed by a lower case letter, so HTTPServer is split into HTTP and
+// Server. Digits belong to the word before them.
+func words(name string) []string {
+	var parts []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, r := runes[i-1], runes[i]
+			lowerToUpper := !unicode.IsUpper(prev) && unicode.IsUpper(r)
+			acronymEnd := unicode.IsUpper(prev) && unicode.IsUpper(r) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if lowerToUpper || acronymEnd {
+				parts = append(parts, string(runes[start:i]))
+				start = i
+			}
+		}
+		parts = append(parts, string(runes[start:]))
+	}
+	return parts
+}
@@ -0,0 +1,76 @@
+package jsoncase_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/jsoncase"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = "package jsoncasetest\n" +
+	"\n" +
+	"type User struct {\n" +
+	"\tID        int    `json:\"id\"`\n" +
+	"\tUserID    int    `json:\"user_id,omitempty\"`\n" +
+	"\tGroupID   int    `json:\"groupId\"`\n" +
+	"\tAvatarURL string `json:\"avatarURL\"`\n" +
+	"\tSkipped   string `json:\"-\"`\n" +
+	"\tUnnamed   string `json:\",omitempty\"`\n" +
+	"\tMongoID   string `json:\"_id\"`\n" +
+	"\tHome      string `json:\"home-page\" xml:\"homePage\"`\n" +
+	"\tUntagged  string\n" +
+	"}\n"
+
+func TestJSONCase(t *testing.T) {
+	testutil.Test(t, "jsoncasetest", []testutil.StaticCheckTest{
+		{
+			Checker:  jsoncase.Checker{},
+			Content:  []byte("package jsoncasetest\n\ntype T struct {\n\tA int\n}\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: jsoncase.Checker{Style: "snake"},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:6:19: json tag "groupId" is not snake_case\n` +
+				`[^\n]*file\.go:7:19: json tag "avatarURL" is not snake_case\n` +
+				`[^\n]*file\.go:10:19: json tag "_id" is not snake_case\n` +
+				`[^\n]*file\.go:11:19: json tag "home-page" is not snake_case$`),
+		},
+		{
+			Checker: jsoncase.Checker{Style: "snake", Allow: []string{"_id"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:6:19: json tag "groupId" is not snake_case\n` +
+				`[^\n]*file\.go:7:19: json tag "avatarURL" is not snake_case\n` +
+				`[^\n]*file\.go:11:19: json tag "home-page" is not snake_case$`),
+		},
+		{
+			Checker: jsoncase.Checker{Style: "camel", Allow: []string{"_id"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:5:19: json tag "user_id" is not camelCase\n` +
+				`[^\n]*file\.go:7:19: json tag "avatarURL" is not camelCase\n` +
+				`[^\n]*file\.go:11:19: json tag "home-page" is not camelCase$`),
+		},
+		{
+			Checker: jsoncase.Checker{Style: "camel", Allow: []string{"_id"}, Acronyms: []string{"ID", "URL"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:5:19: json tag "user_id" is not camelCase\n` +
+				`[^\n]*file\.go:6:19: json tag "groupId" is not camelCase\n` +
+				`[^\n]*file\.go:11:19: json tag "home-page" is not camelCase$`),
+		},
+		{
+			Checker:  jsoncase.Checker{Allow: []string{"_id"}},
+			Content:  []byte("package jsoncasetest\n\ntype T struct {\n\tA int `json:\"first_name\"`\n\tB int `json:\"lastName\"`\n\tC int `json:\"zip_code\"`\n}\n"),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:5:8: json tag "lastName" is not snake_case$`),
+		},
+		{
+			Checker:  jsoncase.Checker{Style: "pascal"},
+			Content:  []byte(src),
+			Validate: testutil.Contains(`unknown Style "pascal", expected one of snake, camel, kebab`),
+		},
+		{
+			Checker:  jsoncase.Checker{Style: "snake"},
+			Content:  []byte("package jsoncasetest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,108 @@
+// Package junit writes lint errors as JUnit XML, which is understood by most CI
+// servers for reporting test results.
+package junit
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/surullabs/lint"
+)
+
+// defaultSuite is used as the suite name for errors without a checker prefix.
+const defaultSuite = "lint"
+
+// Write converts err, as returned by lint.Group.Check, into a JUnit document and
+// writes it to w.
+//
+// Errors are parsed using lint.Diagnostics. There is one testsuite per distinct
+// checker prefix and one failing testcase per file, named after the file, in the
+// order in which each is first seen. The failure holds the errors for the file,
+// one per line. Errors that do not refer to a file are reported in a testcase
+// named after the checker. A nil err results in a document with a single empty
+// testsuite.
+func Write(w io.Writer, err error) error {
+	doc := document{}
+	suites := map[string]int{}
+	cases := map[[2]string]int{}
+	var messages [][][]string
+	for _, d := range lint.Diagnostics(err) {
+		name := d.Checker
+		if name == "" {
+			name = defaultSuite
+		}
+		i, ok := suites[name]
+		if !ok {
+			i = len(doc.Suites)
+			suites[name] = i
+			doc.Suites = append(doc.Suites, suite{Name: name})
+			messages = append(messages, nil)
+		}
+		caseName := d.File
+		if caseName == "" {
+			caseName = name
+		}
+		j, ok := cases[[2]string{name, caseName}]
+		if !ok {
+			j = len(doc.Suites[i].Cases)
+			cases[[2]string{name, caseName}] = j
+			doc.Suites[i].Cases = append(doc.Suites[i].Cases, testCase{
+				Name:      caseName,
+				Classname: name,
+				Failure:   &failure{Message: d.Message, Type: d.Severity.String()},
+			})
+			messages[i] = append(messages[i], nil)
+		}
+		d.Checker = ""
+		messages[i][j] = append(messages[i][j], d.String())
+	}
+	for i := range doc.Suites {
+		s := &doc.Suites[i]
+		for j := range s.Cases {
+			s.Cases[j].Failure.Text = strings.Join(messages[i][j], "\n")
+		}
+		s.Tests, s.Failures = len(s.Cases), len(s.Cases)
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+	}
+	if len(doc.Suites) == 0 {
+		doc.Suites = append(doc.Suites, suite{Name: defaultSuite})
+	}
+	if _, werr := io.WriteString(w, xml.Header); werr != nil {
+		return werr
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if werr := enc.Encode(doc); werr != nil {
+		return werr
+	}
+	_, werr := io.WriteString(w, "\n")
+	return werr
+}
+
+type document struct {
+	XMLName  xml.Name `xml:"testsuites"`
+	Tests    int      `xml:"tests,attr"`
+	Failures int      `xml:"failures,attr"`
+	Suites   []suite  `xml:"testsuite"`
+}
+
+type suite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Cases    []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Failure   *failure `xml:"failure,omitempty"`
+}
+
+type failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
@@ -0,0 +1,58 @@
+package junit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/junit"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0">
+  <testsuite name="lint" tests="0" failures="0"></testsuite>
+</testsuites>
+`,
+		},
+		{
+			err: checkers.Error(
+				"govet.Check: a.go:23: err is <shadowed>",
+				"golint.Check: b.go:2:1: exported Foo should have comment",
+				"govet.Check: a.go:30:4: unreachable code & more",
+				"govet.Check: vet failed",
+			),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="3">
+  <testsuite name="govet.Check" tests="2" failures="2">
+    <testcase name="a.go" classname="govet.Check">
+      <failure message="err is &lt;shadowed&gt;" type="error">a.go:23: err is &lt;shadowed&gt;&#xA;a.go:30:4: unreachable code &amp; more</failure>
+    </testcase>
+    <testcase name="govet.Check" classname="govet.Check">
+      <failure message="vet failed" type="error">vet failed</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="golint.Check" tests="1" failures="1">
+    <testcase name="b.go" classname="golint.Check">
+      <failure message="exported Foo should have comment" type="error">b.go:2:1: exported Foo should have comment</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		if err := junit.Write(&buf, test.err); err != nil {
+			t.Fatal(i, err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%d: expected\n%s\ngot\n%s", i, test.expected, buf.String())
+		}
+	}
+}
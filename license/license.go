@@ -0,0 +1,147 @@
+// Package license provides lint integration for checking license headers.
+package license
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultYearPlaceholder is the placeholder used by Checker if YearPlaceholder
+// is empty.
+const DefaultYearPlaceholder = "{{YEAR}}"
+
+// Checker implements lint.Checker and reports .go files, including test files,
+// that do not start with a license header. The header must follow any build
+// constraints and blank lines at the top of the file and is compared line by
+// line, ignoring trailing white space. Wherever the header holds YearPlaceholder
+// any year, or range of years such as 2015-2017, is accepted. Generated files are
+// not checked.
+//
+// The header is given including its comment markers, as in
+//
+//	license.Checker{Header: "// Copyright {{YEAR}} Acme Inc. All rights reserved."}
+type Checker struct {
+	// Header is the expected header. If neither Header nor HeaderFile is set
+	// nothing is reported.
+	Header string
+	// HeaderFile is the path of a file holding the expected header, for headers
+	// that are too long to keep in code. Only one of Header and HeaderFile may
+	// be set.
+	HeaderFile string
+	// YearPlaceholder is the text in the header that matches a year. If it is
+	// empty, DefaultYearPlaceholder is used.
+	YearPlaceholder string
+}
+
+// Check reports each file in pkgs without the license header as
+//
+//	file.go:1: missing or incorrect license header
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each file in pkgs without the
+// license header.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	re, err := c.regexp()
+	if err != nil || re == nil {
+		return nil, err
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return fileDiagnostics(re, files), nil
+}
+
+// CheckFiles reports files without the license header as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	re, err := c.regexp()
+	if err != nil || re == nil {
+		return err
+	}
+	return checkers.DiagnosticsError(fileDiagnostics(re, files), nil)
+}
+
+// CheckSource reports whether src, the contents of filename, is missing the
+// license header as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	re, err := c.regexp()
+	if err != nil || re == nil {
+		return err
+	}
+	return checkers.DiagnosticsError(sourceDiagnostics(re, filename, src), nil)
+}
+
+// regexp returns a regular expression matching the start of a file with the
+// header, or nil if no header is set.
+func (c Checker) regexp() (*regexp.Regexp, error) {
+	header := c.Header
+	switch {
+	case c.Header != "" && c.HeaderFile != "":
+		return nil, errors.New("only one of Header and HeaderFile may be set")
+	case c.HeaderFile != "":
+		data, err := ioutil.ReadFile(c.HeaderFile)
+		if err != nil {
+			return nil, err
+		}
+		header = string(data)
+	case c.Header == "":
+		return nil, nil
+	}
+	placeholder := c.YearPlaceholder
+	if placeholder == "" {
+		placeholder = DefaultYearPlaceholder
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(normalize(header), "\n"), "\n") {
+		parts := strings.Split(line, placeholder)
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		lines = append(lines, strings.Join(parts, `\d{4}(?:-\d{4})?`))
+	}
+	// Build constraints and blank lines may precede the header.
+	return regexp.Compile(`^(?:(?://go:build|// \+build)[^\n]*\n|\n)*` + strings.Join(lines, `\n`) + `(?:\n|$)`)
+}
+
+func fileDiagnostics(re *regexp.Regexp, files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		diags = append(diags, sourceDiagnostics(re, file, src)...)
+	}
+	return diags
+}
+
+func sourceDiagnostics(re *regexp.Regexp, file string, src []byte) []checkers.Diagnostic {
+	f, err := parser.ParseFile(token.NewFileSet(), file, src, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	if ast.IsGenerated(f) || re.MatchString(normalize(string(src))) {
+		return nil
+	}
+	return []checkers.Diagnostic{{File: file, Line: 1, Message: "missing or incorrect license header"}}
+}
+
+// normalize converts line endings to \n and removes trailing white space from
+// each line.
+func normalize(s string) string {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,75 @@
+package license_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/license"
+	"github.com/surullabs/lint/testutil"
+)
+
+const header = `// Copyright {{YEAR}} Acme Inc.
+// Use of this source code is governed by the LICENSE file.`
+
+func TestLicense(t *testing.T) {
+	dir, err := ioutil.TempDir("", "license")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	headerFile := filepath.Join(dir, "HEADER")
+	if err := ioutil.WriteFile(headerFile, []byte(header+"\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const missing = "file.go:1: missing or incorrect license header"
+	testutil.Test(t, "licensetest", []testutil.StaticCheckTest{
+		{
+			Checker:  license.Checker{},
+			Content:  []byte("package licensetest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  license.Checker{Header: header},
+			Content:  []byte("// Copyright 2017 Acme Inc.\n// Use of this source code is governed by the LICENSE file.  \n\npackage licensetest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  license.Checker{HeaderFile: headerFile},
+			Content:  []byte("//go:build linux\n\n// Copyright 2015-2017 Acme Inc.\n// Use of this source code is governed by the LICENSE file.\n\npackage licensetest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  license.Checker{Header: header},
+			Content:  []byte("// Copyright 2017 Acme Inc.\n\npackage licensetest\n"),
+			Validate: testutil.HasSuffix(missing),
+		},
+		{
+			Checker:  license.Checker{Header: header},
+			Content:  []byte("// Copyright 2017 Acme Inc.\n// Use of this source code is governed by the LICENSE file. Or not.\npackage licensetest\n"),
+			Validate: testutil.HasSuffix(missing),
+		},
+		{
+			Checker:  license.Checker{Header: header},
+			Content:  []byte("package licensetest\n\n// Copyright 2017 Acme Inc.\n// Use of this source code is governed by the LICENSE file.\n"),
+			Validate: testutil.HasSuffix(missing),
+		},
+		{
+			Checker:  license.Checker{Header: "// Copyright YYYY Acme", YearPlaceholder: "YYYY"},
+			Content:  []byte("// Copyright 2017 Acme\npackage licensetest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  license.Checker{Header: header},
+			Content:  []byte("// Code generated by hand. DO NOT EDIT.\n\npackage licensetest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  license.Checker{Header: header, HeaderFile: headerFile},
+			Content:  []byte("package licensetest\n"),
+			Validate: testutil.Contains("only one of Header and HeaderFile may be set"),
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Limit returns a Checker that runs c and keeps only the first n errors. If any
+// errors are dropped, a final entry
+//
+//	... and 4213 more
+//
+// is added, so a truncated result has exactly n+1 entries. Errors that do not
+// implement the errors interface described in Skip are returned unmodified. If
+// n <= 0 all errors are kept.
+func Limit(n int, c Checker) Checker {
+	return limit{checker: c, n: n}
+}
+
+type limit struct {
+	checker Checker
+	n       int
+}
+
+func (l limit) unwrap() interface{} { return l.checker }
+
+// Name returns the name of the wrapped checker.
+func (l limit) Name() string { return checkerName(l.checker) }
+
+// Check runs the wrapped checker and truncates its errors.
+func (l limit) Check(pkgs ...string) error {
+	err := l.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok || l.n <= 0 {
+		return err
+	}
+	errs := list.Errors()
+	if len(errs) <= l.n {
+		return err
+	}
+	truncated := append(errs[:l.n:l.n], fmt.Sprintf("... and %d more", len(errs)-l.n))
+	return checkers.Error(truncated...)
+}
@@ -0,0 +1,37 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestLimit(t *testing.T) {
+	five := checkFn(func(pkgs ...string) error { return checkers.Error("1", "2", "3", "4", "5") })
+
+	for _, test := range []struct {
+		n        int
+		expected string
+	}{
+		{0, "1\n2\n3\n4\n5"},
+		{-1, "1\n2\n3\n4\n5"},
+		{5, "1\n2\n3\n4\n5"},
+		{10, "1\n2\n3\n4\n5"},
+		{2, "1\n2\n... and 3 more"},
+	} {
+		err := lint.Limit(test.n, five).Check()
+		assert(t, err != nil && err.Error() == test.expected, fmt.Sprintf("%d: %v", test.n, err))
+	}
+
+	err := lint.Limit(1, five).Check()
+	list, ok := err.(interface{ Errors() []string })
+	assert(t, ok && len(list.Errors()) == 2, fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.Limit(1, five)}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: 1\nlint_test.checkFn: ... and 4 more", fmt.Sprintf("%v", err))
+
+	err = lint.Limit(1, checkFn(func(pkgs ...string) error { return nil })).Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+}
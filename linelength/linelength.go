@@ -0,0 +1,108 @@
+// Package linelength provides lint integration for checking the length of
+// source lines.
+package linelength
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports lines in .go files, including test
+// files, that are longer than Max columns. Columns are counted in runes, with each
+// tab counting as TabWidth columns.
+type Checker struct {
+	// Max is the longest allowed line. If it is 0 nothing is reported.
+	Max int
+	// TabWidth is the number of columns a tab counts as. If it is 0 a tab counts
+	// as a single column.
+	TabWidth int
+}
+
+// Check reports each line in pkgs that is too long as
+//
+//	file.go:line: line too long (135 > 120)
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each line in pkgs that is too long.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.Max <= 0 {
+		return nil, nil
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports lines in files that are too long as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		found, err := c.checkFile(file)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		diags = append(diags, found...)
+	}
+	return diags
+}
+
+// CheckSource reports lines in src, the contents of filename, that are too long
+// as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkLines(filename, bytes.NewReader(src)), nil)
+}
+
+func (c Checker) checkFile(file string) ([]checkers.Diagnostic, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return c.checkLines(file, f), nil
+}
+
+func (c Checker) checkLines(file string, src io.Reader) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	r := bufio.NewReader(src)
+	for line := 1; ; line++ {
+		text, err := r.ReadString('\n')
+		if n := c.width(strings.TrimRight(text, "\r\n")); n > c.Max {
+			diags = append(diags, checkers.Diagnostic{File: file, Line: line, Message: fmt.Sprintf("line too long (%d > %d)", n, c.Max)})
+		}
+		if err != nil {
+			break
+		}
+	}
+	return diags
+}
+
+func (c Checker) width(line string) int {
+	tabs := strings.Count(line, "\t")
+	n := utf8.RuneCountInString(line) - tabs
+	if c.TabWidth > 0 {
+		return n + tabs*c.TabWidth
+	}
+	return n + tabs
+}
@@ -0,0 +1,44 @@
+package linelength_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/linelength"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = "package linelengthtest\n\n" +
+	"// ééééééééé\n" +
+	"func f() {\n" +
+	"\tvar abc int\n" +
+	"\t_ = abc\n" +
+	"}\n" +
+	"// no trailing newline"
+
+func TestLineLength(t *testing.T) {
+	testutil.Test(t, "linelengthtest", []testutil.StaticCheckTest{
+		{
+			Checker:  linelength.Checker{Max: 22},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  linelength.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: linelength.Checker{Max: 12},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:1: line too long \(22 > 12\)\n` +
+				`.*file\.go:8: line too long \(22 > 12\)$`),
+		},
+		{
+			Checker: linelength.Checker{Max: 12, TabWidth: 4},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:1: line too long \(22 > 12\)\n` +
+				`.*file\.go:5: line too long \(15 > 12\)\n` +
+				`.*file\.go:8: line too long \(22 > 12\)$`),
+		},
+	})
+}
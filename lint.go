@@ -54,38 +54,101 @@ type Group []Checker
 // Check applies each of checkers in g in the order provided.
 //
 // The error returned is either nil or contains errors returned by each Checker.
-// These are exposed using the errors interface described in Skip and prefixed with the type of the
-// Checker that generated the error. For example, the following error generated by govet.Checker:
+// These are exposed using the errors interface described in Skip and prefixed with the name of the
+// Checker that generated the error. The name is the result of Name for checkers that implement Namer
+// and the type of the Checker otherwise. For example, the following error generated by govet.Checker:
 //
-//    file.go:23: err is unintentionally shadowed.
+//    file.go:23:3: declaration of "err" shadows declaration at line 20
 //
 // is converted to:
 //
-//    govet.Checker: file.go:23: err is unintentionally shadowed.
+//    govet.Checker: file.go:23:3: declaration of "err" shadows declaration at line 20
 //
-// A checker is not shorted-circuited by a previous checker returning an error.
+// A checker is not shorted-circuited by a previous checker returning an error. Use FailFast
+// to stop at the first checker that fails.
 //
-// Any error that implements errors is flattened into the final error list.
+// Checkers named in the LINT_SKIP environment variable are not run, as described
+// in SkipEnv.
+//
+// Any error that implements errors is flattened into the final error list. Errors
+// from nested groups, such as a Group, ParallelGroup or GroupFiltered, are already
+// prefixed and are not prefixed again, so
+//
+//    Group{Group{govet.Checker{}}, golint.Check{}}
+//
+// reports the same errors as Group{govet.Checker{}, golint.Check{}}.
 func (g Group) Check(pkgs ...string) error {
 	var errs []string
-	for _, checker := range g {
-		name := reflect.TypeOf(checker).String()
-		switch err := checker.Check(pkgs...).(type) {
-		case nil:
-			continue
-		case errors:
-			cerrs := err.Errors()
-			for _, e := range cerrs {
-				errs = append(errs, name+": "+e)
-			}
-		default:
-			errs = append(errs, name+": "+err.Error())
-		}
+	for _, checker := range g.enabled() {
+		errs = append(errs, prefixErrors(prefixName(checker), checker.Check(pkgs...))...)
+	}
+	return checkers.Error(errs...)
+}
+
+// wrapper is implemented by checkers that wrap a single checker without changing
+// the prefixes of its errors.
+type wrapper interface {
+	unwrap() interface{}
+}
+
+// isGroup reports whether checker, or the checker it wraps, is a group. Errors
+// from groups are already prefixed and are not prefixed again when groups are
+// nested.
+func isGroup(checker interface{}) bool {
+	switch c := checker.(type) {
+	case Group, parallelGroup, filteredGroup, groupContext, failFast, anyOf, loaderGroup:
+		return true
+	case wrapper:
+		return isGroup(c.unwrap())
 	}
-	if len(errs) == 0 {
+	return false
+}
+
+// prefixName returns the name used to prefix errors from checker, or an empty
+// string if they are already prefixed.
+func prefixName(checker interface{}) string {
+	if isGroup(checker) {
+		return ""
+	}
+	return checkerName(checker)
+}
+
+// Namer is implemented by checkers that choose the name used to prefix their
+// errors in a Group. Checkers that wrap another checker, such as those returned by
+// Timeout or SkipChecker, use the name of the checker they wrap.
+type Namer interface {
+	Name() string
+}
+
+// checkerName returns the name used to prefix errors from checker. This is
+// the result of Name if checker implements Namer and its type otherwise.
+func checkerName(checker interface{}) string {
+	if n, ok := checker.(Namer); ok {
+		return n.Name()
+	}
+	return reflect.TypeOf(checker).String()
+}
+
+// prefixErrors flattens err into a list of errors prefixed with name as
+// described in Group.Check. If name is empty the errors are not prefixed.
+func prefixErrors(name string, err error) []string {
+	prefix := ""
+	if name != "" {
+		prefix = name + ": "
+	}
+	switch err := err.(type) {
+	case nil:
 		return nil
+	case errors:
+		cerrs := err.Errors()
+		errs := make([]string, len(cerrs))
+		for i, e := range cerrs {
+			errs[i] = prefix + e
+		}
+		return errs
+	default:
+		return []string{prefix + err.Error()}
 	}
-	return checkers.Error(errs...)
 }
 
 // With returns a copy of g with checkers appended
@@ -5,11 +5,14 @@ import (
 
 	"log"
 
+	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"runtime/debug"
 
 	"strings"
+	"time"
 
 	"github.com/surullabs/lint"
 	"github.com/surullabs/lint/checkers"
@@ -131,19 +134,10 @@ func TestRegexpMatch(t *testing.T) {
 		err != nil && err.Error() == "lint_test.checkFn: err2",
 		fmt.Sprintf("%v", err))
 
-	// panic on bad RE
-
-	func() {
-		defer func() {
-			r := recover()
-			if r != nil {
-				err = fmt.Errorf("%v", r)
-			}
-		}()
-		_ = scheck(twoErrors, lint.RegexpMatch(`(unmatched paren`))
-	}()
+	// Bad REs are reported instead of skipping
+	err = scheck(twoErrors, lint.RegexpMatch(`err1`, `(unmatched paren`))
 	assert(t,
-		err != nil && strings.HasPrefix(err.Error(), "error parsing regexp"),
+		err != nil && strings.HasPrefix(err.Error(), `invalid pattern "(unmatched paren": error parsing regexp`),
 		fmt.Sprintf("%v", err))
 }
 
@@ -192,3 +186,86 @@ func Example_packages() {
 	}
 	// Output:
 }
+
+var fileErrors = checkFn(func(...string) error {
+	return checkers.Error(
+		"dir/file.go:12:3: exported Foo should have comment",
+		"dir/file.pb.go:4: generated",
+		"not a file error",
+	)
+})
+
+func TestGlobMatch(t *testing.T) {
+	err := scheck(fileErrors, lint.GlobMatch(`*.pb.go`))
+	assert(t,
+		err != nil && err.Error() == "dir/file.go:12:3: exported Foo should have comment\nnot a file error",
+		fmt.Sprintf("%v", err))
+
+	err = scheck(fileErrors, lint.GlobMatch(`dir/file.go`, `not a *`))
+	assert(t, err != nil && err.Error() == "dir/file.pb.go:4: generated", fmt.Sprintf("%v", err))
+
+	// Prefixed errors from a Group
+	err = scheck(lint.Group{fileErrors}, lint.GlobMatch(`exported *`, `*.pb.go`))
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: not a file error", fmt.Sprintf("%v", err))
+
+	// Bad patterns are reported instead of skipping, even if there are no errors
+	err = lint.SkipChecker(checkFn(func(...string) error { return nil }), lint.GlobMatch(`*.pb.go`, `[`)).Check("./...")
+	assert(t,
+		err != nil && err.Error() == `invalid pattern "[": syntax error in pattern` && errors.Is(err, path.ErrBadPattern),
+		fmt.Sprintf("%v", err))
+}
+
+func TestSkipChecker(t *testing.T) {
+	err := lint.SkipChecker(fileErrors, lint.GlobMatch(`*.pb.go`)).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "dir/file.go:12:3: exported Foo should have comment\nnot a file error",
+		fmt.Sprintf("%v", err))
+
+	// Everything skipped returns nil
+	err = lint.SkipChecker(fileErrors, lint.GlobMatch(`*.go`, `not *`)).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	// The result can still be flattened by Group
+	err = lint.Group{lint.SkipChecker(twoErrors, errorIs("err1"))}.Check("./...")
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: err2", fmt.Sprintf("%v", err))
+}
+
+func TestNestedGroups(t *testing.T) {
+	inner := checkFn(func(pkgs ...string) error { return checkers.Error("file.go:1: inner") })
+	outer := checkFn(func(pkgs ...string) error { return checkers.Error("file.go:2: outer") })
+	expected := "lint_test.checkFn: file.go:1: inner\nlint_test.checkFn: file.go:2: outer"
+	for i, g := range []lint.Checker{
+		lint.Group{inner, outer},
+		lint.Group{lint.Group{inner}, outer},
+		lint.Group{lint.Dedup(lint.Group{inner}), lint.ParallelGroup(outer)},
+		lint.ParallelGroup(lint.Group{inner}, lint.GroupFiltered(lint.SeverityError, outer)),
+	} {
+		err := g.Check()
+		assert(t, err != nil && err.Error() == expected, fmt.Sprintf("%d: %v", i, err))
+	}
+}
+
+type namedCheck struct{}
+
+func (namedCheck) Check(pkgs ...string) error { return checkers.Error("file.go:23: shadowed") }
+
+func (namedCheck) Name() string { return "govet.Checker" }
+
+func TestNamer(t *testing.T) {
+	for i, c := range []lint.Checker{
+		namedCheck{},
+		&namedCheck{},
+		lint.Timeout(time.Minute, namedCheck{}),
+		lint.Dedup(lint.SkipChecker(lint.Sorted(namedCheck{}))),
+		lint.WithSeverity(lint.SeverityError, lint.Cache(t.TempDir(), namedCheck{})),
+	} {
+		err := lint.Group{c}.Check()
+		assert(t, err != nil && err.Error() == "govet.Checker: file.go:23: shadowed", fmt.Sprintf("%d: %v", i, err))
+	}
+	err := lint.Group{lint.Timeout(time.Minute, &unnamed{})}.Check()
+	assert(t, err != nil && err.Error() == "*lint_test.unnamed: failed", fmt.Sprintf("%v", err))
+}
+
+type unnamed struct{}
+
+func (*unnamed) Check(pkgs ...string) error { return fmt.Errorf("failed") }
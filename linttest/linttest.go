@@ -0,0 +1,34 @@
+// Package linttest makes it easy to run a lint.Checker as part of go test.
+package linttest
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint"
+)
+
+type errors interface {
+	Errors() []string
+}
+
+// Test runs c.Check(pkgs...) and reports any errors using t.
+//
+// If the error returned implements
+//
+//	type errors interface {
+//		Errors() []string
+//	}
+//
+// t.Error is called once for each error so that every failure is shown on its own
+// line. Other errors are reported with a single call to t.Error.
+func Test(t testing.TB, c lint.Checker, pkgs ...string) {
+	switch err := c.Check(pkgs...).(type) {
+	case nil:
+	case errors:
+		for _, e := range err.Errors() {
+			t.Error(e)
+		}
+	default:
+		t.Error(err)
+	}
+}
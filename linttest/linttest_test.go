@@ -0,0 +1,54 @@
+package linttest_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/linttest"
+)
+
+type checkFn func(pkgs ...string) error
+
+func (c checkFn) Check(pkgs ...string) error { return c(pkgs...) }
+
+// recorder records calls to Error instead of failing the test.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Error(args ...interface{}) { r.errs = append(r.errs, fmt.Sprint(args...)) }
+
+func TestTest(t *testing.T) {
+	tests := []struct {
+		c        checkFn
+		expected []string
+	}{
+		{c: func(...string) error { return nil }},
+		{
+			c:        func(...string) error { return checkers.Error("err1", "err2") },
+			expected: []string{"err1", "err2"},
+		},
+		{
+			c:        func(...string) error { return fmt.Errorf("ungrouped") },
+			expected: []string{"ungrouped"},
+		},
+		{
+			c: func(pkgs ...string) error {
+				if !reflect.DeepEqual(pkgs, []string{".", "./..."}) {
+					return fmt.Errorf("unexpected pkgs: %v", pkgs)
+				}
+				return nil
+			},
+		},
+	}
+	for i, test := range tests {
+		r := &recorder{TB: t}
+		linttest.Test(r, test.c, ".", "./...")
+		if !reflect.DeepEqual(r.errs, test.expected) {
+			t.Error("Test", i, "expected", test.expected, "got", r.errs)
+		}
+	}
+}
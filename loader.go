@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"github.com/surullabs/lint/checkers"
+)
+
+// Loader parses and type checks a set of packages once so that they can be
+// shared by several checkers. It is safe for concurrent use.
+//
+// Packages are loaded as checkers.Source values, using go/build, go/parser and
+// go/types as checkers.ParseSource does, rather than as go/packages Packages.
+// Every in-process checker works on a Source, so sharing them lets those
+// checkers use a Loader without a second representation of each package.
+type Loader = checkers.Loader
+
+// NewLoader returns a Loader for pkgs.
+func NewLoader(pkgs ...string) *Loader {
+	return checkers.NewLoader(pkgs...)
+}
+
+// AnalysisChecker is implemented by checkers that can use packages loaded by a
+// Loader instead of loading them again. Most checkers in this repository that
+// parse packages in process implement it.
+type AnalysisChecker interface {
+	CheckLoaded(l *Loader) error
+}
+
+// GroupWithLoader returns a Group that shares the packages of l between
+// checkers. Checkers implementing AnalysisChecker are run with CheckLoaded and
+// other checkers are run with Check, as they are in a Group, so
+//
+//	l := lint.NewLoader("./...")
+//	lint.GroupWithLoader(l, govet.Checker{}, golint.Native{}, gofmt.Native{})
+//
+// parses and type checks each package once for govet and golint. Errors are
+// prefixed as described in Group.Check.
+//
+// The returned checker is normally run with no packages, which runs it for the
+// packages of l. If it is run with other packages they are loaded afresh for
+// that run, since l holds the wrong packages.
+func GroupWithLoader(l *Loader, checkers ...Checker) Checker {
+	return loaderGroup{loader: l, group: Group(checkers)}
+}
+
+type loaderGroup struct {
+	loader *Loader
+	group  Group
+}
+
+// Check runs each checker in g for the packages of its loader.
+func (g loaderGroup) Check(pkgs ...string) error {
+	l := g.loader
+	if len(pkgs) == 0 {
+		pkgs = l.Packages()
+	} else if !samePackages(pkgs, l.Packages()) {
+		l = checkers.NewLoader(pkgs...)
+	}
+	var errs []string
+	for _, checker := range g.group.enabled() {
+		errs = append(errs, prefixErrors(prefixName(checker), checkLoaded(checker, l, pkgs))...)
+	}
+	return checkers.Error(errs...)
+}
+
+// checkLoaded runs checker with CheckLoaded if it implements AnalysisChecker and
+// with Check otherwise.
+func checkLoaded(checker Checker, l *Loader, pkgs []string) error {
+	checker, done := untimed(checker)
+	defer done()
+	if a, ok := checker.(AnalysisChecker); ok {
+		return a.CheckLoaded(l)
+	}
+	return checker.Check(pkgs...)
+}
+
+func (g loaderGroup) children() []Checker { return g.group }
+func (g loaderGroup) withChildren(checkers []Checker) Checker {
+	return loaderGroup{loader: g.loader, group: Group(checkers)}
+}
+
+func samePackages(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
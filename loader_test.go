@@ -0,0 +1,65 @@
+package lint_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+type loadedCheck struct {
+	mu      *sync.Mutex
+	loaders map[*lint.Loader][]*checkers.Source
+}
+
+func (c loadedCheck) Check(pkgs ...string) error { return fmt.Errorf("Check called") }
+
+func (c loadedCheck) CheckLoaded(l *lint.Loader) error {
+	srcs, err := l.Sources()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.loaders[l]; ok && &prev[0] != &srcs[0] {
+		return fmt.Errorf("packages loaded twice")
+	}
+	c.loaders[l] = srcs
+	return nil
+}
+
+func TestGroupWithLoader(t *testing.T) {
+	const pkg = "github.com/surullabs/lint/checkers"
+	c := loadedCheck{mu: &sync.Mutex{}, loaders: map[*lint.Loader][]*checkers.Source{}}
+	var plain []string
+	l := lint.NewLoader(pkg)
+	g := lint.GroupWithLoader(l, c, c, checkFn(func(pkgs ...string) error {
+		plain = append(plain, pkgs...)
+		return nil
+	}))
+
+	err := g.Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	assert(t, len(c.loaders) == 1 && len(c.loaders[l]) == 1 && c.loaders[l][0].ImportPath == pkg, fmt.Sprint(c.loaders))
+	assert(t, fmt.Sprint(plain) == "["+pkg+"]", fmt.Sprint(plain))
+
+	// Other packages are loaded afresh.
+	err = g.Check("github.com/surullabs/lint/testutil")
+	assert(t, err == nil && len(c.loaders) == 2, fmt.Sprintf("%v %d", err, len(c.loaders)))
+
+	// The loader can be shared by checkers running in parallel.
+	c.loaders = map[*lint.Loader][]*checkers.Source{}
+	l = lint.NewLoader(pkg)
+	var group []lint.Checker
+	for i := 0; i < 4; i++ {
+		group = append(group, lint.GroupWithLoader(l, c))
+	}
+	err = lint.ParallelGroup(group...).Check()
+	assert(t, err == nil && len(c.loaders) == 1, fmt.Sprintf("%v %d", err, len(c.loaders)))
+
+	// Errors are prefixed with the name of the checker.
+	err = lint.GroupWithLoader(l, checkFn(func(...string) error { return fmt.Errorf("bad") })).Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: bad", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,159 @@
+// Package loopcapture provides lint integration for finding loop variables
+// captured by goroutines and deferred functions.
+package loopcapture
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports loop variables used in function
+// literals started by go or defer statements in the loop, in .go files
+// including test files. Before Go 1.22 all iterations share one variable, so
+// such a function sees the value of a later iteration. Uses are resolved using
+// go/types, so a function using a copy made with i := i is not reported.
+type Checker struct {
+	// GoVersion is the Go version of the checked module, such as "1.21" or
+	// "go1.21". From Go 1.22 each iteration has its own variables and nothing
+	// is reported. If it is empty, loops are always checked.
+	GoVersion string
+	// SkipShadowed does not report loops whose body redeclares the variable,
+	// as in i := i, even if a function uses the variable before the copy is
+	// made.
+	SkipShadowed bool
+}
+
+// Check reports each captured loop variable in pkgs as one of
+//
+//	file.go:line:col: loop variable i captured by goroutine
+//	file.go:line:col: loop variable i captured by deferred function
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each captured loop variable in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports captured loop variables in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	if c.GoVersion != "" {
+		v := c.GoVersion
+		if !strings.HasPrefix(v, "go") {
+			v = "go" + v
+		}
+		if !version.IsValid(v) {
+			return nil, fmt.Errorf("invalid GoVersion %q", c.GoVersion)
+		}
+		if version.Compare(v, "go1.22") >= 0 {
+			return nil, nil
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					var vars []ast.Expr
+					var body *ast.BlockStmt
+					switch n := n.(type) {
+					case *ast.ForStmt:
+						if init, ok := n.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+							vars = init.Lhs
+						}
+						body = n.Body
+					case *ast.RangeStmt:
+						if n.Tok == token.DEFINE {
+							vars = []ast.Expr{n.Key, n.Value}
+						}
+						body = n.Body
+					default:
+						return true
+					}
+					loopVars := map[types.Object]bool{}
+					for _, v := range vars {
+						if id, ok := v.(*ast.Ident); ok && id.Name != "_" {
+							if obj := info.Defs[id]; obj != nil {
+								loopVars[obj] = true
+							}
+						}
+					}
+					if c.SkipShadowed {
+						for obj := range redeclared(info, body, loopVars) {
+							delete(loopVars, obj)
+						}
+					}
+					if len(loopVars) == 0 {
+						return true
+					}
+					ast.Inspect(body, func(n ast.Node) bool {
+						var call *ast.CallExpr
+						by := ""
+						switch n := n.(type) {
+						case *ast.GoStmt:
+							call, by = n.Call, "goroutine"
+						case *ast.DeferStmt:
+							call, by = n.Call, "deferred function"
+						default:
+							return true
+						}
+						lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit)
+						if !ok {
+							return true
+						}
+						reported := map[types.Object]bool{}
+						ast.Inspect(lit.Body, func(n ast.Node) bool {
+							id, ok := n.(*ast.Ident)
+							if !ok {
+								return true
+							}
+							if obj := info.Uses[id]; loopVars[obj] && !reported[obj] {
+								reported[obj] = true
+								diags = append(diags, s.Diagnostic(id.Pos(), "loop variable %s captured by %s", id.Name, by))
+							}
+							return true
+						})
+						return true
+					})
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// redeclared returns the variables of vars declared again with the same name in
+// body, outside function literals.
+func redeclared(info *types.Info, body *ast.BlockStmt, vars map[types.Object]bool) map[types.Object]bool {
+	names := map[string]types.Object{}
+	for obj := range vars {
+		names[obj.Name()] = obj
+	}
+	found := map[types.Object]bool{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.Ident:
+			if obj, ok := names[n.Name]; ok && info.Defs[n] != nil {
+				found[obj] = true
+			}
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,61 @@
+package loopcapture_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/loopcapture"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package loopcapturetest
+
+import "fmt"
+
+func Capture(xs []int) {
+	for i := 0; i < 3; i++ {
+		go func() {
+			fmt.Println(i, i)
+		}()
+	}
+	for _, x := range xs {
+		defer func() { fmt.Println(x) }()
+		go fmt.Println(x)
+		go func(x int) { fmt.Println(x) }(x)
+	}
+	for k, v := range xs {
+		k := k
+		go func() { fmt.Println(k) }()
+		go func() { fmt.Println(v) }()
+		v := v
+		_ = v
+	}
+}
+`
+
+func TestLoopCapture(t *testing.T) {
+	testutil.Test(t, "loopcapturetest", []testutil.StaticCheckTest{
+		{
+			Checker: loopcapture.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:16: loop variable i captured by goroutine\n` +
+				`[^\n]*file\.go:12:30: loop variable x captured by deferred function\n` +
+				`[^\n]*file\.go:19:27: loop variable v captured by goroutine$`),
+		},
+		{
+			Checker: loopcapture.Checker{GoVersion: "1.21", SkipShadowed: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:8:16: loop variable i captured by goroutine\n` +
+				`[^\n]*file\.go:12:30: loop variable x captured by deferred function$`),
+		},
+		{
+			Checker:  loopcapture.Checker{GoVersion: "go1.22"},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  loopcapture.Checker{GoVersion: "one"},
+			Content:  []byte(src),
+			Validate: testutil.Contains(`invalid GoVersion "one"`),
+		},
+	})
+}
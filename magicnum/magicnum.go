@@ -0,0 +1,163 @@
+// Package magicnum provides lint integration for finding numeric literals that
+// should be named constants.
+package magicnum
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports integer, floating-point and
+// imaginary literals in .go files, including test files, that are not 0, 1 or
+// -1 and are not in Allow. Literals in const declarations are the named constants
+// the check asks for and are not reported, nor are array lengths, indexes and
+// slice bounds, whose meaning is usually clear from the expression.
+type Checker struct {
+	// Allow holds the numbers that are not reported besides 0, 1 and -1, such
+	// as "2" or "100". Numbers are compared by value, so "16" also allows 0x10
+	// and 16.0. A negative number only allows the negated literal.
+	Allow []string
+	// SkipTests does not check _test.go files.
+	SkipTests bool
+}
+
+// Check reports each magic number in pkgs as
+//
+//	file.go:line:col: magic number 86400; define a named constant
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each magic number in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	allow, err := c.allowed()
+	if err != nil {
+		return nil, err
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(allow, files), nil
+}
+
+// CheckFiles reports magic numbers in files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	allow, err := c.allowed()
+	if err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(allow, files), nil)
+}
+
+// CheckSource reports magic numbers in src, the contents of filename, as
+// described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	allow, err := c.allowed()
+	if err != nil {
+		return err
+	}
+	return checkers.DiagnosticsError(c.sourceDiagnostics(allow, filename, src), nil)
+}
+
+// allowed returns the values of the numbers in Allow, along with 0, 1 and -1.
+func (c Checker) allowed() ([]constant.Value, error) {
+	allow := []constant.Value{constant.MakeInt64(0), constant.MakeInt64(1), constant.MakeInt64(-1)}
+	for _, number := range c.Allow {
+		v, ok := parseNumber(strings.TrimPrefix(number, "-"))
+		if !ok {
+			return nil, fmt.Errorf("invalid number in Allow: %q", number)
+		}
+		if strings.HasPrefix(number, "-") {
+			v = constant.UnaryOp(token.SUB, v, 0)
+		}
+		allow = append(allow, v)
+	}
+	return allow, nil
+}
+
+// parseNumber returns the value of the numeric literal lit.
+func parseNumber(lit string) (constant.Value, bool) {
+	for _, tok := range []token.Token{token.INT, token.FLOAT, token.IMAG} {
+		if v := constant.MakeFromLiteral(lit, tok, 0); v.Kind() != constant.Unknown {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (c Checker) fileDiagnostics(allow []constant.Value, files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		if c.SkipTests && strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		diags = append(diags, c.sourceDiagnostics(allow, file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of file if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) sourceDiagnostics(allow []constant.Value, file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	var diags []checkers.Diagnostic
+	report := func(lit *ast.BasicLit, negative bool) {
+		v, ok := parseNumber(lit.Value)
+		if !ok {
+			return
+		}
+		text := lit.Value
+		if negative {
+			v, text = constant.UnaryOp(token.SUB, v, 0), "-"+text
+		}
+		for _, a := range allow {
+			if constant.Compare(v, token.EQL, a) {
+				return
+			}
+		}
+		p := fset.Position(lit.Pos())
+		diags = append(diags, checkers.Diagnostic{File: file, Line: p.Line, Col: p.Column, Message: fmt.Sprintf("magic number %s; define a named constant", text)})
+	}
+	var visit func(n ast.Node) bool
+	inspect := func(n ast.Node) {
+		if n != nil {
+			ast.Inspect(n, visit)
+		}
+	}
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			return n.Tok != token.CONST
+		case *ast.ArrayType:
+			inspect(n.Elt)
+			return false
+		case *ast.IndexExpr:
+			inspect(n.X)
+			return false
+		case *ast.SliceExpr:
+			inspect(n.X)
+			return false
+		case *ast.UnaryExpr:
+			if lit, ok := n.X.(*ast.BasicLit); ok && n.Op == token.SUB {
+				report(lit, true)
+				return false
+			}
+		case *ast.BasicLit:
+			report(n, false)
+		}
+		return true
+	}
+	inspect(f)
+	return diags
+}
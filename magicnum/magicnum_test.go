@@ -0,0 +1,61 @@
+package magicnum_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/magicnum"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package magicnumtest
+
+import "time"
+
+const day = 86400
+
+const (
+	hour   = 3600
+	minute = 60 * time.Second
+)
+
+var buf [512]byte
+
+func Timeout(xs []int) time.Duration {
+	_ = xs[2]
+	_ = xs[1:3]
+	_ = buf[0] + 1
+	_ = -1
+	_ = 0x10
+	_ = 2.5
+	_ = -7
+	return 86400 * time.Second
+}
+`
+
+func TestMagicNum(t *testing.T) {
+	testutil.Test(t, "magicnumtest", []testutil.StaticCheckTest{
+		{
+			Checker: magicnum.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:19:6: magic number 0x10; define a named constant\n` +
+				`.*file\.go:20:6: magic number 2\.5; define a named constant\n` +
+				`.*file\.go:21:7: magic number -7; define a named constant\n` +
+				`.*file\.go:22:9: magic number 86400; define a named constant$`),
+		},
+		{
+			Checker:  magicnum.Checker{Allow: []string{"16", "2.5", "-7", "86400"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  magicnum.Checker{Allow: []string{"two"}},
+			Content:  []byte(src),
+			Validate: testutil.Contains(`invalid number in Allow: "two"`),
+		},
+		{
+			Checker:  magicnum.Checker{},
+			Content:  []byte("package magicnumtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
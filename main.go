@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MainOutput is where Main writes JSON output.
+var MainOutput io.Writer = os.Stdout
+
+// MainErrors is where Main writes errors, usage and its summary.
+var MainErrors io.Writer = os.Stderr
+
+// Main runs c as a command line tool and returns the exit status, so that a
+// linter can be built as
+//
+//	func main() {
+//		os.Exit(lint.Main(lint.Group{gofmt.Check{}, govet.Check{}}, os.Args[1:]))
+//	}
+//
+// args holds flags followed by the packages to check, which default to ./...
+// if there are none. Each error returned by c.Check is written to MainErrors on
+// its own line, followed by a summary. The flags are
+//
+//	-json	write the errors to MainOutput as described in MarshalJSON instead
+//	-q	do not write the summary
+//
+// Main returns 0 if there are no errors, 1 if every error refers to a file and
+// 2 for operational failures: bad flags, packages that cannot be resolved or
+// errors without a file, such as a missing tool.
+func Main(c Checker, args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(MainErrors)
+	jsonOut := flags.Bool("json", false, "write errors as JSON to stdout")
+	quiet := flags.Bool("q", false, "do not write the summary")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	pkgs := flags.Args()
+	if len(pkgs) == 0 {
+		pkgs = []string{"./..."}
+	}
+	if _, err := ExpandPackages(pkgs...); err != nil {
+		fmt.Fprintf(MainErrors, "lint: %v\n", err)
+		return 2
+	}
+	err := safeCheck(c, pkgs)
+	diags := Diagnostics(err)
+	if *jsonOut {
+		data, jerr := MarshalJSON(err)
+		if jerr != nil {
+			fmt.Fprintf(MainErrors, "lint: %v\n", jerr)
+			return 2
+		}
+		fmt.Fprintf(MainOutput, "%s\n", data)
+	} else {
+		for _, e := range prefixErrors("", err) {
+			fmt.Fprintln(MainErrors, e)
+		}
+	}
+	status := 0
+	for _, d := range diags {
+		if d.File == "" {
+			status = 2
+			break
+		}
+		status = 1
+	}
+	if !*quiet {
+		switch len(diags) {
+		case 0:
+			fmt.Fprintln(MainErrors, "lint: no problems found")
+		case 1:
+			fmt.Fprintln(MainErrors, "lint: 1 problem found")
+		default:
+			fmt.Fprintf(MainErrors, "lint: %d problems found\n", len(diags))
+		}
+	}
+	return status
+}
@@ -0,0 +1,59 @@
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestMainStatus(t *testing.T) {
+	defer func(out, errs interface{ Write([]byte) (int, error) }) {
+		lint.MainOutput, lint.MainErrors = out, errs
+	}(lint.MainOutput, lint.MainErrors)
+	var out, errs bytes.Buffer
+	lint.MainOutput, lint.MainErrors = &out, &errs
+
+	var checked []string
+	clean := checkFn(func(pkgs ...string) error { checked = pkgs; return nil })
+	findings := checkFn(func(pkgs ...string) error {
+		return checkers.Error("file.go:1: bad", "file.go:2:3: worse")
+	})
+	failing := lint.Group{findings, checkFn(func(pkgs ...string) error { return fmt.Errorf("tool not found") })}
+
+	tests := []struct {
+		checker lint.Checker
+		args    []string
+		status  int
+		out     string
+		errs    string
+	}{
+		{clean, nil, 0, "", "lint: no problems found\n"},
+		{clean, []string{"-q", "github.com/surullabs/lint/checkers"}, 0, "", ""},
+		{findings, []string{"."}, 1, "", "file.go:1: bad\nfile.go:2:3: worse\nlint: 2 problems found\n"},
+		{findings, []string{"-json", "-q", "."}, 1, `[{"checker":"","file":"file.go","line":1,"column":0,"message":"bad","severity":"error"},` +
+			`{"checker":"","file":"file.go","line":2,"column":3,"message":"worse","severity":"error"}]` + "\n", ""},
+		{failing, []string{"-q", "."}, 2, "", "lint_test.checkFn: file.go:1: bad\nlint_test.checkFn: file.go:2:3: worse\nlint_test.checkFn: tool not found\n"},
+		{clean, []string{"-json", "."}, 0, "[]\n", "lint: no problems found\n"},
+		{clean, []string{"-nosuchflag"}, 2, "", "flag provided but not defined: -nosuchflag"},
+		{clean, []string{"github.com/surullabs/nosuchpackage"}, 2, "", "lint: go list failed"},
+	}
+	for i, test := range tests {
+		out.Reset()
+		errs.Reset()
+		status := lint.Main(test.checker, test.args)
+		if i == 0 {
+			assert(t, strings.Join(checked, ",") == "./...", fmt.Sprintf("checked %v", checked))
+		}
+		assert(t, status == test.status, fmt.Sprintf("%d: status %d, expected %d: %s", i, status, test.status, errs.String()))
+		assert(t, out.String() == test.out, fmt.Sprintf("%d: output %q, expected %q", i, out.String(), test.out))
+		if strings.HasSuffix(test.errs, "\n") || test.errs == "" {
+			assert(t, errs.String() == test.errs, fmt.Sprintf("%d: errors %q, expected %q", i, errs.String(), test.errs))
+		} else {
+			assert(t, strings.Contains(errs.String(), test.errs), fmt.Sprintf("%d: errors %q, expected to contain %q", i, errs.String(), test.errs))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package mutablereturn provides lint integration for finding methods that
+// return internal slices and maps.
+package mutablereturn
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported methods, in .go files
+// other than test files, that return a slice or map field of their receiver as
+// is. Callers can then modify the internal state of the receiver through the
+// returned value.
+//
+// The check is a heuristic that only matches returning a field selected
+// directly on the receiver, as in return s.items. Fields are resolved using
+// go/types, including fields of embedded structs. Returns in function literals
+// belong to the literal and are not checked. Reports can be suppressed with a
+// comment on the line of the return statement or the line before it of the form
+//
+//	//nolint:mutablereturn
+type Checker struct {
+	// Allow holds path.Match patterns for the names of receiver types whose
+	// methods are not checked, such as types whose results are read-only by
+	// convention.
+	Allow []string
+}
+
+// Check reports each method in pkgs that returns an internal slice or map as
+//
+//	file.go:line:col: GetItems returns internal slice directly; consider returning a copy
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each method in pkgs that returns an
+// internal slice or map.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports methods that return internal slices or maps in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "mutablereturn.Checker")
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil || !fn.Name.IsExported() || c.allowed(fn) {
+					continue
+				}
+				recv := receiver(info, fn)
+				if recv == nil {
+					continue
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.FuncLit:
+						return false
+					case *ast.ReturnStmt:
+						line := s.Fset.Position(n.Pos()).Line
+						if suppressed[line] || suppressed[line-1] {
+							return true
+						}
+						for _, r := range n.Results {
+							if kind := internalField(info, recv, r); kind != "" {
+								diags = append(diags, s.Diagnostic(r.Pos(),
+									"%s returns internal %s directly; consider returning a copy", fn.Name.Name, kind))
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether the methods of the receiver type of fn are not
+// checked.
+func (c Checker) allowed(fn *ast.FuncDecl) bool {
+	name := recvName(fn)
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// receiver returns the receiver variable of fn, or nil if fn is not a method or
+// its receiver is unnamed.
+func receiver(info *types.Info, fn *ast.FuncDecl) types.Object {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return nil
+	}
+	return info.Defs[fn.Recv.List[0].Names[0]]
+}
+
+// internalField returns "slice" or "map" if e selects a field of that kind on
+// recv, or "" otherwise.
+func internalField(info *types.Info, recv types.Object, e ast.Expr) string {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok || info.Uses[id] != recv {
+		return ""
+	}
+	if selection := info.Selections[sel]; selection == nil || selection.Kind() != types.FieldVal {
+		return ""
+	}
+	switch info.TypeOf(sel).Underlying().(type) {
+	case *types.Slice:
+		return "slice"
+	case *types.Map:
+		return "map"
+	}
+	return ""
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
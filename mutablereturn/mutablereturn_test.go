@@ -0,0 +1,102 @@
+package mutablereturn_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/mutablereturn"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package mutablereturntest
+
+type base struct {
+	tags []string
+}
+
+type Store struct {
+	base
+	items []int
+	index map[string]int
+	name  string
+	ids   IDs
+}
+
+type IDs []int
+
+func (s *Store) GetItems() []int {
+	return s.items
+}
+
+func (s *Store) Index() (map[string]int, error) {
+	return s.index, nil
+}
+
+func (s *Store) Tags() []string {
+	return s.tags
+}
+
+func (s Store) IDs() IDs {
+	return s.ids
+}
+
+func (s *Store) Copy() []int {
+	return append([]int(nil), s.items...)
+}
+
+func (s *Store) Name() string {
+	return s.name
+}
+
+func (s *Store) items2() []int {
+	return s.items
+}
+
+func (s *Store) Lazy() func() []int {
+	return func() []int { return s.items }
+}
+
+func (s *Store) Ignored() []int {
+	//nolint:mutablereturn
+	return s.items
+}
+
+type Config struct {
+	hosts []string
+}
+
+func (c Config) Hosts() []string {
+	return c.hosts
+}
+`
+
+func TestMutableReturn(t *testing.T) {
+	testutil.Test(t, "mutablereturntest", []testutil.StaticCheckTest{
+		{
+			Checker: mutablereturn.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:18:9: GetItems returns internal slice directly; consider returning a copy\n` +
+				`[^\n]*file\.go:22:9: Index returns internal map directly; consider returning a copy\n` +
+				`[^\n]*file\.go:26:9: Tags returns internal slice directly; consider returning a copy\n` +
+				`[^\n]*file\.go:30:9: IDs returns internal slice directly; consider returning a copy\n` +
+				`[^\n]*file\.go:59:9: Hosts returns internal slice directly; consider returning a copy$`),
+		},
+		{
+			Checker: mutablereturn.Checker{Allow: []string{"Conf*"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:18:9: GetItems returns internal slice directly; consider returning a copy\n` +
+				`[^\n]*file\.go:22:9: Index returns internal map directly; consider returning a copy\n` +
+				`[^\n]*file\.go:26:9: Tags returns internal slice directly; consider returning a copy\n` +
+				`[^\n]*file\.go:30:9: IDs returns internal slice directly; consider returning a copy$`),
+		},
+		{
+			Checker:  mutablereturn.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+		{
+			Checker:  mutablereturn.Checker{},
+			Content:  []byte("package mutablereturntest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,140 @@
+// Package nakedret provides lint integration for checking for naked returns in
+// long functions.
+package nakedret
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports naked returns in functions whose
+// body is longer than MaxLines. The body is counted from the line of its opening
+// brace to the line of its closing brace. Only functions with named results can
+// have naked returns. Function literals are checked on their own, so a return in
+// a function literal belongs to the literal and not to the enclosing function.
+//
+// Test files are checked along with the package.
+type Checker struct {
+	// MaxLines is the longest body, in lines, in which naked returns are
+	// allowed. If it is 0 nothing is reported.
+	MaxLines int
+}
+
+// Check reports each naked return in a long function in pkgs as
+//
+//	file.go:line:col: naked return in function longer than 30 lines
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each naked return in a long function
+// in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.MaxLines <= 0 {
+		return nil, nil
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				diags = append(diags, c.checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each naked return in a long function in files as described
+// in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.MaxLines <= 0 {
+		return nil
+	}
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, c.checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each naked return in a long function in src, the contents
+// of filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.MaxLines <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return c.checkFile(fset, f)
+}
+
+func (c Checker) checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	ast.Inspect(f, func(n ast.Node) bool {
+		var typ *ast.FuncType
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			typ, body = fn.Type, fn.Body
+		case *ast.FuncLit:
+			typ, body = fn.Type, fn.Body
+		default:
+			return true
+		}
+		if body == nil || !namedResults(typ) {
+			return true
+		}
+		if lines := fset.Position(body.Rbrace).Line - fset.Position(body.Lbrace).Line + 1; lines <= c.MaxLines {
+			return true
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				if len(n.Results) == 0 {
+					diags = append(diags, c.diagnostic(fset, n.Pos()))
+				}
+			}
+			return true
+		})
+		return true
+	})
+	return diags
+}
+
+func (c Checker) diagnostic(fset *token.FileSet, pos token.Pos) checkers.Diagnostic {
+	p := fset.Position(pos)
+	return checkers.Diagnostic{
+		File:    p.Filename,
+		Line:    p.Line,
+		Col:     p.Column,
+		Message: fmt.Sprintf("naked return in function longer than %d lines", c.MaxLines),
+	}
+}
+
+// namedResults reports whether typ has named results.
+func namedResults(typ *ast.FuncType) bool {
+	if typ.Results == nil {
+		return false
+	}
+	for _, field := range typ.Results.List {
+		if len(field.Names) > 0 {
+			return true
+		}
+	}
+	return false
+}
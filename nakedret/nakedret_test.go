@@ -0,0 +1,65 @@
+package nakedret_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/nakedret"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package nakedrettest
+
+func Long(a int) (n int, err error) {
+	if a > 0 {
+		return
+	}
+	f := func() (m int) {
+		return
+	}
+	n = f()
+	return n, nil
+}
+
+func Short() (n int) {
+	return
+}
+
+func Unnamed(a int) (int, error) {
+	if a > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+`
+
+func TestNakedRet(t *testing.T) {
+	testutil.Test(t, "nakedrettest", []testutil.StaticCheckTest{
+		{
+			Checker:  nakedret.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  nakedret.Checker{MaxLines: 10},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  nakedret.Checker{MaxLines: 9},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5:3: naked return in function longer than 9 lines$`),
+		},
+		{
+			Checker: nakedret.Checker{MaxLines: 2},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5:3: naked return in function longer than 2 lines\n` +
+				`.*file\.go:8:3: naked return in function longer than 2 lines\n` +
+				`.*file\.go:15:2: naked return in function longer than 2 lines$`),
+		},
+		{
+			Checker:  nakedret.Checker{MaxLines: 1},
+			Content:  []byte("package nakedrettest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
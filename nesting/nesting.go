@@ -0,0 +1,155 @@
+// Package nesting provides lint integration for checking the nesting depth of
+// blocks in functions.
+package nesting
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports blocks nested more than Max
+// levels deep. The body of each if, for, switch and select statement and of
+// each function literal is a level, as is the body of a function declaration
+// unless ExcludeFuncBody is set. An else if chain and the cases of a switch or
+// select are at the level of the statement they belong to.
+//
+// Each run of blocks deeper than Max is reported once, at its deepest statement.
+// Test files are checked along with the package.
+type Checker struct {
+	// Max is the deepest nesting allowed. If it is 0 nothing is reported.
+	Max int
+	// ExcludeFuncBody does not count the body of a function declaration as a
+	// level, so a top-level if statement is at level 1.
+	ExcludeFuncBody bool
+}
+
+// Check reports each block nested too deeply in pkgs as
+//
+//	file.go:line:col: block nested 6 levels deep (> 4)
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each block nested too deeply in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.Max <= 0 {
+		return nil, nil
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				diags = append(diags, c.checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each block nested too deeply in files as described in
+// Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, c.checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each block nested too deeply in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return c.checkFile(fset, f)
+}
+
+// run is the deepest statement found in a run of blocks deeper than Max.
+type run struct {
+	depth int
+	pos   token.Pos
+}
+
+func (c Checker) checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	report := func(r *run) {
+		p := fset.Position(r.pos)
+		diags = append(diags, checkers.Diagnostic{
+			File:    p.Filename,
+			Line:    p.Line,
+			Col:     p.Column,
+			Message: fmt.Sprintf("block nested %d levels deep (> %d)", r.depth, c.Max),
+		})
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil && !c.ExcludeFuncBody {
+			c.visit(fn, 0, nil, report)
+		} else {
+			c.nest(decl, 0, nil, report)
+		}
+	}
+	return diags
+}
+
+// visit checks n, a statement or function whose body is a level, in a block at
+// depth. r is the run n is part of, if any.
+func (c Checker) visit(n ast.Node, depth int, r *run, report func(*run)) {
+	depth++
+	switch {
+	case r == nil && depth > c.Max:
+		r = &run{depth, n.Pos()}
+		defer report(r)
+	case r != nil && depth > r.depth:
+		r.depth, r.pos = depth, n.Pos()
+	}
+	s, ok := n.(*ast.IfStmt)
+	if !ok {
+		c.nest(n, depth, r, report)
+		return
+	}
+	for _, child := range []ast.Node{s.Init, s.Cond, s.Body} {
+		if child != nil {
+			c.nest(child, depth, r, report)
+		}
+	}
+	switch e := s.Else.(type) {
+	case *ast.IfStmt:
+		c.visit(e, depth-1, r, report)
+	case *ast.BlockStmt:
+		c.nest(e, depth, r, report)
+	}
+}
+
+// nest visits each statement or function literal in n whose body is a level.
+func (c Checker) nest(n ast.Node, depth int, r *run, report func(*run)) {
+	ast.Inspect(n, func(m ast.Node) bool {
+		switch m.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+			if m != n {
+				c.visit(m, depth, r, report)
+				return false
+			}
+		}
+		return true
+	})
+}
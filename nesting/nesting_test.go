@@ -0,0 +1,90 @@
+package nesting_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/nesting"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package nestingtest
+
+func Deep(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		if x > 0 {
+			switch {
+			case x > 10:
+				if x > 100 {
+					n++
+				}
+			}
+		} else if x < 0 {
+			n--
+		} else {
+			n += 2
+		}
+	}
+	return n
+}
+
+var f = func(ch chan int) {
+	select {
+	case v := <-ch:
+		if v > 0 {
+			for {
+				break
+			}
+		}
+	}
+}
+
+func Flat(a int) int {
+	if a > 0 {
+		return 1
+	}
+	return 0
+}
+`
+
+func TestNesting(t *testing.T) {
+	testutil.Test(t, "nestingtest", []testutil.StaticCheckTest{
+		{
+			Checker:  nesting.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  nesting.Checker{Max: 5},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  nesting.Checker{Max: 4},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:9:5: block nested 5 levels deep \(> 4\)$`),
+		},
+		{
+			Checker:  nesting.Checker{Max: 4, ExcludeFuncBody: true},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: nesting.Checker{Max: 3},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:9:5: block nested 5 levels deep \(> 3\)\n` +
+				`[^\n]*file\.go:26:4: block nested 4 levels deep \(> 3\)$`),
+		},
+		{
+			Checker: nesting.Checker{Max: 2},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:9:5: block nested 5 levels deep \(> 2\)\n` +
+				`[^\n]*file\.go:26:4: block nested 4 levels deep \(> 2\)$`),
+		},
+		{
+			Checker:  nesting.Checker{Max: 4},
+			Content:  []byte("package nestingtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
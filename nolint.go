@@ -0,0 +1,154 @@
+package lint
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"os"
+	"sort"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// NoLintChecker is a Checker that drops errors suppressed by nolint comments. It
+// is created using NoLint.
+type NoLintChecker struct {
+	// ReportUnused reports nolint comments in .go files, including test files, of
+	// the checked packages that did not suppress any error as
+	//
+	//	file.go:line: unused nolint directive
+	ReportUnused bool
+
+	checker Checker
+}
+
+// NoLint returns a Checker that runs c and drops errors for lines that have, or
+// follow a line that has, a comment of the form
+//
+//	//nolint
+//	//nolint:govet,golint
+//
+// The first form suppresses every error. The second only suppresses errors from
+// the listed checkers. A checker is listed if its name, or the package part of its
+// name, is in the list, ignoring case, so govet matches govet.Checker. If c is a
+// group the checker is taken from the prefix of each error. Otherwise it is the
+// name of c.
+//
+// Errors without a file and line are always kept. Errors that do not implement the
+// errors interface described in Skip are returned unmodified.
+func NoLint(c Checker) NoLintChecker {
+	return NoLintChecker{checker: c}
+}
+
+func (n NoLintChecker) unwrap() interface{} { return n.checker }
+
+// Name returns the name of the wrapped checker.
+func (n NoLintChecker) Name() string { return checkerName(n.checker) }
+
+// nolint is a nolint directive.
+type nolint struct {
+	line  int
+	names []string // nil for all checkers
+	used  bool
+}
+
+func (d *nolint) matches(checker string) bool { return checkers.NoLintMatches(d.names, checker) }
+
+// Check runs the wrapped checker and drops suppressed errors.
+func (n NoLintChecker) Check(pkgs ...string) error {
+	err := n.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if err != nil && !ok {
+		return err
+	}
+	directives := map[string][]*nolint{}
+	parse := func(file string) []*nolint {
+		ds, ok := directives[file]
+		if !ok {
+			ds = parseNoLint(file)
+			directives[file] = ds
+		}
+		return ds
+	}
+	var errs []string
+	if list != nil {
+		for _, e := range list.Errors() {
+			d := ParseDiagnostic(e)
+			if d.File == "" || d.Line == 0 {
+				errs = append(errs, e)
+				continue
+			}
+			checker := d.Checker
+			if !isGroup(n.checker) {
+				checker = checkerName(n.checker)
+			}
+			if !suppress(parse(d.File), d.Line, checker) {
+				errs = append(errs, e)
+			}
+		}
+	}
+	if n.ReportUnused {
+		unused, err := n.unused(pkgs, parse)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, unused...)
+	}
+	return checkers.Error(errs...)
+}
+
+func suppress(directives []*nolint, line int, checker string) bool {
+	suppressed := false
+	for _, d := range directives {
+		if (d.line == line || d.line == line-1) && d.matches(checker) {
+			d.used, suppressed = true, true
+		}
+	}
+	return suppressed
+}
+
+func (n NoLintChecker) unused(pkgs []string, parse func(string) []*nolint) ([]string, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var errs []string
+	for _, file := range files {
+		for _, d := range parse(file) {
+			if !d.used {
+				errs = append(errs, fmt.Sprintf("%s:%d: unused nolint directive", file, d.line))
+			}
+		}
+	}
+	return errs, nil
+}
+
+// parseNoLint returns the nolint directives in file. Files that cannot be read
+// have none.
+func parseNoLint(file string) []*nolint {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile(file, fset.Base(), len(src)), src, func(token.Position, string) {}, scanner.ScanComments)
+	var directives []*nolint
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.COMMENT {
+			continue
+		}
+		names, ok := checkers.ParseNoLint(lit)
+		if !ok {
+			continue
+		}
+		d := &nolint{line: fset.Position(pos).Line, names: names}
+		directives = append(directives, d)
+	}
+	return directives
+}
@@ -0,0 +1,82 @@
+package lint_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+)
+
+const nolintSrc = `package nolint
+
+func a() {} //nolint
+
+//nolint:govet // reason
+func b() {}
+
+func c() {} //nolint:golint,errcheck
+
+func d() {} //nolintable
+
+//nolint:dupl
+func e() {}
+`
+
+type vetCheck struct{ errs []string }
+
+func (v vetCheck) Check(pkgs ...string) error { return checkers.Error(v.errs...) }
+
+func (vetCheck) Name() string { return "govet.Checker" }
+
+type golintCheck struct{ errs []string }
+
+func (g golintCheck) Check(pkgs ...string) error { return checkers.Error(g.errs...) }
+
+func (golintCheck) Name() string { return "golint.Check" }
+
+func TestNoLint(t *testing.T) {
+	tmp, err := testutil.NewGopath("nolint", []testutil.SourceFile{
+		{Content: []byte(nolintSrc), Dest: filepath.Join("nolint", "file.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	file := filepath.Join(tmp.Path, "src", "nolint", "file.go")
+	at := func(lines ...int) []string {
+		var errs []string
+		for _, l := range lines {
+			errs = append(errs, fmt.Sprintf("%s:%d: problem", file, l))
+		}
+		return errs
+	}
+
+	g := lint.Group{vetCheck{at(3, 6, 8, 10)}, golintCheck{at(3, 6, 8, 10, 11)}}
+	err = lint.NoLint(g).Check("nolint")
+	expected := []string{
+		"govet.Checker: " + file + ":8: problem",
+		"govet.Checker: " + file + ":10: problem",
+		"golint.Check: " + file + ":6: problem",
+		"golint.Check: " + file + ":10: problem",
+		"golint.Check: " + file + ":11: problem",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	// A single checker uses its own name.
+	err = lint.NoLint(vetCheck{append(at(5, 6), "unparsed")}).Check("nolint")
+	assert(t, err != nil && err.Error() == "unparsed", fmt.Sprintf("%v", err))
+
+	n := lint.NoLint(vetCheck{at(3)})
+	n.ReportUnused = true
+	err = n.Check("nolint")
+	expected = []string{
+		file + ":5: unused nolint directive",
+		file + ":8: unused nolint directive",
+		file + ":12: unused nolint directive",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,179 @@
+// Package nopanic provides lint integration for restricting calls to panic.
+package nopanic
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultAllow holds the patterns used by Checker if Allow is empty.
+var DefaultAllow = []string{"init", "Must*"}
+
+// Checker implements lint.Checker and reports calls to the builtin panic in .go
+// files, including test files, outside the functions allowed by Allow. Calls are
+// resolved using go/types, so calls to a function of another package or a local
+// function named panic are not reported. A panic in a function literal is allowed
+// if the function declaring the literal is.
+type Checker struct {
+	// Allow holds path.Match patterns for the names of functions in which panic
+	// is allowed, such as "init" or "Must*". Methods match both their name and
+	// their name qualified by the receiver type, as in "T.Must*". If it is empty,
+	// DefaultAllow is used.
+	Allow []string
+	// SkipTests does not check _test.go files.
+	SkipTests bool
+	// AllowRepanic allows panics with a value returned by recover in the same
+	// function, which propagate a panic that could not be handled.
+	AllowRepanic bool
+}
+
+// Check reports each call to panic in pkgs that is not allowed as
+//
+//	file.go:line:col: panic is not allowed here
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each call to panic in pkgs that is
+// not allowed.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports calls to panic that are not allowed in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	allow := c.Allow
+	if len(allow) == 0 {
+		allow = DefaultAllow
+	}
+	for _, pattern := range allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(!c.SkipTests)
+		files := [][]*ast.File{s.Files}
+		if !c.SkipTests {
+			files = append(files, s.TestFiles, s.XTestFiles)
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range files {
+			for _, f := range group {
+				for _, decl := range f.Decls {
+					if fn, ok := decl.(*ast.FuncDecl); ok && allowed(allow, fn) {
+						continue
+					}
+					recovered := map[types.Object]bool{}
+					if c.AllowRepanic {
+						recovered = recoveredValues(info, decl)
+					}
+					ast.Inspect(decl, func(n ast.Node) bool {
+						call, ok := n.(*ast.CallExpr)
+						if !ok || !isBuiltin(info, call, "panic") {
+							return true
+						}
+						if c.AllowRepanic && len(call.Args) == 1 && repanics(info, recovered, call.Args[0]) {
+							return true
+						}
+						diags = append(diags, s.Diagnostic(call.Pos(), "panic is not allowed here"))
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether fn matches one of patterns.
+func allowed(patterns []string, fn *ast.FuncDecl) bool {
+	names := []string{fn.Name.Name}
+	if recv := recvName(fn); recv != "" {
+		names = append(names, recv+"."+fn.Name.Name)
+	}
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isBuiltin reports whether call calls the builtin function name.
+func isBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// recoveredValues returns the variables assigned the result of recover in decl.
+func recoveredValues(info *types.Info, decl ast.Decl) map[types.Object]bool {
+	values := map[types.Object]bool{}
+	ast.Inspect(decl, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+			if !ok || !isBuiltin(info, call, "recover") {
+				continue
+			}
+			if id, ok := assign.Lhs[i].(*ast.Ident); ok {
+				if obj := info.ObjectOf(id); obj != nil {
+					values[obj] = true
+				}
+			}
+		}
+		return true
+	})
+	return values
+}
+
+// repanics reports whether arg is a value returned by recover.
+func repanics(info *types.Info, recovered map[types.Object]bool, arg ast.Expr) bool {
+	switch arg := ast.Unparen(arg).(type) {
+	case *ast.Ident:
+		return recovered[info.Uses[arg]]
+	case *ast.CallExpr:
+		return isBuiltin(info, arg, "recover")
+	}
+	return false
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
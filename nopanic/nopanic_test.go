@@ -0,0 +1,89 @@
+package nopanic_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/nopanic"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package nopanictest
+
+type T struct{}
+
+func init() { panic("init") }
+
+func MustParse() { panic("must") }
+
+func (T) MustGet() { panic("must") }
+
+func (T) Get() { panic("get") }
+
+func Lib() {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(r)
+		}
+	}()
+	panic("lib")
+}
+
+func Shadowed() {
+	panic := func(v interface{}) {}
+	panic("shadowed")
+}
+
+var _ = func() int { panic("var") }
+`
+
+func TestNoPanic(t *testing.T) {
+	testutil.Test(t, "nopanictest", []testutil.StaticCheckTest{
+		{
+			Checker: nopanic.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:18: panic is not allowed here\n` +
+				`.*file\.go:16:4: panic is not allowed here\n` +
+				`.*file\.go:19:2: panic is not allowed here\n` +
+				`.*file\.go:27:22: panic is not allowed here$`),
+		},
+		{
+			Checker: nopanic.Checker{AllowRepanic: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:11:18: panic is not allowed here\n` +
+				`.*file\.go:19:2: panic is not allowed here\n` +
+				`.*file\.go:27:22: panic is not allowed here$`),
+		},
+		{
+			Checker: nopanic.Checker{Allow: []string{"T.*", "Lib"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5:15: panic is not allowed here\n` +
+				`.*file\.go:7:20: panic is not allowed here\n` +
+				`.*file\.go:27:22: panic is not allowed here$`),
+		},
+		{
+			Checker:  nopanic.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+	})
+}
+
+func TestNoPanicSkipTests(t *testing.T) {
+	checkers.Unload("nopanictest")
+	tmp, err := testutil.NewGopath("nopanictest", []testutil.SourceFile{
+		{Content: []byte("package nopanictest\n"), Dest: filepath.Join("nopanictest", "file.go")},
+		{Content: []byte("package nopanictest\n\nfunc helper() { panic(1) }\n"), Dest: filepath.Join("nopanictest", "file_test.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := testutil.HasSuffix("file_test.go:3:17: panic is not allowed here")(nopanic.Checker{}.Check("nopanictest")); err != nil {
+		t.Error(err)
+	}
+	if err := (nopanic.Checker{SkipTests: true}).Check("nopanictest"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,42 @@
+package lint
+
+import "fmt"
+
+// NotChecker is a Checker that passes only if the checker it wraps fails. Use
+// Not to create one.
+type NotChecker struct {
+	// Log, if set, is called with each message of the wrapped checker when it
+	// fails, such as to see which findings made a negative assertion pass.
+	Log func(msg string)
+
+	checker Checker
+}
+
+// Not returns a NotChecker that inverts the result of c. It passes if c reports
+// any error and fails with
+//
+//	expected findings but got none
+//
+// if c passes. This is useful in tests to assert that a checker, such as one
+// banning a pattern, fires on fixture files. Only the pass or fail signal is
+// inverted and the messages reported by c are discarded, unless Log is set.
+func Not(c Checker) NotChecker {
+	return NotChecker{checker: c}
+}
+
+// Name returns the name of the wrapped checker.
+func (n NotChecker) Name() string { return checkerName(n.checker) }
+
+// Check runs the wrapped checker and inverts its result.
+func (n NotChecker) Check(pkgs ...string) error {
+	errs := prefixErrors("", n.checker.Check(pkgs...))
+	if len(errs) == 0 {
+		return fmt.Errorf("expected findings but got none")
+	}
+	if n.Log != nil {
+		for _, msg := range errs {
+			n.Log(msg)
+		}
+	}
+	return nil
+}
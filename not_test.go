@@ -0,0 +1,27 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestNot(t *testing.T) {
+	err := lint.Not(checkFn(func(pkgs ...string) error { return checkers.Error("one", "two") })).Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Not(checkFn(func(pkgs ...string) error { return nil })).Check()
+	assert(t, err != nil && err.Error() == "expected findings but got none", fmt.Sprintf("%v", err))
+
+	// In a Group the error is prefixed with the name of the wrapped checker.
+	err = lint.Group{lint.Not(namedCheck{}), lint.Not(checkFn(func(pkgs ...string) error { return nil }))}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: expected findings but got none", fmt.Sprintf("%v", err))
+
+	var logged []string
+	not := lint.Not(checkFn(func(pkgs ...string) error { return checkers.Error("one", "two") }))
+	not.Log = func(msg string) { logged = append(logged, msg) }
+	err = not.Check()
+	assert(t, err == nil && fmt.Sprint(logged) == "[one two]", fmt.Sprintf("%v %v", err, logged))
+}
@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// OnlyEnv is the environment variable read by OnlyFromEnv.
+const OnlyEnv = "LINT_ONLY"
+
+// Only returns a Checker that runs only the checkers in g whose name is one of
+// names, such as to debug a single checker of a large Group. Names are matched
+// against the name used to prefix errors, as described in Group.Check, and the
+// type of the checker, such as golint.Check. Checkers in nested groups are
+// selected in the same way.
+//
+// g may be any group returned by this package, such as a Group, ParallelGroup or
+// FailFast, and the selected checkers are run in the same way. Any other
+// checker is treated as a group of one. If no checker matches, Check returns an
+// error listing the available names instead of passing.
+func Only(g Checker, names ...string) Checker {
+	selected, ok := only(g, names)
+	if !ok {
+		return checkFunc(func(pkgs ...string) error {
+			return fmt.Errorf("Only: no checkers named %s, available checkers are %s",
+				strings.Join(names, ", "), strings.Join(available(g, nil), ", "))
+		})
+	}
+	return selected
+}
+
+// OnlyFromEnv returns Only(g, names...) where names is the comma separated list
+// of names in the LINT_ONLY environment variable. If it is empty, g is returned.
+// This allows a single checker to be run from a test without editing it:
+//
+//	LINT_ONLY=govet.Check go test -run TestLint
+func OnlyFromEnv(g Checker) Checker {
+	var names []string
+	for _, name := range strings.Split(os.Getenv(OnlyEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return g
+	}
+	return Only(g, names...)
+}
+
+type checkFunc func(pkgs ...string) error
+
+func (f checkFunc) Check(pkgs ...string) error { return f(pkgs...) }
+
+// composite is implemented by groups so that Only can select their checkers.
+type composite interface {
+	children() []Checker
+	withChildren(checkers []Checker) Checker
+}
+
+func (g Group) children() []Checker                     { return g }
+func (g Group) withChildren(checkers []Checker) Checker { return Group(checkers) }
+
+func (g parallelGroup) children() []Checker { return g.checkers }
+func (g parallelGroup) withChildren(checkers []Checker) Checker {
+	return parallelGroup{checkers: checkers, limit: g.limit}
+}
+
+func (g filteredGroup) children() []Checker { return g.group }
+func (g filteredGroup) withChildren(checkers []Checker) Checker {
+	return filteredGroup{min: g.min, group: Group(checkers), out: g.out}
+}
+
+func (f failFast) children() []Checker                     { return f }
+func (f failFast) withChildren(checkers []Checker) Checker { return failFast(checkers) }
+
+func (a anyOf) children() []Checker                     { return a }
+func (a anyOf) withChildren(checkers []Checker) Checker { return anyOf(checkers) }
+
+// only returns the checkers in g matching names, or false if none match.
+func only(g Checker, names []string) (Checker, bool) {
+	c, ok := g.(composite)
+	if !ok {
+		return g, matchesName(g, names)
+	}
+	var selected []Checker
+	for _, child := range c.children() {
+		if matchesName(child, names) {
+			selected = append(selected, child)
+		} else if _, nested := child.(composite); nested {
+			if s, ok := only(child, names); ok {
+				selected = append(selected, s)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, false
+	}
+	return c.withChildren(selected), true
+}
+
+func matchesName(checker Checker, names []string) bool {
+	for _, name := range names {
+		if name == checkerName(checker) || name == reflect.TypeOf(checker).String() {
+			return true
+		}
+	}
+	return false
+}
+
+// available appends the names of the checkers that Only can select from g.
+func available(g Checker, names []string) []string {
+	c, ok := g.(composite)
+	if !ok {
+		return append(names, checkerName(g))
+	}
+	for _, child := range c.children() {
+		if _, nested := child.(composite); nested {
+			names = available(child, names)
+		} else {
+			names = append(names, checkerName(child))
+		}
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestOnly(t *testing.T) {
+	var ran []string
+	check := func(name string) lint.Checker {
+		return onlyCheck{name: name, fn: func(pkgs ...string) error {
+			ran = append(ran, name)
+			return checkers.Error("found")
+		}}
+	}
+	g := lint.Group{check("a"), lint.FailFast(check("b"), check("c")), check("d")}
+
+	err := lint.Only(g, "a", "c").Check()
+	assert(t, err != nil && err.Error() == "a: found\nc: found" && fmt.Sprint(ran) == "[a c]", fmt.Sprintf("%v %v", err, ran))
+
+	ran = nil
+	err = lint.Only(g, "lint_test.onlyCheck").Check()
+	assert(t, err != nil && fmt.Sprint(ran) == "[a b d]", fmt.Sprintf("%v %v", err, ran))
+
+	ran = nil
+	err = lint.Only(g, "x", "y").Check()
+	assert(t, err != nil && err.Error() == "Only: no checkers named x, y, available checkers are a, b, c, d", fmt.Sprintf("%v", err))
+	assert(t, len(ran) == 0, fmt.Sprint(ran))
+
+	err = lint.Only(check("a"), "a").Check()
+	assert(t, err != nil && err.Error() == "found", fmt.Sprintf("%v", err))
+
+	ran = nil
+	os.Setenv(lint.OnlyEnv, " d, b ")
+	defer os.Unsetenv(lint.OnlyEnv)
+	err = lint.OnlyFromEnv(g).Check()
+	assert(t, err != nil && err.Error() == "b: found\nd: found", fmt.Sprintf("%v", err))
+
+	ran = nil
+	os.Setenv(lint.OnlyEnv, "")
+	err = lint.OnlyFromEnv(g).Check()
+	assert(t, err != nil && fmt.Sprint(ran) == "[a b d]", fmt.Sprintf("%v %v", err, ran))
+}
+
+type onlyCheck struct {
+	name string
+	fn   checkFn
+}
+
+func (c onlyCheck) Check(pkgs ...string) error { return c.fn(pkgs...) }
+
+func (c onlyCheck) Name() string { return c.name }
@@ -0,0 +1,137 @@
+// Package osexit provides lint integration for restricting calls to os.Exit.
+package osexit
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports calls to os.Exit in .go files,
+// including test files, outside func main of package main, init functions and
+// the functions allowed by Allow. Exiting skips deferred calls and ends the
+// tests of a function, so only the entry points of a program should exit.
+//
+// Calls are resolved using go/types, so a function named Exit of another
+// package is not reported. A call in a function literal is allowed if the
+// function declaring the literal is.
+type Checker struct {
+	// Allow holds path.Match patterns for the names of other functions in which
+	// os.Exit is allowed, such as a "run" helper called by main. Methods match
+	// both their name and their name qualified by the receiver type, as in
+	// "T.Run".
+	Allow []string
+	// SkipTests does not check _test.go files, in which TestMain calls os.Exit
+	// with the result of m.Run.
+	SkipTests bool
+}
+
+// Check reports each call to os.Exit in pkgs that is not allowed as
+//
+//	file.go:line:col: os.Exit called outside main or init
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each call to os.Exit in pkgs that is
+// not allowed.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports calls to os.Exit that are not allowed in the packages of
+// l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(!c.SkipTests)
+		files := [][]*ast.File{s.Files}
+		if !c.SkipTests {
+			files = append(files, s.TestFiles, s.XTestFiles)
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range files {
+			for _, f := range group {
+				for _, decl := range f.Decls {
+					if fn, ok := decl.(*ast.FuncDecl); ok && c.allowed(f, fn) {
+						continue
+					}
+					ast.Inspect(decl, func(n ast.Node) bool {
+						if call, ok := n.(*ast.CallExpr); ok && isExit(info, call) {
+							diags = append(diags, s.Diagnostic(call.Pos(), "os.Exit called outside main or init"))
+						}
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether fn, declared in f, may call os.Exit.
+func (c Checker) allowed(f *ast.File, fn *ast.FuncDecl) bool {
+	if fn.Recv == nil {
+		switch {
+		case fn.Name.Name == "init":
+			return true
+		case fn.Name.Name == "main" && f.Name.Name == "main":
+			return true
+		}
+	}
+	names := []string{fn.Name.Name}
+	if recv := recvName(fn); recv != "" {
+		names = append(names, recv+"."+fn.Name.Name)
+	}
+	for _, pattern := range c.Allow {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExit reports whether call calls os.Exit.
+func isExit(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "os" && fn.Name() == "Exit"
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
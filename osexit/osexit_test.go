@@ -0,0 +1,82 @@
+package osexit_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/osexit"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package osexittest
+
+import (
+	"os"
+	sys "os"
+)
+
+type T struct{}
+
+func init() { os.Exit(0) }
+
+func main() { os.Exit(1) }
+
+func run() {
+	defer func() { sys.Exit(2) }()
+}
+
+func (T) Run() { os.Exit(3) }
+
+func Shadowed() {
+	os := struct{ Exit func(int) }{func(int) {}}
+	os.Exit(4)
+}
+`
+
+func TestOSExit(t *testing.T) {
+	testutil.Test(t, "osexittest", []testutil.StaticCheckTest{
+		{
+			Checker: osexit.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:12:15: os\.Exit called outside main or init\n` +
+				`[^\n]*file\.go:15:17: os\.Exit called outside main or init\n` +
+				`[^\n]*file\.go:18:18: os\.Exit called outside main or init$`),
+		},
+		{
+			Checker:  osexit.Checker{Allow: []string{"main", "run", "T.*"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  osexit.Checker{Allow: []string{"["}},
+			Content:  []byte(src),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+	})
+}
+
+func TestOSExitMain(t *testing.T) {
+	checkers.Unload("osexitmain")
+	tmp, err := testutil.NewGopath("osexitmain", []testutil.SourceFile{
+		{
+			Content: []byte("package main\n\nimport \"os\"\n\nfunc main() { os.Exit(run()) }\n\nfunc run() int { os.Exit(1); return 0 }\n"),
+			Dest:    filepath.Join("osexitmain", "main.go"),
+		},
+		{
+			Content: []byte("package main\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\nfunc TestMain(m *testing.M) { os.Exit(m.Run()) }\n"),
+			Dest:    filepath.Join("osexitmain", "main_test.go"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := testutil.MatchesRegexp(`^[^\n]*main\.go:7:18: os\.Exit called outside main or init\n` +
+		`[^\n]*main_test\.go:8:31: os\.Exit called outside main or init$`)(osexit.Checker{}.Check("osexitmain")); err != nil {
+		t.Error(err)
+	}
+	if err := (osexit.Checker{Allow: []string{"run"}, SkipTests: true}).Check("osexitmain"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// ParallelGroup returns a Checker that runs each of checkers concurrently, each
+// in its own goroutine.
+//
+// Errors are flattened and prefixed exactly as they are by Group.Check. The
+// order of the final error list is deterministic: errors are ordered by the
+// position of the checker in checkers and then by the order in which each checker
+// returned them.
+//
+// A checker that panics does not crash the caller. The panic is recovered and
+// reported as an error for that checker.
+func ParallelGroup(checkers ...Checker) Checker {
+	return parallelGroup{checkers: checkers, limit: len(checkers)}
+}
+
+// BoundedGroup returns a Checker that behaves like ParallelGroup but never runs
+// more than maxConcurrency checkers at once. This avoids oversubscribing machines
+// with few cores when each checker itself runs an external tool.
+//
+// If maxConcurrency <= 0 the checkers are run sequentially, exactly as Group does.
+func BoundedGroup(maxConcurrency int, checkers ...Checker) Checker {
+	if maxConcurrency <= 0 {
+		return Group(checkers)
+	}
+	return parallelGroup{checkers: checkers, limit: maxConcurrency}
+}
+
+type parallelGroup struct {
+	checkers []Checker
+	limit    int
+}
+
+// Check runs all checkers in g, with at most g.limit running at once.
+func (g parallelGroup) Check(pkgs ...string) error {
+	results := make([][]string, len(g.checkers))
+	sem := make(chan struct{}, g.limit)
+	var wg sync.WaitGroup
+	for i, checker := range g.checkers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = prefixErrors(prefixName(checker), safeCheck(checker, pkgs))
+		}(i, checker)
+	}
+	wg.Wait()
+	var errs []string
+	for _, r := range results {
+		errs = append(errs, r...)
+	}
+	return checkers.Error(errs...)
+}
+
+// safeCheck runs c.Check(pkgs...) and converts a panic into an error.
+func safeCheck(c Checker, pkgs []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return c.Check(pkgs...)
+}
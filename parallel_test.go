@@ -0,0 +1,65 @@
+package lint_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint"
+)
+
+var panics = checkFn(func(...string) error {
+	panic("checker exploded")
+})
+
+func TestParallelGroup(t *testing.T) {
+	err := lint.ParallelGroup(expectRecursive).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.ParallelGroup().Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	// The slow checker finishes last but its errors are still reported first.
+	slow := checkFn(func(pkgs ...string) error {
+		time.Sleep(20 * time.Millisecond)
+		return twoErrors(pkgs...)
+	})
+	err = lint.ParallelGroup(slow, ungroupedError).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: err1\nlint_test.checkFn: err2\nlint_test.checkFn: ungrouped: 1",
+		fmt.Sprintf("%v", err))
+
+	err = lint.ParallelGroup(panics, ungroupedError).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: panic: checker exploded\nlint_test.checkFn: ungrouped: 1",
+		fmt.Sprintf("%v", err))
+}
+
+func TestBoundedGroup(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	counting := checkFn(func(...string) error {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+	err := lint.BoundedGroup(2, counting, counting, counting, counting, counting).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	assert(t, peak <= 2, fmt.Sprintf("expected at most 2 concurrent checkers, got %d", peak))
+
+	// Output must match Group for both the bounded and sequential variants.
+	expected := lint.Group{twoErrors, ungroupedError}.Check("./...").Error()
+	for _, n := range []int{-1, 0, 1, 3} {
+		err = lint.BoundedGroup(n, twoErrors, ungroupedError).Check("./...")
+		assert(t, err != nil && err.Error() == expected, fmt.Sprintf("%d: %v", n, err))
+	}
+}
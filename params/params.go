@@ -0,0 +1,192 @@
+// Package params provides lint integration for checking the number of
+// parameters taken by functions.
+package params
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports functions that take more than Max
+// parameters. Each name in a parameter list is counted, so a, b, c int is three
+// parameters, and a variadic parameter counts as one. The receiver of a method
+// counts as a parameter unless ExcludeReceiver is set. Function literals are
+// checked on their own.
+//
+// Test files are checked along with the package.
+type Checker struct {
+	// Max is the highest allowed number of parameters. If it is 0 nothing is
+	// reported.
+	Max int
+	// ExcludeReceiver does not count the receiver of methods.
+	ExcludeReceiver bool
+	// ExcludeContext does not count a first parameter of type context.Context.
+	ExcludeContext bool
+}
+
+// Check reports each function in pkgs with too many parameters as
+//
+//	file.go:line:col: function Foo has 9 parameters (> 5)
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each function in pkgs with too many
+// parameters.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if c.Max <= 0 {
+		return nil, nil
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				diags = append(diags, c.checkFile(s.Fset, f)...)
+			}
+		}
+		return diags
+	})
+}
+
+// CheckFiles reports each function in files with too many parameters as
+// described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	var diags []checkers.Diagnostic
+	fset := token.NewFileSet()
+	for _, file := range files {
+		diags = append(diags, c.checkSource(fset, file, nil)...)
+	}
+	return checkers.DiagnosticsError(diags, nil)
+}
+
+// CheckSource reports each function in src, the contents of filename, with too
+// many parameters as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if c.Max <= 0 {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.checkSource(token.NewFileSet(), filename, src), nil)
+}
+
+// checkSource checks src, or the contents of filename if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) checkSource(fset *token.FileSet, filename string, src interface{}) []checkers.Diagnostic {
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	return c.checkFile(fset, f)
+}
+
+func (c Checker) checkFile(fset *token.FileSet, f *ast.File) []checkers.Diagnostic {
+	ctx := contextName(f)
+	var diags []checkers.Diagnostic
+	report := func(pos token.Pos, name string, recv *ast.FieldList, typ *ast.FuncType) {
+		n := c.count(ctx, recv, typ)
+		if n > c.Max {
+			p := fset.Position(pos)
+			diags = append(diags, checkers.Diagnostic{
+				File:    p.Filename,
+				Line:    p.Line,
+				Col:     p.Column,
+				Message: fmt.Sprintf("function %s has %d parameters (> %d)", name, n, c.Max),
+			})
+		}
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		name := funcName(fn)
+		report(fn.Pos(), name, fn.Recv, fn.Type)
+		if fn.Body == nil {
+			continue
+		}
+		lits := 0
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.FuncLit); ok {
+				lits++
+				report(lit.Pos(), fmt.Sprintf("%s.func%d", name, lits), nil, lit.Type)
+			}
+			return true
+		})
+	}
+	return diags
+}
+
+// count returns the number of parameters of a function with receiver recv and
+// type typ. ctx is the name the context package is imported as in the file.
+func (c Checker) count(ctx string, recv *ast.FieldList, typ *ast.FuncType) int {
+	n := 0
+	if recv != nil && !c.ExcludeReceiver {
+		n += recv.NumFields()
+	}
+	if typ.Params == nil {
+		return n
+	}
+	n += typ.Params.NumFields()
+	if list := typ.Params.List; c.ExcludeContext && len(list) > 0 && isContext(ctx, list[0].Type) {
+		n--
+	}
+	return n
+}
+
+// contextName returns the name the context package is imported as in f, or ""
+// if it is not imported by name.
+func contextName(f *ast.File) string {
+	for _, imp := range f.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path != "context" {
+			continue
+		}
+		if imp.Name == nil {
+			return "context"
+		}
+		if imp.Name.Name != "_" && imp.Name.Name != "." {
+			return imp.Name.Name
+		}
+	}
+	return ""
+}
+
+// isContext reports whether typ is context.Context, with the context package
+// imported as ctx.
+func isContext(ctx string, typ ast.Expr) bool {
+	sel, ok := typ.(*ast.SelectorExpr)
+	if !ok || ctx == "" {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == ctx && sel.Sel.Name == "Context"
+}
+
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
@@ -0,0 +1,62 @@
+package params_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/params"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package paramstest
+
+import stdctx "context"
+
+type T struct{}
+
+func (t T) Method(a, b, c int, d string) {}
+
+func Variadic(a int, rest ...string) {}
+
+func Context(ctx stdctx.Context, a, b string, c int) {}
+
+func Unnamed(int, string, bool, error) {}
+
+func Outer(a int) func(a, b, c, d int) {
+	return func(a, b, c, d int) {}
+}
+`
+
+func TestParams(t *testing.T) {
+	testutil.Test(t, "paramstest", []testutil.StaticCheckTest{
+		{
+			Checker:  params.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  params.Checker{Max: 5},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: params.Checker{Max: 3},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:7:1: function T\.Method has 5 parameters \(> 3\)\n` +
+				`.*file\.go:11:1: function Context has 4 parameters \(> 3\)\n` +
+				`.*file\.go:13:1: function Unnamed has 4 parameters \(> 3\)\n` +
+				`.*file\.go:16:9: function Outer\.func1 has 4 parameters \(> 3\)$`),
+		},
+		{
+			Checker: params.Checker{Max: 3, ExcludeReceiver: true, ExcludeContext: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:7:1: function T\.Method has 4 parameters \(> 3\)\n` +
+				`.*file\.go:13:1: function Unnamed has 4 parameters \(> 3\)\n` +
+				`.*file\.go:16:9: function Outer\.func1 has 4 parameters \(> 3\)$`),
+		},
+		{
+			Checker:  params.Checker{Max: 1},
+			Content:  []byte("package paramstest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
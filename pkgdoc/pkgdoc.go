@@ -0,0 +1,63 @@
+// Package pkgdoc provides lint integration for checking package doc comments.
+package pkgdoc
+
+import (
+	"go/ast"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports packages without a doc comment.
+// A package has a doc comment if a comment immediately precedes the package
+// clause of at least one of its files. Test files in the package itself are not
+// considered.
+type Checker struct {
+	// SkipMain does not check main packages.
+	SkipMain bool
+	// SkipTests does not check external _test packages. If it is false they must
+	// have a doc comment of their own.
+	SkipTests bool
+}
+
+// Check reports each package in pkgs without a doc comment once, as
+//
+//	pkg/foo: package foo is missing a doc comment
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each package in pkgs without a doc
+// comment.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		if d, missing := c.missing(s.ImportPath, s.Files); missing {
+			diags = append(diags, d)
+		}
+		if c.SkipTests {
+			return diags
+		}
+		if d, missing := c.missing(s.ImportPath, s.XTestFiles); missing {
+			diags = append(diags, d)
+		}
+		return diags
+	})
+}
+
+// missing returns a diagnostic and true if none of files, which all belong to
+// the same package, has a doc comment.
+func (c Checker) missing(path string, files []*ast.File) (checkers.Diagnostic, bool) {
+	if len(files) == 0 {
+		return checkers.Diagnostic{}, false
+	}
+	name := files[0].Name.Name
+	if name == "main" && c.SkipMain {
+		return checkers.Diagnostic{}, false
+	}
+	for _, f := range files {
+		if f.Doc != nil {
+			return checkers.Diagnostic{}, false
+		}
+	}
+	return checkers.Diagnostic{File: path, Message: "package " + name + " is missing a doc comment"}, true
+}
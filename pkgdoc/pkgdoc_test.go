@@ -0,0 +1,61 @@
+package pkgdoc_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/pkgdoc"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestPkgDoc(t *testing.T) {
+	testutil.Test(t, "pkgdoctest", []testutil.StaticCheckTest{
+		{
+			Checker:  pkgdoc.Checker{},
+			Content:  []byte("// Package pkgdoctest is documented.\npackage pkgdoctest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  pkgdoc.Checker{},
+			Content:  []byte("// Not a doc comment.\n\npackage pkgdoctest\n"),
+			Validate: testutil.HasSuffix("pkgdoctest: package pkgdoctest is missing a doc comment"),
+		},
+		{
+			Checker:  pkgdoc.Checker{},
+			Content:  []byte("package main\n\nfunc main() {}\n"),
+			Validate: testutil.HasSuffix("pkgdoctest: package main is missing a doc comment"),
+		},
+		{
+			Checker:  pkgdoc.Checker{SkipMain: true},
+			Content:  []byte("package main\n\nfunc main() {}\n"),
+			Validate: testutil.NoError,
+		},
+	})
+}
+
+func TestPkgDocFiles(t *testing.T) {
+	checkers.Unload("pkgdocfiles/...")
+	tmp, err := testutil.NewGopath("pkgdocfiles", []testutil.SourceFile{
+		{Content: []byte("package a\n"), Dest: filepath.Join("pkgdocfiles", "a", "a.go")},
+		{Content: []byte("// Package a is documented.\npackage a\n"), Dest: filepath.Join("pkgdocfiles", "a", "doc.go")},
+		{Content: []byte("package a_test\n"), Dest: filepath.Join("pkgdocfiles", "a", "a_test.go")},
+		{Content: []byte("package b\n"), Dest: filepath.Join("pkgdocfiles", "b", "b.go")},
+		{Content: []byte("package b\n"), Dest: filepath.Join("pkgdocfiles", "b", "c.go")},
+		{Content: []byte("// Package b is documented in a test.\npackage b\n"), Dest: filepath.Join("pkgdocfiles", "b", "b_test.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+
+	err = pkgdoc.Checker{}.Check("pkgdocfiles/...")
+	expected := "pkgdocfiles/a: package a_test is missing a doc comment\npkgdocfiles/b: package b is missing a doc comment"
+	if err == nil || err.Error() != expected {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = pkgdoc.Checker{SkipTests: true}.Check("pkgdocfiles/...")
+	if err == nil || err.Error() != "pkgdocfiles/b: package b is missing a doc comment" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Package pkgname provides lint integration for checking that package names
+// match their directory.
+package pkgname
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports packages whose name differs from
+// the name of their directory, which tools and readers expect them to match.
+// main packages and external _test packages are not reported. Each directory is
+// reported at most once.
+//
+// A directory containing files of more than one package, other than its
+// external _test package, cannot be built and is reported with a distinct
+// message naming the packages, in addition to the error reported when loading
+// it.
+type Checker struct {
+	// Allow holds path.Match patterns for the names of directories whose
+	// package may have another name, such as "v[0-9]*" for major version
+	// directories.
+	Allow []string
+}
+
+// Check reports each directory in pkgs whose package name does not match it as
+// one of
+//
+//	foo/bar: directory "bar" contains package "baz"
+//	foo/bar: directory "bar" contains multiple packages "bar", "baz"
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each directory in pkgs whose package
+// name does not match it.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		if s.Build == nil {
+			return nil
+		}
+		dir := filepath.Base(s.Build.Dir)
+		names := packageNames(s)
+		switch {
+		case len(names) > 1:
+			quoted := make([]string, len(names))
+			for i, name := range names {
+				quoted[i] = strconv.Quote(name)
+			}
+			return []checkers.Diagnostic{{
+				File:    s.ImportPath,
+				Message: "directory " + strconv.Quote(dir) + " contains multiple packages " + strings.Join(quoted, ", "),
+			}}
+		case len(names) == 0, names[0] == dir, names[0] == "main", c.allowed(dir):
+			return nil
+		}
+		return []checkers.Diagnostic{{
+			File:    s.ImportPath,
+			Message: "directory " + strconv.Quote(dir) + " contains package " + strconv.Quote(names[0]),
+		}}
+	})
+}
+
+// allowed reports whether the package in dir may have another name.
+func (c Checker) allowed(dir string) bool {
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, dir); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// packageNames returns the sorted names of the packages in the directory of s,
+// other than its external _test package. Files go/build rejected for belonging
+// to another package are read to find their names.
+func packageNames(s *checkers.Source) []string {
+	seen := map[string]bool{}
+	for _, f := range s.Files {
+		seen[f.Name.Name] = true
+	}
+	for _, f := range s.TestFiles {
+		seen[f.Name.Name] = true
+	}
+	for _, name := range s.Build.InvalidGoFiles {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filepath.Join(s.Build.Dir, name), nil, parser.PackageClauseOnly)
+		if err != nil || strings.HasSuffix(f.Name.Name, "_test") {
+			continue
+		}
+		seen[f.Name.Name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+package pkgname_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/pkgname"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestPkgName(t *testing.T) {
+	testutil.Test(t, "pkgnametest", []testutil.StaticCheckTest{
+		{
+			Checker:  pkgname.Checker{},
+			Content:  []byte("package pkgnametest\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  pkgname.Checker{},
+			Content:  []byte("package main\n\nfunc main() {}\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  pkgname.Checker{},
+			Content:  []byte("package other\n"),
+			Validate: testutil.MatchesRegexp(`^pkgnametest: directory "pkgnametest" contains package "other"$`),
+		},
+		{
+			Checker:  pkgname.Checker{Allow: []string{"pkgname*"}},
+			Content:  []byte("package other\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  pkgname.Checker{Allow: []string{"["}},
+			Content:  []byte("package other\n"),
+			Validate: testutil.Contains("syntax error in pattern"),
+		},
+	})
+}
+
+func TestPkgNameMultiple(t *testing.T) {
+	checkers.Unload("pkgnamemulti/v2")
+	tmp, err := testutil.NewGopath("pkgnamemulti", []testutil.SourceFile{
+		{Content: []byte("package multi\n"), Dest: filepath.Join("pkgnamemulti", "v2", "a.go")},
+		{Content: []byte("package multi_test\n"), Dest: filepath.Join("pkgnamemulti", "v2", "a_test.go")},
+		{Content: []byte("package other\n"), Dest: filepath.Join("pkgnamemulti", "v2", "b.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := testutil.MatchesRegexp(`(?m)^pkgnamemulti/v2: directory "v2" contains multiple packages "multi", "other"$`)(
+		pkgname.Checker{Allow: []string{"v[0-9]*"}}.Check("pkgnamemulti/v2")); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package printf provides lint integration for checking calls to the fmt
+// formatting functions.
+package printf
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// formats maps the fmt functions checked to the index of their format argument.
+var formats = map[string]int{
+	"Errorf":  0,
+	"Fprintf": 1,
+	"Printf":  0,
+	"Sprintf": 0,
+}
+
+// Checker implements lint.Checker and reports calls to the formatting functions
+// of fmt in the .go files of packages. Calls are resolved using go/types, so
+// methods and functions of other packages with the same names are not reported.
+// Each check is enabled by its field and a zero Checker runs every check.
+type Checker struct {
+	// ErrorfNoArgs reports calls to fmt.Errorf with a constant format and no
+	// other arguments, which can use errors.New instead. Formats containing %
+	// are not reported, since errors.New would not unescape them.
+	ErrorfNoArgs bool
+	// NonConstFormat reports calls to fmt.Errorf, fmt.Fprintf, fmt.Printf and
+	// fmt.Sprintf with a format string that is not a constant.
+	NonConstFormat bool
+}
+
+// all returns c with every check enabled if none is.
+func (c Checker) all() Checker {
+	if !c.ErrorfNoArgs && !c.NonConstFormat {
+		return Checker{ErrorfNoArgs: true, NonConstFormat: true}
+	}
+	return c
+}
+
+// Check reports calls in pkgs as
+//
+//	file.go:line:col: fmt.Errorf with no formatting args; use errors.New
+//	file.go:line:col: non-constant format string in Printf
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each call reported in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports calls in the packages of l, which may be shared with
+// other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	c = c.all()
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				name := fmtFunc(info, call)
+				index, ok := formats[name]
+				if !ok || len(call.Args) <= index || call.Ellipsis.IsValid() {
+					return true
+				}
+				format := info.Types[call.Args[index]].Value
+				switch {
+				case format == nil || format.Kind() != constant.String:
+					if c.NonConstFormat {
+						diags = append(diags, s.Diagnostic(call.Pos(), "non-constant format string in %s", name))
+					}
+				case name == "Errorf" && len(call.Args) == 1 && c.ErrorfNoArgs:
+					if !strings.Contains(constant.StringVal(format), "%") {
+						diags = append(diags, s.Diagnostic(call.Pos(), "fmt.Errorf with no formatting args; use errors.New"))
+					}
+				}
+				return true
+			})
+		}
+		return diags
+	})
+}
+
+// fmtFunc returns the name of the function of package fmt called by call, or ""
+// if call does not call a function of fmt.
+func fmtFunc(info *types.Info, call *ast.CallExpr) string {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" {
+		return ""
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return ""
+	}
+	return fn.Name()
+}
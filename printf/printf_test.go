@@ -0,0 +1,59 @@
+package printf_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/printf"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package printftest
+
+import (
+	"fmt"
+	"os"
+)
+
+type logger struct{}
+
+func (logger) Printf(format string, args ...interface{}) {}
+
+func Calls(format string, args []interface{}) error {
+	fmt.Printf(format)
+	fmt.Fprintf(os.Stdout, format, 1)
+	fmt.Printf("%d", 1)
+	fmt.Printf(format, args...)
+	logger{}.Printf(format)
+	_ = fmt.Sprintf("%%")
+	_ = fmt.Errorf("100%%")
+	return fmt.Errorf("failed")
+}
+`
+
+func TestPrintf(t *testing.T) {
+	testutil.Test(t, "printftest", []testutil.StaticCheckTest{
+		{
+			Checker: printf.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:13:2: non-constant format string in Printf\n` +
+				`.*file\.go:14:2: non-constant format string in Fprintf\n` +
+				`.*file\.go:20:9: fmt\.Errorf with no formatting args; use errors\.New$`),
+		},
+		{
+			Checker:  printf.Checker{ErrorfNoArgs: true},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:20:9: fmt\.Errorf with no formatting args; use errors\.New$`),
+		},
+		{
+			Checker: printf.Checker{NonConstFormat: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:13:2: non-constant format string in Printf\n` +
+				`.*file\.go:14:2: non-constant format string in Fprintf$`),
+		},
+		{
+			Checker:  printf.Checker{ErrorfNoArgs: true},
+			Content:  []byte("package printftest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
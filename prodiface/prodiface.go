@@ -0,0 +1,138 @@
+// Package prodiface provides lint integration for finding interfaces defined by
+// the packages producing their implementations rather than by their consumers.
+package prodiface
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported interfaces in the .go
+// files of packages that no other package of the module refers to. Such
+// interfaces are usually better defined by the packages that consume them,
+// following "accept interfaces, return structs".
+//
+// Every package in the module is type checked to find references to the
+// interfaces, which is expensive, so nothing is checked unless ModulePath is
+// set. References from test files are not counted, nor are interfaces that
+// only constrain type parameters. Single method interfaces returned by a
+// constructor, an exported function whose name starts with New, are not
+// reported.
+type Checker struct {
+	// ModulePath is the import path of the module, such as
+	// "github.com/surullabs/lint". The packages below it are searched for
+	// references to the interfaces checked.
+	ModulePath string
+}
+
+// Check reports each exported interface in pkgs that is only used by its own
+// package as
+//
+//	file.go:line:col: exported interface Reader is only implemented and used within its own package; consider defining it at the consumer
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each exported interface in pkgs that
+// is only used by its own package.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports exported interfaces that are only used by their own
+// package in the packages of l, which may be shared with other checkers. The
+// rest of the module is loaded separately.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	if c.ModulePath == "" {
+		return nil, nil
+	}
+	used, err := c.references()
+	if err != nil {
+		return nil, err
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		pkg, info, _ := s.TypeCheck(false)
+		if pkg == nil {
+			return nil
+		}
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || !ts.Name.IsExported() || used[pkg.Path()+"."+ts.Name.Name] {
+						continue
+					}
+					obj, ok := info.Defs[ts.Name].(*types.TypeName)
+					if !ok {
+						continue
+					}
+					iface, ok := obj.Type().Underlying().(*types.Interface)
+					if !ok || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+						continue
+					}
+					if iface.NumMethods() == 1 && constructed(pkg, obj) {
+						continue
+					}
+					diags = append(diags, s.Diagnostic(ts.Name.Pos(), "exported interface %s is only implemented and used within its own package; consider defining it at the consumer", ts.Name.Name))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// references returns the exported types of the packages of the module referred
+// to by other packages of the module, as their import path and name separated
+// by a dot.
+func (c Checker) references() (map[string]bool, error) {
+	module := strings.TrimSuffix(c.ModulePath, "/")
+	srcs, err := checkers.NewLoader(module + "/...").Sources()
+	if err != nil {
+		return nil, err
+	}
+	used := map[string]bool{}
+	for _, s := range srcs {
+		_, info, _ := s.TypeCheck(false)
+		for _, obj := range info.Uses {
+			tn, ok := obj.(*types.TypeName)
+			if !ok || tn.Pkg() == nil || tn.Pkg().Path() == s.ImportPath {
+				continue
+			}
+			if path := tn.Pkg().Path(); path == module || strings.HasPrefix(path, module+"/") {
+				used[path+"."+tn.Name()] = true
+			}
+		}
+	}
+	return used, nil
+}
+
+// constructed reports whether an exported function of pkg whose name starts with
+// New returns obj.
+func constructed(pkg *types.Package, obj *types.TypeName) bool {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok || !fn.Exported() || !strings.HasPrefix(name, "New") {
+			continue
+		}
+		results := fn.Type().(*types.Signature).Results()
+		for i := 0; i < results.Len(); i++ {
+			if named, ok := results.At(i).Type().(*types.Named); ok && named.Obj() == obj {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package prodiface_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/prodiface"
+	"github.com/surullabs/lint/testutil"
+)
+
+const producer = `package a
+
+type Local interface{ Get() int }
+
+type Shared interface{ Get() int }
+
+type Single interface{ Get() int }
+
+type Number interface{ ~int | ~int64 }
+
+type unexported interface{ Get() int }
+
+type impl struct{}
+
+func (impl) Get() int { return 1 }
+
+func NewSingle() Single { return impl{} }
+
+var _ Local = impl{}
+`
+
+const consumer = `package b
+
+import "prodifacetest/a"
+
+func Use(s a.Shared) int { return s.Get() }
+`
+
+func TestProdIface(t *testing.T) {
+	for _, pkg := range []string{"prodifacetest/a", "prodifacetest/..."} {
+		checkers.Unload(pkg)
+		defer checkers.Unload(pkg)
+	}
+	tmp, err := testutil.NewGopath("prodifacetest", []testutil.SourceFile{
+		{Content: []byte(producer), Dest: filepath.Join("prodifacetest", "a", "a.go")},
+		{Content: []byte(consumer), Dest: filepath.Join("prodifacetest", "b", "b.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	defer checkers.ClearPackageCache()
+	if err := testutil.NoError(prodiface.Checker{}.Check("prodifacetest/a")); err != nil {
+		t.Error(err)
+	}
+	validate := testutil.MatchesRegexp(`a\.go:3:6: exported interface Local is only implemented and used within its own package; consider defining it at the consumer$`)
+	if err := validate(prodiface.Checker{ModulePath: "prodifacetest"}.Check("prodifacetest/a")); err != nil {
+		t.Error(err)
+	}
+}
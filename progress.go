@@ -0,0 +1,216 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Progress receives reports of how many packages have been checked. See
+// WithProgress.
+type Progress = checkers.Progress
+
+// progresser is implemented by checkers that report each package as it is
+// checked. govet.Checker, golint.Native and gocyclo.Checker implement it, as do
+// Group and the groups returned by ParallelGroup and BoundedGroup.
+type progresser interface {
+	CheckProgress(pkgs []string, p Progress) error
+}
+
+// WithProgress returns a Checker that runs c and reports its progress to p, such
+// as to show how far a run over thousands of packages has got. The errors
+// returned are those of c.
+//
+// Checkers that report packages as they are checked do so as they go. The
+// packages checked by other checkers are reported when they finish. The total
+// passed to p.Start is the number of packages matched by pkgs for each checker
+// in a group, or -1 if the packages could not be listed.
+func WithProgress(c Checker, p Progress) Checker {
+	return withProgress{checker: c, progress: p}
+}
+
+type withProgress struct {
+	checker  Checker
+	progress Progress
+}
+
+func (w withProgress) unwrap() interface{} { return w.checker }
+
+// Name returns the name of the wrapped checker.
+func (w withProgress) Name() string { return checkerName(w.checker) }
+
+// Check runs the wrapped checker, reporting progress as it goes.
+func (w withProgress) Check(pkgs ...string) error {
+	defer w.progress.Done()
+	checker, done := untimed(w.checker)
+	defer done()
+	if c, ok := checker.(progresser); ok {
+		return c.CheckProgress(pkgs, w.progress)
+	}
+	expanded, total := expandCount(pkgs)
+	w.progress.Start(total)
+	return checkProgress(checker, pkgs, expanded, w.progress)
+}
+
+// CheckProgress is like Check but reports the packages checked by each checker
+// to p. p.Done is not called.
+func (g Group) CheckProgress(pkgs []string, p Progress) error {
+	enabled := g.enabled()
+	expanded, total := expandCount(pkgs)
+	if total > 0 {
+		total *= len(enabled)
+	}
+	p.Start(total)
+	var errs []string
+	for _, checker := range enabled {
+		errs = append(errs, prefixErrors(prefixName(checker), checkProgress(checker, pkgs, expanded, steps{p}))...)
+	}
+	return checkers.Error(errs...)
+}
+
+// CheckProgress is like Check but reports the packages checked by each checker
+// to p. Calls to p.Step are serialized. p.Done is not called.
+func (g parallelGroup) CheckProgress(pkgs []string, p Progress) error {
+	expanded, total := expandCount(pkgs)
+	if total > 0 {
+		total *= len(g.checkers)
+	}
+	p.Start(total)
+	step := &serialSteps{progress: p}
+	results := make([][]string, len(g.checkers))
+	sem := make(chan struct{}, g.limit)
+	var wg sync.WaitGroup
+	for i, checker := range g.checkers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = prefixErrors(prefixName(checker), safeProgress(checker, pkgs, expanded, step))
+		}(i, checker)
+	}
+	wg.Wait()
+	var errs []string
+	for _, r := range results {
+		errs = append(errs, r...)
+	}
+	return checkers.Error(errs...)
+}
+
+// checkProgress runs c for pkgs, reporting the packages it checks to p. Checkers
+// that do not report packages themselves have expanded, or pkgs if that is nil,
+// reported once they finish.
+func checkProgress(c Checker, pkgs, expanded []string, p Progress) error {
+	c, done := untimed(c)
+	defer done()
+	if c, ok := c.(progresser); ok {
+		return c.CheckProgress(pkgs, p)
+	}
+	err := c.Check(pkgs...)
+	if expanded == nil {
+		expanded = pkgs
+	}
+	for _, pkg := range expanded {
+		p.Step(pkg)
+	}
+	return err
+}
+
+// safeProgress is like checkProgress but converts a panic into an error, as
+// safeCheck does.
+func safeProgress(c Checker, pkgs, expanded []string, p Progress) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return checkProgress(c, pkgs, expanded, p)
+}
+
+// expandCount returns the import paths of the packages matched by pkgs and their
+// number, or nil and -1 if they cannot be listed.
+func expandCount(pkgs []string) ([]string, int) {
+	var expanded []string
+	for _, pkg := range pkgs {
+		p, err := checkers.Load(pkg)
+		if err != nil {
+			return nil, -1
+		}
+		expanded = append(expanded, p.Pkgs...)
+	}
+	return expanded, len(expanded)
+}
+
+// steps passes only calls to Step to the wrapped Progress, for checkers in a
+// group whose total has already been reported.
+type steps struct {
+	progress Progress
+}
+
+func (s steps) Start(int)       {}
+func (s steps) Step(pkg string) { s.progress.Step(pkg) }
+func (s steps) Done()           {}
+
+// serialSteps is like steps but serializes calls to Step.
+type serialSteps struct {
+	mu       sync.Mutex
+	progress Progress
+}
+
+func (s *serialSteps) Start(int) {}
+func (s *serialSteps) Step(pkg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress.Step(pkg)
+}
+func (s *serialSteps) Done() {}
+
+// ProgressOutput is where StderrProgress writes its counter.
+var ProgressOutput io.Writer = os.Stderr
+
+// StderrProgress is a Progress that keeps a single line counter of the packages
+// checked up to date on ProgressOutput, such as
+//
+//	lint: 120/2000 packages
+//
+// The line is ended by Done. The zero value is ready to use and it is safe for
+// concurrent use.
+type StderrProgress struct {
+	mu    sync.Mutex
+	total int
+	steps int
+}
+
+// Start resets the counter for a run over total packages.
+func (s *StderrProgress) Start(total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total, s.steps = total, 0
+	s.print()
+}
+
+// Step counts one more package.
+func (s *StderrProgress) Step(pkg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps++
+	s.print()
+}
+
+// Done ends the line.
+func (s *StderrProgress) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(ProgressOutput)
+}
+
+func (s *StderrProgress) print() {
+	if s.total < 0 {
+		fmt.Fprintf(ProgressOutput, "\rlint: %d packages", s.steps)
+		return
+	}
+	fmt.Fprintf(ProgressOutput, "\rlint: %d/%d packages", s.steps, s.total)
+}
@@ -0,0 +1,82 @@
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/gocyclo"
+)
+
+type recordProgress struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recordProgress) Start(total int) { r.add(fmt.Sprintf("start %d", total)) }
+func (r *recordProgress) Step(pkg string) { r.add("step " + pkg) }
+func (r *recordProgress) Done()           { r.add("done") }
+
+func (r *recordProgress) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestWithProgress(t *testing.T) {
+	const pkg = "github.com/surullabs/lint/checkers"
+	defer checkers.Unload(pkg)
+	fail := checkFn(func(...string) error { return fmt.Errorf("bad") })
+
+	p := &recordProgress{}
+	err := lint.WithProgress(fail, p).Check(pkg)
+	assert(t, err != nil && err.Error() == "bad", fmt.Sprintf("%v", err))
+	expected := "[start 1 step " + pkg + " done]"
+	assert(t, fmt.Sprint(p.events) == expected, fmt.Sprint(p.events))
+
+	p = &recordProgress{}
+	err = lint.WithProgress(gocyclo.Checker{Max: 100}, p).Check(pkg)
+	assert(t, err == nil && fmt.Sprint(p.events) == expected, fmt.Sprintf("%v %v", err, p.events))
+
+	// Groups report the total for all their checkers and prefix errors as usual.
+	for _, g := range []lint.Checker{
+		lint.Group{fail, gocyclo.Checker{Max: 100}},
+		lint.ParallelGroup(fail, gocyclo.Checker{Max: 100}),
+	} {
+		p = &recordProgress{}
+		err = lint.WithProgress(g, p).Check(pkg)
+		assert(t, err != nil && err.Error() == "lint_test.checkFn: bad", fmt.Sprintf("%v", err))
+		sort.Strings(p.events[1:3])
+		expected := "[start 2 step " + pkg + " step " + pkg + " done]"
+		assert(t, fmt.Sprint(p.events) == expected, fmt.Sprint(p.events))
+	}
+
+	// Packages that cannot be listed have an unknown total.
+	p = &recordProgress{}
+	err = lint.WithProgress(checkFn(func(...string) error { return nil }), p).Check("./does/not/exist")
+	assert(t, err == nil && fmt.Sprint(p.events) == "[start -1 step ./does/not/exist done]", fmt.Sprint(p.events))
+}
+
+func TestStderrProgress(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(w interface{ Write([]byte) (int, error) }) { lint.ProgressOutput = w }(lint.ProgressOutput)
+	lint.ProgressOutput = &buf
+
+	p := &lint.StderrProgress{}
+	p.Start(2)
+	p.Step("a")
+	p.Step("b")
+	p.Done()
+	assert(t, buf.String() == "\rlint: 0/2 packages\rlint: 1/2 packages\rlint: 2/2 packages\n", fmt.Sprintf("%q", buf.String()))
+
+	buf.Reset()
+	p.Start(-1)
+	p.Step("a")
+	p.Done()
+	assert(t, strings.HasSuffix(buf.String(), "\rlint: 1 packages\n"), fmt.Sprintf("%q", buf.String()))
+}
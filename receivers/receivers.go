@@ -0,0 +1,92 @@
+// Package receivers provides lint integration for checking that methods of the
+// same type use the same receiver name.
+package receivers
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports methods whose receiver name
+// differs from the name used by earlier methods of the same type. Methods are
+// associated with their type using go/types, so methods declared in different
+// files, including test files, are compared. Blank and missing receiver names
+// are ignored.
+type Checker struct{}
+
+// Check reports each method in pkgs with an inconsistent receiver name as
+//
+//	file.go:line: receiver name "srv" does not match previous name "s" for type Server
+//
+// The first named receiver of a type, in file order, sets the name for the rest.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each method in pkgs with an
+// inconsistent receiver name.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports inconsistent receiver names in the packages of l, which may
+// be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		names := map[*types.TypeName]string{}
+		var diags []checkers.Diagnostic
+		for _, f := range append(append([]*ast.File{}, s.Files...), s.TestFiles...) {
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+					continue
+				}
+				ident := fn.Recv.List[0].Names[0]
+				if ident.Name == "_" {
+					continue
+				}
+				typ := receiverType(info, fn)
+				if typ == nil {
+					continue
+				}
+				previous, seen := names[typ]
+				switch {
+				case !seen:
+					names[typ] = ident.Name
+				case previous != ident.Name:
+					diags = append(diags, s.Diagnostic(ident.Pos(),
+						"receiver name %q does not match previous name %q for type %s", ident.Name, previous, typ.Name()))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// receiverType returns the named type that fn is a method of, or nil if it is
+// not known.
+func receiverType(info *types.Info, fn *ast.FuncDecl) *types.TypeName {
+	obj, ok := info.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	recv := obj.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj()
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package receivers_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/receivers"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestReceivers(t *testing.T) {
+	testutil.Test(t, "receiverstest", []testutil.StaticCheckTest{
+		{
+			Checker: receivers.Checker{},
+			Content: []byte(`package receiverstest
+
+type Server struct{}
+
+func (s *Server) A() {}
+func (s Server) B()  {}
+func (_ *Server) C() {}
+func (*Server) D()   {}
+`),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: receivers.Checker{},
+			Content: []byte(`package receiverstest
+
+type Server struct{}
+
+type Client struct{}
+
+func (s *Server) A()   {}
+func (srv *Server) B() {}
+func (c Client) C()    {}
+func (s Server) D()    {}
+func (cl *Client) E()  {}
+`),
+			Validate: testutil.MatchesRegexp(`file\.go:8:7: receiver name "srv" does not match previous name "s" for type Server\n` +
+				`.*file\.go:11:7: receiver name "cl" does not match previous name "c" for type Client$`),
+		},
+	})
+}
+
+func TestReceiversAcrossFiles(t *testing.T) {
+	checkers.Unload("receiversfiles")
+	tmp, err := testutil.NewGopath("receiversfiles", []testutil.SourceFile{
+		{Content: []byte("package a\n\ntype T struct{}\n\nfunc (t T) A() {}\n"), Dest: filepath.Join("receiversfiles", "a.go")},
+		{Content: []byte("package a\n\nfunc (x *T) B() {}\n"), Dest: filepath.Join("receiversfiles", "b.go")},
+		{Content: []byte("package a\n\nfunc (tt T) C() {}\n"), Dest: filepath.Join("receiversfiles", "a_test.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	err = receivers.Checker{}.Check("receiversfiles")
+	if err := testutil.MatchesRegexp(`b\.go:3:7: receiver name "x" does not match previous name "t" for type T\n` +
+		`.*a_test\.go:3:7: receiver name "tt" does not match previous name "t" for type T$`)(err); err != nil {
+		t.Error(err)
+	}
+}
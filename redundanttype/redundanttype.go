@@ -0,0 +1,90 @@
+// Package redundanttype provides lint integration for finding conversions of a
+// value to the type it already has.
+package redundanttype
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports conversions in .go files,
+// including test files, whose operand already has the type converted to, such
+// as string(s) where s is a string. Types are compared using go/types, so
+// conversions between a named type and its underlying type, such as
+// time.Duration(n) where n is an int64, are not reported. Neither are
+// conversions of untyped constants and nil, which give them a type.
+type Checker struct{}
+
+// Check reports each redundant conversion in pkgs as
+//
+//	file.go:line:col: redundant conversion to string
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each redundant conversion in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports redundant conversions in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		pkg, info, _ := s.TypeCheck(true)
+		qualifier := func(p *types.Package) string {
+			if pkg != nil && p.Path() == pkg.Path() {
+				return ""
+			}
+			return p.Name()
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok || len(call.Args) != 1 || !info.Types[call.Fun].IsType() {
+						return true
+					}
+					to, arg := info.TypeOf(call.Fun), info.Types[call.Args[0]]
+					if to == nil || arg.Type == nil || arg.IsNil() || !types.Identical(to, arg.Type) {
+						return true
+					}
+					if arg.Value != nil && !typedConst(info, call.Args[0]) {
+						return true
+					}
+					diags = append(diags, s.Diagnostic(call.Pos(), "redundant conversion to %s", types.TypeString(to, qualifier)))
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// typedConst reports whether e names a constant declared with a type. Other
+// constant expressions may be untyped, in which case the conversion gives them
+// a type.
+func typedConst(info *types.Info, e ast.Expr) bool {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	c, ok := info.Uses[id].(*types.Const)
+	if !ok {
+		return false
+	}
+	basic, ok := c.Type().(*types.Basic)
+	return !ok || basic.Info()&types.IsUntyped == 0
+}
@@ -0,0 +1,56 @@
+package redundanttype_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/redundanttype"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package redundanttypetest
+
+import "time"
+
+type ID string
+
+const (
+	name       = "x"
+	typed string = "y"
+)
+
+func Convert(s string, n int, id ID, d time.Duration, p *int) {
+	_ = string(s)
+	_ = int(n)
+	_ = string(id)
+	_ = ID(s)
+	_ = ID(id)
+	_ = time.Duration(n)
+	_ = time.Duration(d)
+	_ = int64(5)
+	_ = string(name)
+	_ = string(typed)
+	_ = (*int)(nil)
+	_ = (*int)(p)
+	_ = []byte(s)
+}
+`
+
+func TestRedundantType(t *testing.T) {
+	testutil.Test(t, "redundanttypetest", []testutil.StaticCheckTest{
+		{
+			Checker: redundanttype.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:13:6: redundant conversion to string\n` +
+				`[^\n]*file\.go:14:6: redundant conversion to int\n` +
+				`[^\n]*file\.go:17:6: redundant conversion to ID\n` +
+				`[^\n]*file\.go:19:6: redundant conversion to time\.Duration\n` +
+				`[^\n]*file\.go:22:6: redundant conversion to string\n` +
+				`[^\n]*file\.go:24:6: redundant conversion to \*int$`),
+		},
+		{
+			Checker:  redundanttype.Checker{},
+			Content:  []byte("package redundanttypetest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,111 @@
+// Package regexphoisting provides lint integration for finding regular
+// expressions compiled inside functions.
+package regexphoisting
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// compilers holds the functions of package regexp that compile a pattern.
+var compilers = map[string]bool{
+	"Compile":          true,
+	"CompilePOSIX":     true,
+	"MustCompile":      true,
+	"MustCompilePOSIX": true,
+}
+
+// Checker implements lint.Checker and reports calls to regexp.Compile,
+// regexp.MustCompile and their POSIX variants with a constant pattern inside a
+// function body, including function literals assigned to package-level
+// variables, in .go files including test files. The pattern is compiled again
+// on every call, so it should be compiled once in a package-level variable.
+// Calls with a pattern computed at run time, and calls in init functions, which
+// run once, are not reported.
+//
+// Calls are resolved using go/types, so a function named MustCompile of another
+// package is not reported. Reports can be suppressed with a comment on the line
+// of the call or the line before it of the form
+//
+//	//nolint:regexphoisting
+type Checker struct{}
+
+// Check reports each regular expression compiled inside a function in pkgs as
+//
+//	file.go:line:col: regexp.MustCompile inside a function; hoist to a package-level var
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each regular expression compiled
+// inside a function in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports regular expressions compiled inside functions in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				suppressed := checkers.NoLintLines(s.Fset, f, "regexphoisting.Checker")
+				check := func(body *ast.BlockStmt) {
+					ast.Inspect(body, func(n ast.Node) bool {
+						call, ok := n.(*ast.CallExpr)
+						if !ok || len(call.Args) == 0 || info.Types[call.Args[0]].Value == nil {
+							return true
+						}
+						name := compiler(info, call)
+						if name == "" {
+							return true
+						}
+						if line := s.Fset.Position(call.Pos()).Line; suppressed[line] || suppressed[line-1] {
+							return true
+						}
+						diags = append(diags, s.Diagnostic(call.Pos(), "regexp.%s inside a function; hoist to a package-level var", name))
+						return true
+					})
+				}
+				for _, decl := range f.Decls {
+					if fn, ok := decl.(*ast.FuncDecl); ok {
+						if fn.Body != nil && (fn.Recv != nil || fn.Name.Name != "init") {
+							check(fn.Body)
+						}
+						continue
+					}
+					ast.Inspect(decl, func(n ast.Node) bool {
+						if lit, ok := n.(*ast.FuncLit); ok {
+							check(lit.Body)
+							return false
+						}
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// compiler returns the name of the function of package regexp compiling a
+// pattern called by call, or "" if it is not one.
+func compiler(info *types.Info, call *ast.CallExpr) string {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "regexp" || !compilers[fn.Name()] {
+		return ""
+	}
+	return fn.Name()
+}
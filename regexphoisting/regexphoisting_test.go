@@ -0,0 +1,57 @@
+package regexphoisting_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/regexphoisting"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package regexphoistingtest
+
+import "regexp"
+
+const idPattern = "^[a-z]+$"
+
+var word = regexp.MustCompile("\\w+")
+
+var lazy = func(s string) bool {
+	return regexp.MustCompile("^lazy").MatchString(s)
+}
+
+func ValidID(id string) bool {
+	return regexp.MustCompile(idPattern).MatchString(id)
+}
+
+func Parse(s string) (*regexp.Regexp, error) {
+	if re, err := regexp.Compile(` + "`^\\d+$`" + `); err == nil && re.MatchString(s) {
+		return re, nil
+	}
+	return regexp.Compile(s)
+}
+
+func init() {
+	word = regexp.MustCompile("\\w+")
+}
+
+func Ignored() *regexp.Regexp {
+	return regexp.MustCompilePOSIX("[a-z]") //nolint:regexphoisting
+}
+`
+
+func TestRegexpHoisting(t *testing.T) {
+	testutil.Test(t, "regexphoistingtest", []testutil.StaticCheckTest{
+		{
+			Checker: regexphoisting.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:10:9: regexp\.MustCompile inside a function; hoist to a package-level var\n` +
+				`[^\n]*file\.go:14:9: regexp\.MustCompile inside a function; hoist to a package-level var\n` +
+				`[^\n]*file\.go:18:16: regexp\.Compile inside a function; hoist to a package-level var$`),
+		},
+		{
+			Checker:  regexphoisting.Checker{},
+			Content:  []byte("package regexphoistingtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package lint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// RelativeTo returns a Checker that runs c and rewrites the file name at the
+// start of each error, as in
+//
+//	/src/repo/pkg/file.go:23:4: message
+//
+// to be relative to root, giving pkg/file.go:23:4: message. Only absolute file
+// names inside root are rewritten, so files outside it never become ../ chains.
+// Any prefix added by Group or WithSeverity is kept.
+//
+// If root is empty, the directory containing the main module's go.mod, as
+// reported by go env GOMOD, is used. It is found once each time Check is run,
+// before running c. Outside a module the current directory is used.
+func RelativeTo(root string, c Checker) Checker {
+	if root == "" {
+		return relativeTo{checker: c}
+	}
+	return relativeFiles(root, c)
+}
+
+func relativeFiles(root string, c Checker) Checker {
+	return Transform(c, func(msg string) string { return relativeFile(root, msg) })
+}
+
+type relativeTo struct {
+	checker Checker
+}
+
+func (r relativeTo) unwrap() interface{} { return r.checker }
+
+// Name returns the name of the wrapped checker.
+func (r relativeTo) Name() string { return checkerName(r.checker) }
+
+// Check runs the wrapped checker and rewrites its file names to be relative to
+// the main module.
+func (r relativeTo) Check(pkgs ...string) error {
+	return relativeFiles(moduleRoot(), r.checker).Check(pkgs...)
+}
+
+// moduleRoot returns the directory of the main module or the current directory
+// if there is none.
+func moduleRoot() string {
+	res, err := checkers.Exec(exec.Command("go", "env", "GOMOD"))
+	if gomod := strings.TrimSpace(res.Stdout); err == nil && gomod != "" && gomod != os.DevNull {
+		return filepath.Dir(gomod)
+	}
+	wd, _ := os.Getwd()
+	return wd
+}
+
+// relativeFile rewrites the file name at the start of msg to be relative to root
+// if it is an absolute path inside root.
+func relativeFile(root, msg string) string {
+	file := ParseDiagnostic(msg).File
+	if root == "" || !filepath.IsAbs(file) {
+		return msg
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return msg
+	}
+	return strings.Replace(msg, file, filepath.ToSlash(rel), 1)
+}
@@ -0,0 +1,47 @@
+package lint_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestRelativeTo(t *testing.T) {
+	msgs := checkFn(func(pkgs ...string) error {
+		return checkers.Error(
+			"/src/repo/a.go:23: one",
+			"/src/repo/pkg/b.go:23:4: two",
+			"/src/other/c.go:1: outside",
+			"/src/repository/d.go:1: sibling",
+			"e.go:1: relative",
+			"/src/repo/f.go: no position",
+			"not a position /src/repo/g.go:1",
+		)
+	})
+	expected := []string{
+		"a.go:23: one",
+		"pkg/b.go:23:4: two",
+		"/src/other/c.go:1: outside",
+		"/src/repository/d.go:1: sibling",
+		"e.go:1: relative",
+		"f.go: no position",
+		"not a position /src/repo/g.go:1",
+	}
+	err := lint.RelativeTo("/src/repo", msgs).Check()
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	err = lint.RelativeTo("/src/repo/", lint.WithSeverity(lint.SeverityWarning, msgs)).Check()
+	assert(t, err != nil && strings.HasPrefix(err.Error(), "warning: a.go:23: one\nwarning: pkg/b.go:23:4: two\n"), fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.RelativeTo("/src/repo", msgs)}.Check()
+	assert(t, err != nil && strings.HasPrefix(err.Error(), "lint_test.checkFn: a.go:23: one\n"), fmt.Sprintf("%v", err))
+
+	wd, err := os.Getwd()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	err = lint.RelativeTo("", checkFn(func(pkgs ...string) error { return checkers.Error(wd + "/lint.go:1: found") })).Check()
+	assert(t, err != nil && err.Error() == "lint.go:1: found", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,214 @@
+// Package resourceleak provides lint integration for finding opened resources
+// that are never closed.
+package resourceleak
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultConstructors holds the functions tracked by Checker if Constructors is
+// empty.
+var DefaultConstructors = []string{
+	"net.Dial",
+	"net.Listen",
+	"net/http.Get",
+	"net/http.Head",
+	"net/http.Post",
+	"net/http.PostForm",
+	"os.Create",
+	"os.Open",
+	"os.OpenFile",
+}
+
+// Checker implements lint.Checker and reports resources opened in .go files
+// that are never closed. A resource is the result of a call to one of
+// Constructors that implements io.Closer, or has a Body field that does as
+// *http.Response does, assigned to a variable. It is considered closed if Close
+// is called on the variable, or on its Body, anywhere in the function assigning
+// it, including in defer statements and function literals. A resource that is
+// returned or assigned to another variable or field is assumed to be closed by
+// its new owner.
+//
+// The check is flow-insensitive, so a resource closed on only some paths is not
+// reported. Reports can be suppressed with a comment on the line of the call or
+// the line before it of the form
+//
+//	//nolint:resourceleak
+type Checker struct {
+	// Constructors holds the functions whose results are tracked, as the import
+	// path of their package and their name, such as "os.Open" or
+	// "net/http.Get". If it is empty, DefaultConstructors is used.
+	Constructors []string
+}
+
+// Check reports each resource in pkgs that is never closed as
+//
+//	file.go:line:col: result of os.Open is never Closed
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each resource in pkgs that is never
+// closed.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports resources that are never closed in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	constructors := map[string]bool{}
+	list := c.Constructors
+	if len(list) == 0 {
+		list = DefaultConstructors
+	}
+	for _, name := range list {
+		constructors[name] = true
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "resourceleak.Checker")
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					assign, ok := n.(*ast.AssignStmt)
+					if !ok || len(assign.Rhs) != 1 {
+						return true
+					}
+					call, ok := assign.Rhs[0].(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					callee := calledFunc(info, call)
+					if callee == nil || !constructors[callee.Pkg().Path()+"."+callee.Name()] {
+						return true
+					}
+					index := closerResult(callee)
+					if index < 0 || index >= len(assign.Lhs) {
+						return true
+					}
+					line := s.Fset.Position(call.Pos()).Line
+					if suppressed[line] || suppressed[line-1] {
+						return true
+					}
+					id, ok := assign.Lhs[index].(*ast.Ident)
+					if !ok {
+						// Assigned to a field or element, which owns it.
+						return true
+					}
+					if id.Name != "_" {
+						if v := info.ObjectOf(id); v == nil || closed(info, fn.Body, v) {
+							return true
+						}
+					}
+					diags = append(diags, s.Diagnostic(call.Pos(), "result of %s.%s is never Closed", callee.Pkg().Name(), callee.Name()))
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// calledFunc returns the package-level function called by call, or nil.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// closerResult returns the index of the first result of fn that is or holds a
+// closer, or -1 if there is none.
+func closerResult(fn *types.Func) int {
+	results := fn.Type().(*types.Signature).Results()
+	for i := 0; i < results.Len(); i++ {
+		typ := results.At(i).Type()
+		if isCloser(typ) {
+			return i
+		}
+		if body, _, _ := types.LookupFieldOrMethod(typ, true, nil, "Body"); body != nil {
+			if v, ok := body.(*types.Var); ok && v.IsField() && isCloser(v.Type()) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isCloser reports whether typ has a method Close() error.
+func isCloser(typ types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "Close")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 && sig.Results().At(0).Type().String() == "error"
+}
+
+// closed reports whether v is closed or handed over to another owner in body.
+func closed(info *types.Info, body *ast.BlockStmt, v types.Object) bool {
+	refers := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && info.Uses[id] == v
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Close" {
+				return true
+			}
+			x := ast.Unparen(sel.X)
+			if body, ok := x.(*ast.SelectorExpr); ok && body.Sel.Name == "Body" {
+				x = body.X
+			}
+			found = refers(x)
+		case *ast.ReturnStmt:
+			for _, r := range n.Results {
+				found = found || refers(r)
+			}
+		case *ast.AssignStmt:
+			for _, r := range n.Rhs {
+				found = found || refers(r)
+			}
+		case *ast.KeyValueExpr:
+			found = refers(n.Value)
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				found = found || refers(elt)
+			}
+		case *ast.SendStmt:
+			found = refers(n.Value)
+		}
+		return true
+	})
+	return found
+}
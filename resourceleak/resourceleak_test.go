@@ -0,0 +1,82 @@
+package resourceleak_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/resourceleak"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package resourceleaktest
+
+import (
+	"io"
+	"net/http"
+	"os"
+)
+
+type holder struct{ f *os.File }
+
+func Leaked() {
+	f, _ := os.Open("file")
+	_, _ = io.ReadAll(f)
+}
+
+func Deferred() error {
+	f, err := os.Create("file")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return nil
+}
+
+func Returned() (*os.File, error) {
+	f, err := os.Open("file")
+	return f, err
+}
+
+func Stored(h *holder) {
+	f, _ := os.Open("file")
+	*h = holder{f: f}
+	h.f, _ = os.Open("other")
+}
+
+func Body() {
+	resp, _ := http.Get("http://example.com")
+	func() { resp.Body.Close() }()
+	leaked, _ := http.Get("http://example.com")
+	_ = leaked.StatusCode
+}
+
+func Ignored() {
+	_, _ = os.Open("file")
+	f, _ := os.Open("file") //nolint:resourceleak
+	//nolint
+	g, _ := os.OpenFile("file", os.O_RDONLY, 0)
+	h, _ := os.Create("file") //nolint:resourceleak.Checker
+	_, _, _ = f, g, h
+}
+`
+
+func TestResourceLeak(t *testing.T) {
+	testutil.Test(t, "resourceleaktest", []testutil.StaticCheckTest{
+		{
+			Checker: resourceleak.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:12:10: result of os\.Open is never Closed\n` +
+				`.*file\.go:39:15: result of http\.Get is never Closed\n` +
+				`.*file\.go:44:9: result of os\.Open is never Closed$`),
+		},
+		{
+			Checker:  resourceleak.Checker{Constructors: []string{"os.Create"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  resourceleak.Checker{},
+			Content:  []byte("package resourceleaktest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package lint
+
+import "strings"
+
+// transientMessages are the messages of errors, reported by tools that fail to
+// load packages, that Transient treats as infrastructure failures.
+var transientMessages = []string{"cannot find package", "build failed"}
+
+// Transient reports whether err looks like an infrastructure failure, rather
+// than a lint finding, because one of its messages contains
+//
+//	cannot find package
+//	build failed
+//
+// It is the default predicate used by RetryChecker.
+func Transient(err error) bool {
+	if err == nil {
+		return false
+	}
+	msgs := []string{err.Error()}
+	if list, ok := err.(errors); ok {
+		msgs = list.Errors()
+	}
+	for _, msg := range msgs {
+		for _, transient := range transientMessages {
+			if strings.Contains(msg, transient) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RetryChecker is a Checker that re-runs a checker that fails with an
+// infrastructure failure. Use Retry to create one.
+type RetryChecker struct {
+	// ShouldRetry reports whether a check that failed with err should be run
+	// again. If it is nil, Transient is used.
+	ShouldRetry func(err error) bool
+
+	checker  Checker
+	attempts int
+}
+
+// Retry returns a RetryChecker that runs c up to attempts times, stopping at the
+// first run that succeeds or fails with an error that should not be retried.
+// Lint findings are never retried by default. The error from the last run is
+// returned unmodified. If attempts < 1, c is run once.
+func Retry(attempts int, c Checker) RetryChecker {
+	return RetryChecker{checker: c, attempts: attempts}
+}
+
+func (r RetryChecker) unwrap() interface{} { return r.checker }
+
+// Name returns the name of the wrapped checker.
+func (r RetryChecker) Name() string { return checkerName(r.checker) }
+
+// Check runs the wrapped checker until it succeeds, fails with an error that
+// should not be retried or has been run for every attempt.
+func (r RetryChecker) Check(pkgs ...string) error {
+	retry := r.ShouldRetry
+	if retry == nil {
+		retry = Transient
+	}
+	err := r.checker.Check(pkgs...)
+	for i := 1; i < r.attempts && err != nil && retry(err); i++ {
+		err = r.checker.Check(pkgs...)
+	}
+	return err
+}
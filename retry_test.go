@@ -0,0 +1,44 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestRetry(t *testing.T) {
+	var runs int
+	flaky := func(failures int, err error) lint.Checker {
+		runs = 0
+		return checkFn(func(pkgs ...string) error {
+			runs++
+			if runs <= failures {
+				return checkers.Error("file.go:1: finding", fmt.Sprintf("run %d: cannot find package \"a\"", runs))
+			}
+			return err
+		})
+	}
+
+	err := lint.Retry(3, flaky(2, nil)).Check()
+	assert(t, err == nil && runs == 3, fmt.Sprintf("%d: %v", runs, err))
+
+	err = lint.Retry(2, flaky(5, nil)).Check()
+	list, ok := err.(interface{ Errors() []string })
+	assert(t, ok && runs == 2 && list.Errors()[1] == `run 2: cannot find package "a"`, fmt.Sprintf("%d: %v", runs, err))
+
+	err = lint.Retry(3, flaky(0, checkers.Error("file.go:1: finding"))).Check()
+	assert(t, err != nil && err.Error() == "file.go:1: finding" && runs == 1, fmt.Sprintf("%d: %v", runs, err))
+
+	err = lint.Retry(0, flaky(5, nil)).Check()
+	assert(t, err != nil && runs == 1, fmt.Sprintf("%d: %v", runs, err))
+
+	r := lint.Retry(3, flaky(0, fmt.Errorf("timed out")))
+	r.ShouldRetry = func(err error) bool { return err.Error() == "timed out" }
+	err = r.Check()
+	assert(t, err != nil && runs == 3, fmt.Sprintf("%d: %v", runs, err))
+
+	err = lint.Group{lint.Retry(2, flaky(0, fmt.Errorf("build failed")))}.Check()
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: build failed" && runs == 2, fmt.Sprintf("%d: %v", runs, err))
+}
@@ -0,0 +1,129 @@
+// Package sarif writes lint errors as SARIF 2.1.0 documents, which can be
+// uploaded to code scanning services such as GitHub code scanning.
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/surullabs/lint"
+)
+
+const (
+	version = "2.1.0"
+	schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// defaultTool is used as the tool name for errors without a checker prefix.
+	defaultTool = "lint"
+)
+
+// Write converts err, as returned by lint.Group.Check, into a SARIF document
+// and writes it to w.
+//
+// Errors are parsed using lint.Diagnostics. There is one run per distinct checker
+// prefix and one result per error. Each checker also has a single rule whose id is
+// the checker name. A nil err results in a document with a single run containing
+// no results.
+func Write(w io.Writer, err error) error {
+	doc := log{Version: version, Schema: schema}
+	runs := map[string]int{}
+	for _, d := range lint.Diagnostics(err) {
+		name := d.Checker
+		if name == "" {
+			name = defaultTool
+		}
+		i, ok := runs[name]
+		if !ok {
+			i = len(doc.Runs)
+			runs[name] = i
+			doc.Runs = append(doc.Runs, newRun(name))
+		}
+		doc.Runs[i].Results = append(doc.Runs[i].Results, newResult(name, d))
+	}
+	if len(doc.Runs) == 0 {
+		doc.Runs = append(doc.Runs, newRun(defaultTool))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func newRun(name string) run {
+	return run{
+		Tool:    tool{Driver: driver{Name: name, Rules: []rule{{ID: name}}}},
+		Results: []result{},
+	}
+}
+
+func newResult(rule string, d lint.Diagnostic) result {
+	r := result{RuleID: rule, Level: "error", Message: message{Text: d.Message}}
+	if d.File == "" {
+		return r
+	}
+	loc := physicalLocation{ArtifactLocation: artifactLocation{URI: uri(d.File)}}
+	if d.Line > 0 {
+		loc.Region = &region{StartLine: d.Line, StartColumn: d.Col}
+	}
+	r.Locations = []location{{PhysicalLocation: loc}}
+	return r
+}
+
+func uri(file string) string {
+	if filepath.IsAbs(file) {
+		return "file://" + filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(file)
+}
+
+type log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name  string `json:"name"`
+	Rules []rule `json:"rules"`
+}
+
+type rule struct {
+	ID string `json:"id"`
+}
+
+type result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   message    `json:"message"`
+	Locations []location `json:"locations,omitempty"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           *region          `json:"region,omitempty"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
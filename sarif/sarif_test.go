@@ -0,0 +1,91 @@
+package sarif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/sarif"
+)
+
+type doc struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine   int `json:"startLine"`
+						StartColumn int `json:"startColumn"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func write(t *testing.T, err error) doc {
+	var buf bytes.Buffer
+	if werr := sarif.Write(&buf, err); werr != nil {
+		t.Fatal(werr)
+	}
+	var d doc
+	if jerr := json.Unmarshal(buf.Bytes(), &d); jerr != nil {
+		t.Fatal(jerr, buf.String())
+	}
+	return d
+}
+
+func TestWrite(t *testing.T) {
+	d := write(t, nil)
+	if d.Version != "2.1.0" || len(d.Runs) != 1 || d.Runs[0].Results == nil || len(d.Runs[0].Results) != 0 {
+		t.Fatalf("unexpected document for nil error: %+v", d)
+	}
+
+	d = write(t, checkers.Error(
+		"govet.Check: a/file.go:23:4: err is shadowed",
+		"golint.Check: /abs/b.go:2: exported Foo should have comment",
+		"govet.Check: failed to run",
+	))
+	var names []string
+	for _, r := range d.Runs {
+		names = append(names, r.Tool.Driver.Name)
+		if len(r.Tool.Driver.Rules) != 1 || r.Tool.Driver.Rules[0].ID != r.Tool.Driver.Name {
+			t.Errorf("unexpected rules: %+v", r.Tool.Driver)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"govet.Check", "golint.Check"}) {
+		t.Fatalf("unexpected runs: %v", names)
+	}
+	vet := d.Runs[0].Results
+	if len(vet) != 2 || vet[0].RuleID != "govet.Check" || vet[0].Message.Text != "err is shadowed" {
+		t.Fatalf("unexpected results: %+v", vet)
+	}
+	loc := vet[0].Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "a/file.go" || loc.Region.StartLine != 23 || loc.Region.StartColumn != 4 {
+		t.Errorf("unexpected location: %+v", loc)
+	}
+	if len(vet[1].Locations) != 0 || vet[1].Message.Text != "failed to run" {
+		t.Errorf("unexpected result: %+v", vet[1])
+	}
+	if uri := d.Runs[1].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "file:///abs/b.go" {
+		t.Errorf("unexpected uri: %s", uri)
+	}
+}
@@ -0,0 +1,131 @@
+// Package senterr provides lint integration for checking the naming and use of
+// sentinel errors.
+package senterr
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and checks sentinel errors, package-level
+// variables of type error, in .go files, including test files. Each check is
+// enabled by its field and a zero Checker runs every check.
+type Checker struct {
+	// Naming reports sentinel errors whose name does not start with Err, or err
+	// if unexported, as in ErrNotFound.
+	Naming bool
+	// Compare reports comparisons of two errors using == or !=, which do not
+	// match a sentinel error wrapped by fmt.Errorf with %w. Both operands must
+	// have type error, so comparisons with nil are not reported.
+	Compare bool
+}
+
+// all returns c with every check enabled if none is.
+func (c Checker) all() Checker {
+	if !c.Naming && !c.Compare {
+		return Checker{Naming: true, Compare: true}
+	}
+	return c
+}
+
+// Check reports each problem with sentinel errors in pkgs as one of
+//
+//	file.go:line:col: sentinel error should be named ErrNotFound
+//	file.go:line:col: comparing errors with ==; use errors.Is
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each problem with sentinel errors in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports problems with sentinel errors in the packages of l, which
+// may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	c = c.all()
+	errorType := types.Universe.Lookup("error").Type()
+	isError := func(t types.Type) bool { return t != nil && types.Identical(t, errorType) }
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				if c.Naming {
+					for _, decl := range f.Decls {
+						gen, ok := decl.(*ast.GenDecl)
+						if !ok || gen.Tok != token.VAR {
+							continue
+						}
+						for _, spec := range gen.Specs {
+							for _, name := range spec.(*ast.ValueSpec).Names {
+								obj := info.Defs[name]
+								if name.Name == "_" || obj == nil || !isError(obj.Type()) || hasErrPrefix(name.Name) {
+									continue
+								}
+								diags = append(diags, s.Diagnostic(name.Pos(), "sentinel error should be named %s", sentinelName(name.Name)))
+							}
+						}
+					}
+				}
+				if c.Compare {
+					ast.Inspect(f, func(n ast.Node) bool {
+						bin, ok := n.(*ast.BinaryExpr)
+						if !ok || (bin.Op != token.EQL && bin.Op != token.NEQ) {
+							return true
+						}
+						if isError(info.TypeOf(bin.X)) && isError(info.TypeOf(bin.Y)) {
+							diags = append(diags, s.Diagnostic(bin.OpPos, "comparing errors with %s; use errors.Is", bin.Op))
+						}
+						return true
+					})
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// hasErrPrefix reports whether name is Err or err followed by the start of a
+// word, as in ErrNotFound.
+func hasErrPrefix(name string) bool {
+	return hasWordPrefix(name, "Err") || hasWordPrefix(name, "err")
+}
+
+// hasWordPrefix reports whether name starts with prefix followed by the end of
+// name or the start of another word.
+func hasWordPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return r == utf8.RuneError || unicode.IsUpper(r) || unicode.IsDigit(r)
+}
+
+// sentinelName returns the suggested name for a sentinel error named name.
+func sentinelName(name string) string {
+	prefix := "err"
+	if ast.IsExported(name) {
+		prefix = "Err"
+	}
+	for _, word := range []string{"Error", "error", "Err", "err"} {
+		if hasWordPrefix(name, word) && name != word {
+			name = name[len(word):]
+			break
+		}
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return prefix + string(unicode.ToUpper(r)) + name[size:]
+}
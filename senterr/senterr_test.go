@@ -0,0 +1,69 @@
+package senterr_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/senterr"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package senterrtest
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	errClosed   = errors.New("closed")
+	NotFound    = errors.New("not found")
+	timeout     = errors.New("timeout")
+	ErrorBusy   = errors.New("busy")
+	Errant      = errors.New("errant")
+	count       = 0
+	_           = errors.New("blank")
+)
+
+func Read(err error) bool {
+	if err == nil || err != io.EOF {
+		return false
+	}
+	n := 1
+	return n == count || err == ErrNotFound
+}
+`
+
+func TestSentErr(t *testing.T) {
+	testutil.Test(t, "senterrtest", []testutil.StaticCheckTest{
+		{
+			Checker: senterr.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:2: sentinel error should be named ErrNotFound\n` +
+				`[^\n]*file\.go:12:2: sentinel error should be named errTimeout\n` +
+				`[^\n]*file\.go:13:2: sentinel error should be named ErrBusy\n` +
+				`[^\n]*file\.go:14:2: sentinel error should be named ErrErrant\n` +
+				`[^\n]*file\.go:20:23: comparing errors with !=; use errors\.Is\n` +
+				`[^\n]*file\.go:24:27: comparing errors with ==; use errors\.Is$`),
+		},
+		{
+			Checker: senterr.Checker{Naming: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:11:2: sentinel error should be named ErrNotFound\n` +
+				`[^\n]*file\.go:12:2: sentinel error should be named errTimeout\n` +
+				`[^\n]*file\.go:13:2: sentinel error should be named ErrBusy\n` +
+				`[^\n]*file\.go:14:2: sentinel error should be named ErrErrant$`),
+		},
+		{
+			Checker: senterr.Checker{Compare: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:20:23: comparing errors with !=; use errors\.Is\n` +
+				`[^\n]*file\.go:24:27: comparing errors with ==; use errors\.Is$`),
+		},
+		{
+			Checker:  senterr.Checker{Naming: true},
+			Content:  []byte("package senterrtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
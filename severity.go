@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Severity indicates how serious an error is.
+type Severity = checkers.Severity
+
+// Severity levels, from most to least severe. The zero value is SeverityError,
+// which is the severity of every message that has not been tagged by WithSeverity.
+const (
+	SeverityError   = checkers.SeverityError
+	SeverityWarning = checkers.SeverityWarning
+	SeverityInfo    = checkers.SeverityInfo
+)
+
+// cutSeverity removes a leading severity tag, other than error, from str.
+func cutSeverity(str string) (Severity, string, bool) {
+	for _, s := range []Severity{SeverityWarning, SeverityInfo} {
+		if name := s.String(); strings.HasPrefix(str, name+": ") {
+			return s, str[len(name)+len(": "):], true
+		}
+	}
+	return SeverityError, str, false
+}
+
+// WithSeverity returns a Checker that tags every error returned by c with s,
+// so
+//
+//	file.go:23: exported Foo should have comment
+//
+// becomes
+//
+//	warning: file.go:23: exported Foo should have comment
+//
+// SeverityError is the default and is not shown. Any severity already present is
+// replaced. Group.Check prefixes errors from WithSeverity with the name of c,
+// placing the prefix before the severity.
+func WithSeverity(s Severity, c Checker) Checker {
+	return severity{checker: c, severity: s}
+}
+
+type severity struct {
+	checker  Checker
+	severity Severity
+}
+
+func (s severity) unwrap() interface{} { return s.checker }
+
+// Name returns the name of the wrapped checker.
+func (s severity) Name() string { return checkerName(s.checker) }
+
+// Check runs the wrapped checker and tags its errors.
+func (s severity) Check(pkgs ...string) error {
+	return s.tag(s.checker.Check(pkgs...))
+}
+
+// CheckSource checks src using the wrapped checker as described in CheckSource
+// and tags its errors.
+func (s severity) CheckSource(filename string, src []byte) error {
+	return s.tag(CheckSource(s.checker, filename, src))
+}
+
+func (s severity) tag(err error) error {
+	if err == nil {
+		return nil
+	}
+	var tag string
+	if s.severity != SeverityError {
+		tag = s.severity.String() + ": "
+	}
+	list, ok := err.(errors)
+	if !ok {
+		return checkers.Error(tagSeverity(tag, err.Error()))
+	}
+	errs := list.Errors()
+	tagged := make([]string, len(errs))
+	for i, e := range errs {
+		tagged[i] = tagSeverity(tag, e)
+	}
+	return checkers.Error(tagged...)
+}
+
+func tagSeverity(tag, str string) string {
+	_, str, _ = cutSeverity(str)
+	return tag + str
+}
+
+// GroupFiltered returns a Checker that runs checkers as a Group but only fails
+// if at least one error is at least as severe as min. If it fails, all errors are
+// returned, including less severe ones, so that they remain visible. If it does
+// not fail, the less severe errors are written to os.Stderr, one per line, and
+// Check returns nil.
+//
+// This allows warnings to be reported from go test without failing the build:
+//
+//	lint.GroupFiltered(lint.SeverityError,
+//		govet.Shadow,
+//		lint.WithSeverity(lint.SeverityWarning, golint.Check{}),
+//	)
+func GroupFiltered(min Severity, checkers ...Checker) Checker {
+	return GroupFilteredTo(os.Stderr, min, checkers...)
+}
+
+// GroupFilteredTo is like GroupFiltered but writes the errors that do not fail
+// the check to w.
+func GroupFilteredTo(w io.Writer, min Severity, checkers ...Checker) Checker {
+	return filteredGroup{min: min, group: Group(checkers), out: w}
+}
+
+type filteredGroup struct {
+	min   Severity
+	group Group
+	out   io.Writer
+}
+
+// Check runs the group and returns its errors if any is at least as severe as
+// g.min. Otherwise its errors are written to g.out.
+func (g filteredGroup) Check(pkgs ...string) error {
+	err := g.group.Check(pkgs...)
+	for _, d := range Diagnostics(err) {
+		// Lower values are more severe.
+		if d.Severity <= g.min {
+			return err
+		}
+	}
+	for _, msg := range prefixErrors("", err) {
+		fmt.Fprintln(g.out, msg)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+var positioned = checkFn(func(...string) error {
+	return checkers.Error("file.go:23: exported Foo should have comment")
+})
+
+func TestWithSeverity(t *testing.T) {
+	err := lint.WithSeverity(lint.SeverityWarning, positioned).Check("./...")
+	assert(t, err != nil && err.Error() == "warning: file.go:23: exported Foo should have comment", fmt.Sprintf("%v", err))
+
+	// Severity is replaced and error is not shown.
+	err = lint.WithSeverity(lint.SeverityError, lint.WithSeverity(lint.SeverityInfo, positioned)).Check("./...")
+	assert(t, err != nil && err.Error() == "file.go:23: exported Foo should have comment", fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.WithSeverity(lint.SeverityInfo, ungroupedError)}.Check("./...")
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: info: ungrouped: 1", fmt.Sprintf("%v", err))
+
+	err = lint.WithSeverity(lint.SeverityInfo, expectRecursive).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+}
+
+func TestGroupFiltered(t *testing.T) {
+	warning := lint.WithSeverity(lint.SeverityWarning, positioned)
+	info := lint.WithSeverity(lint.SeverityInfo, ungroupedError)
+
+	// Errors that do not fail the check are written out instead.
+	var buf bytes.Buffer
+	err := lint.GroupFilteredTo(&buf, lint.SeverityError, warning, info).Check("./...")
+	assert(t,
+		err == nil && buf.String() == "lint_test.checkFn: warning: file.go:23: exported Foo should have comment\n"+
+			"lint_test.checkFn: info: ungrouped: 1\n",
+		fmt.Sprint(err, buf.String()))
+
+	buf.Reset()
+	err = lint.GroupFilteredTo(&buf, lint.SeverityError, expectRecursive).Check("./...")
+	assert(t, err == nil && buf.Len() == 0, fmt.Sprint(err, buf.String()))
+
+	err = lint.GroupFilteredTo(&buf, lint.SeverityWarning, warning, info).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: warning: file.go:23: exported Foo should have comment\n"+
+			"lint_test.checkFn: info: ungrouped: 1",
+		fmt.Sprintf("%v", err))
+
+	// Untagged errors are always reported
+	err = lint.GroupFilteredTo(&buf, lint.SeverityError, info, twoErrors).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: info: ungrouped: 1\nlint_test.checkFn: err1\nlint_test.checkFn: err2",
+		fmt.Sprintf("%v", err))
+}
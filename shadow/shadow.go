@@ -0,0 +1,124 @@
+// Package shadow provides lint integration for finding local variables that
+// shadow imported packages and package-level declarations.
+package shadow
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports local variables and parameters in
+// .go files, including test files, that shadow an imported package or a
+// package-level declaration. Identifiers are resolved using go/types, so only
+// names that would otherwise refer to the package or declaration are reported
+// and locals that share a name with a package imported by another file are not.
+type Checker struct {
+	// Names holds the names to protect, which may be package names or the names
+	// of package-level declarations. If it is empty, the names of all imported
+	// packages are protected and package-level declarations are not.
+	Names []string
+}
+
+// Check reports each local variable in pkgs that shadows a protected name as
+//
+//	file.go:line:col: local variable "url" shadows imported package
+//	file.go:line:col: parameter "config" shadows package-level declaration
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each local variable in pkgs that
+// shadows a protected name.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports local variables that shadow protected names in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	names := map[string]bool{}
+	for _, name := range c.Names {
+		names[name] = true
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				params := parameters(f)
+				ast.Inspect(f, func(n ast.Node) bool {
+					id, ok := n.(*ast.Ident)
+					if !ok || id.Name == "_" {
+						return true
+					}
+					v, ok := info.Defs[id].(*types.Var)
+					if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+						return true
+					}
+					kind := "local variable"
+					if body, ok := params[id]; ok {
+						if !body {
+							return true
+						}
+						kind = "parameter"
+					}
+					_, shadowed := v.Parent().Parent().LookupParent(id.Name, token.NoPos)
+					switch {
+					case shadowed == nil:
+					case isPkgName(shadowed) && (len(names) == 0 || names[id.Name]):
+						diags = append(diags, s.Diagnostic(id.Pos(), "%s %q shadows imported package", kind, id.Name))
+					case shadowed.Parent() == v.Pkg().Scope() && names[id.Name]:
+						diags = append(diags, s.Diagnostic(id.Pos(), "%s %q shadows package-level declaration", kind, id.Name))
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+func isPkgName(obj types.Object) bool {
+	_, ok := obj.(*types.PkgName)
+	return ok
+}
+
+// parameters returns the names of the receivers, parameters and results of the
+// function types in f, mapped to whether the function has a body. Names in a
+// function type without a body, such as the type of a variable, are never in
+// scope and cannot shadow anything.
+func parameters(f *ast.File) map[*ast.Ident]bool {
+	params := map[*ast.Ident]bool{}
+	add := func(fields *ast.FieldList, body bool) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				params[name] = body
+			}
+		}
+	}
+	bodies := map[*ast.FuncType]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			add(n.Recv, n.Body != nil)
+			bodies[n.Type] = n.Body != nil
+		case *ast.FuncLit:
+			bodies[n.Type] = true
+		case *ast.FuncType:
+			add(n.Params, bodies[n])
+			add(n.Results, bodies[n])
+		}
+		return true
+	})
+	return params
+}
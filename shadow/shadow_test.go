@@ -0,0 +1,71 @@
+package shadow_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/shadow"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package shadowtest
+
+import (
+	"net/url"
+	"strings"
+)
+
+var config = "default"
+
+type T struct {
+	url string
+}
+
+func Parse(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		url := strings.TrimSpace(s)
+		_ = url
+	}
+	return u, err
+}
+
+func Join(strings []string) string {
+	config := ""
+	for _, s := range strings {
+		config += s
+	}
+	return config
+}
+
+func Closure() func(url string) {
+	return func(url string) {}
+}
+`
+
+func TestShadow(t *testing.T) {
+	testutil.Test(t, "shadowtest", []testutil.StaticCheckTest{
+		{
+			Checker: shadow.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:17:3: local variable "url" shadows imported package\n` +
+				`.*file\.go:23:11: parameter "strings" shadows imported package\n` +
+				`.*file\.go:32:14: parameter "url" shadows imported package$`),
+		},
+		{
+			Checker: shadow.Checker{Names: []string{"config", "strings"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:23:11: parameter "strings" shadows imported package\n` +
+				`.*file\.go:24:2: local variable "config" shadows package-level declaration$`),
+		},
+		{
+			Checker:  shadow.Checker{Names: []string{"fmt"}},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker:  shadow.Checker{},
+			Content:  []byte("package shadowtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
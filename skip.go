@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"fmt"
+	"path"
 	"regexp"
 
 	"github.com/surullabs/lint/checkers"
@@ -30,6 +32,25 @@ func (s StringSkipper) Skip(check string) bool {
 	return false
 }
 
+// patternSkipper is a StringSkipper created with patterns that must be valid,
+// such as by RegexpMatch and GlobMatch. err is the error for the first malformed
+// pattern.
+type patternSkipper struct {
+	StringSkipper
+	err error
+}
+
+// skipperError returns the error of the first skipper in skippers created with a
+// malformed pattern.
+func skipperError(skippers []Skipper) error {
+	for _, s := range skippers {
+		if p, ok := s.(patternSkipper); ok && p.err != nil {
+			return p.err
+		}
+	}
+	return nil
+}
+
 func skip(check string, skippers []Skipper) bool {
 	for _, s := range skippers {
 		if s.Skip(check) {
@@ -51,7 +72,13 @@ func skip(check string, skippers []Skipper) bool {
 //
 // Skippers are run in the order provided and a single
 // skipper returning true will result in that error being skipped.
+//
+// If a skipper returned by RegexpMatch or GlobMatch has a malformed pattern, Skip
+// returns the error for that pattern instead of filtering err.
 func Skip(err error, skippers ...Skipper) error {
+	if perr := skipperError(skippers); perr != nil {
+		return perr
+	}
 	switch serr := err.(type) {
 	case nil:
 		return nil
@@ -76,17 +103,90 @@ func Skip(err error, skippers ...Skipper) error {
 }
 
 // RegexpMatch returns a Skipper that skips all errors which match
-// any of the provided regular expression patterns. SkipRegexpMatch expects
-// all patterns to be valid regexps and panics otherwise.
+// any of the provided regular expression patterns. If a pattern is not a valid
+// regexp, Skip and the checkers returned by SkipChecker return an error such as
+//
+//	invalid pattern "(": error parsing regexp: missing closing ): `(`
 func RegexpMatch(regexps ...string) Skipper {
-	return StringSkipper{
-		Strings: regexps,
-		Matcher: func(errstr, pattern string) bool {
-			matched, err := regexp.MatchString(pattern, errstr)
-			if err != nil {
-				panic(err)
-			}
-			return matched
+	var perr error
+	for _, pattern := range regexps {
+		if _, err := regexp.Compile(pattern); err != nil {
+			perr = fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			break
+		}
+	}
+	return patternSkipper{
+		StringSkipper: StringSkipper{
+			Strings: regexps,
+			Matcher: func(errstr, pattern string) bool {
+				matched, err := regexp.MatchString(pattern, errstr)
+				return err == nil && matched
+			},
+		},
+		err: perr,
+	}
+}
+
+// GlobMatch returns a Skipper that skips all errors whose file path or message
+// matches any of the provided patterns. Patterns use path.Match semantics and are
+// matched against the file in the leading file.go:line token of the error, the base
+// name of that file and the remainder of the message. If a pattern is malformed,
+// Skip and the checkers returned by SkipChecker return an error wrapping
+// path.ErrBadPattern, such as
+//
+//	invalid pattern "[": syntax error in pattern
+func GlobMatch(patterns ...string) Skipper {
+	var perr error
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			perr = fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			break
+		}
+	}
+	return patternSkipper{
+		StringSkipper: StringSkipper{
+			Strings: patterns,
+			Matcher: func(errstr, pattern string) bool {
+				d := ParseDiagnostic(errstr)
+				candidates := []string{d.Message}
+				if d.File != "" {
+					candidates = append(candidates, d.File, path.Base(d.File))
+				}
+				for _, c := range candidates {
+					if matched, _ := path.Match(pattern, c); matched {
+						return true
+					}
+				}
+				return false
+			},
 		},
+		err: perr,
 	}
 }
+
+// SkipChecker returns a Checker that runs c and skips errors using skippers as
+// described in Skip. If every error is skipped, Check returns nil.
+func SkipChecker(c Checker, skippers ...Skipper) Checker {
+	return skipChecker{checker: c, skippers: skippers}
+}
+
+type skipChecker struct {
+	checker  Checker
+	skippers []Skipper
+}
+
+func (s skipChecker) unwrap() interface{} { return s.checker }
+
+// Name returns the name of the wrapped checker.
+func (s skipChecker) Name() string { return checkerName(s.checker) }
+
+// Check runs the wrapped checker and filters its errors.
+func (s skipChecker) Check(pkgs ...string) error {
+	return Skip(s.checker.Check(pkgs...), s.skippers...)
+}
+
+// CheckSource checks src using the wrapped checker as described in CheckSource
+// and filters its errors.
+func (s skipChecker) CheckSource(filename string, src []byte) error {
+	return Skip(CheckSource(s.checker, filename, src), s.skippers...)
+}
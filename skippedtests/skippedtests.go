@@ -0,0 +1,111 @@
+// Package skippedtests provides lint integration for finding tests that are
+// always skipped.
+package skippedtests
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports tests and benchmarks in _test.go
+// files that are skipped unconditionally, so that tests disabled while fixing
+// something are not forgotten. A skip is unconditional if it is a statement of
+// the test function itself rather than part of an if, switch or loop, so skips
+// guarded by testing.Short() or other conditions are not reported. Skips in
+// subtests are not checked.
+type Checker struct{}
+
+// Check reports each unconditionally skipped test in pkgs as
+//
+//	file_test.go:line:col: test TestFoo is unconditionally skipped
+//
+// Benchmarks are reported as "benchmark BenchmarkFoo".
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each unconditionally skipped test in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return checkers.AnalyzeDiagnostics(pkgs, func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, files := range [][]*ast.File{s.TestFiles, s.XTestFiles} {
+			for _, f := range files {
+				for _, decl := range f.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok || fn.Recv != nil || fn.Body == nil {
+						continue
+					}
+					kind := testKind(fn.Name.Name)
+					if kind == "" {
+						continue
+					}
+					if call := unconditionalSkip(fn); call != nil {
+						diags = append(diags, s.Diagnostic(call.Pos(), "%s %s is unconditionally skipped", kind, fn.Name.Name))
+					}
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// testKind returns "test" or "benchmark" if name is the name of a test or
+// benchmark function, and "" otherwise.
+func testKind(name string) string {
+	switch {
+	case strings.HasPrefix(name, "Test"):
+		return "test"
+	case strings.HasPrefix(name, "Benchmark"):
+		return "benchmark"
+	}
+	return ""
+}
+
+// unconditionalSkip returns the first call skipping fn that is a statement of
+// fn itself, or nil if there is none.
+func unconditionalSkip(fn *ast.FuncDecl) *ast.CallExpr {
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 || !isTesting(params[0].Type) {
+		return nil
+	}
+	t := params[0].Names[0].Name
+	for _, stmt := range fn.Body.List {
+		expr, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		call, ok := expr.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == t {
+			switch sel.Sel.Name {
+			case "Skip", "Skipf", "SkipNow":
+				return call
+			}
+		}
+	}
+	return nil
+}
+
+// isTesting reports whether typ is *testing.T or *testing.B, assuming that the
+// testing package is imported as testing.
+func isTesting(typ ast.Expr) bool {
+	star, ok := typ.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "testing" && (sel.Sel.Name == "T" || sel.Sel.Name == "B")
+}
@@ -0,0 +1,71 @@
+package skippedtests_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/skippedtests"
+	"github.com/surullabs/lint/testutil"
+)
+
+const test = `package skippedteststest
+
+import "testing"
+
+func TestSkipped(t *testing.T) {
+	t.Skip("broken")
+}
+
+func TestSkippedLater(tt *testing.T) {
+	tt.Log("starting")
+	tt.Skipf("broken: %d", 1)
+}
+
+func TestShort(t *testing.T) {
+	if testing.Short() {
+		t.Skip("slow")
+	}
+}
+
+func TestSubtest(t *testing.T) {
+	t.Run("a", func(t *testing.T) { t.Skip() })
+}
+
+func BenchmarkSkipped(b *testing.B) {
+	b.SkipNow()
+}
+
+func helper(t *testing.T) {
+	t.Skip()
+}
+`
+
+const xtest = `package skippedteststest_test
+
+import "testing"
+
+func TestExternal(t *testing.T) {
+	t.SkipNow()
+}
+`
+
+func TestSkippedTests(t *testing.T) {
+	checkers.Unload("skippedteststest")
+	tmp, err := testutil.NewGopath("skippedteststest", []testutil.SourceFile{
+		{Content: []byte("package skippedteststest\n\nfunc init() { t := 1; _ = t }\n"), Dest: filepath.Join("skippedteststest", "file.go")},
+		{Content: []byte(test), Dest: filepath.Join("skippedteststest", "file_test.go")},
+		{Content: []byte(xtest), Dest: filepath.Join("skippedteststest", "x_test.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	validate := testutil.MatchesRegexp(`file_test\.go:6:2: test TestSkipped is unconditionally skipped\n` +
+		`.*file_test\.go:11:2: test TestSkippedLater is unconditionally skipped\n` +
+		`.*file_test\.go:25:2: benchmark BenchmarkSkipped is unconditionally skipped\n` +
+		`.*x_test\.go:6:2: test TestExternal is unconditionally skipped$`)
+	if err := validate(skippedtests.Checker{}.Check("skippedteststest")); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,146 @@
+// Package sortdecls provides lint integration for checking that const and var
+// blocks are sorted.
+package sortdecls
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports parenthesized const and var
+// blocks, in .go files including test files, whose entries are not sorted by
+// name. Entries separated by a blank line form separate groups and are only
+// compared within their group. An entry declaring several names is sorted by its
+// first name.
+//
+// The order of a const block matters if it uses iota or repeats an earlier value
+// implicitly, so such blocks are never reported.
+//
+// A zero Checker checks both const and var blocks.
+type Checker struct {
+	// Const checks const blocks. If neither Const nor Var is set both are
+	// checked.
+	Const bool
+	// Var checks var blocks.
+	Var bool
+}
+
+// Check reports the first entry out of order in each group of a block in pkgs as
+//
+//	file.go:line:col: const block is not sorted; "Alpha" should come before "Beta"
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each unsorted group of a block in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports unsorted blocks in files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource reports unsorted blocks in src, the contents of filename, as
+// described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.sourceDiagnostics(filename, src), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.sourceDiagnostics(file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of file if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) sourceDiagnostics(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	var diags []checkers.Diagnostic
+	ast.Inspect(f, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || !decl.Lparen.IsValid() || !c.enabled(decl.Tok) {
+			return true
+		}
+		if decl.Tok == token.CONST && ordered(decl) {
+			return true
+		}
+		// Only the first entry out of order in each group is reported.
+		var prev *ast.ValueSpec
+		reported := false
+		for _, spec := range decl.Specs {
+			vs := spec.(*ast.ValueSpec)
+			switch {
+			case prev == nil:
+			case startLine(fset, vs) > fset.Position(prev.End()).Line+1:
+				reported = false
+			case !reported && vs.Names[0].Name < prev.Names[0].Name:
+				reported = true
+				p := fset.Position(vs.Pos())
+				diags = append(diags, checkers.Diagnostic{
+					File:    p.Filename,
+					Line:    p.Line,
+					Col:     p.Column,
+					Message: fmt.Sprintf("%s block is not sorted; %q should come before %q", decl.Tok, vs.Names[0].Name, prev.Names[0].Name),
+				})
+			}
+			prev = vs
+		}
+		return true
+	})
+	return diags
+}
+
+func (c Checker) enabled(tok token.Token) bool {
+	all := !c.Const && !c.Var
+	return (tok == token.CONST && (all || c.Const)) || (tok == token.VAR && (all || c.Var))
+}
+
+// ordered reports whether the order of the entries of a const block matters
+// because it uses iota or repeats values implicitly.
+func ordered(decl *ast.GenDecl) bool {
+	for _, spec := range decl.Specs {
+		vs := spec.(*ast.ValueSpec)
+		if len(vs.Values) == 0 {
+			return true
+		}
+		for _, v := range vs.Values {
+			iota := false
+			ast.Inspect(v, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+					iota = true
+				}
+				return !iota
+			})
+			if iota {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// startLine returns the first line of spec, including its doc comment.
+func startLine(fset *token.FileSet, spec *ast.ValueSpec) int {
+	if spec.Doc != nil {
+		return fset.Position(spec.Doc.Pos()).Line
+	}
+	return fset.Position(spec.Pos()).Line
+}
@@ -0,0 +1,77 @@
+package sortdecls_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/sortdecls"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package sortdeclstest
+
+const (
+	Beta  = "b"
+	Alpha = "a"
+	Gamma = "c"
+
+	// Delta is in a group of its own.
+	Delta = "d"
+	Charlie, Echo = "c", "e"
+	Bravo = "b"
+)
+
+const (
+	Second = iota
+	First
+)
+
+const (
+	Z = "z"
+	Y
+)
+
+var (
+	b = 2
+	a = 1
+)
+
+var single = 1
+
+func f() {
+	var (
+		y int
+		x int
+	)
+	_, _ = x, y
+}
+`
+
+func TestSortDecls(t *testing.T) {
+	testutil.Test(t, "sortdeclstest", []testutil.StaticCheckTest{
+		{
+			Checker: sortdecls.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5:2: const block is not sorted; "Alpha" should come before "Beta"\n` +
+				`.*file\.go:10:2: const block is not sorted; "Charlie" should come before "Delta"\n` +
+				`.*file\.go:26:2: var block is not sorted; "a" should come before "b"\n` +
+				`.*file\.go:34:3: var block is not sorted; "x" should come before "y"$`),
+		},
+		{
+			Checker: sortdecls.Checker{Const: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:5:2: const block is not sorted; "Alpha" should come before "Beta"\n` +
+				`.*file\.go:10:2: const block is not sorted; "Charlie" should come before "Delta"$`),
+		},
+		{
+			Checker: sortdecls.Checker{Var: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:26:2: var block is not sorted; "a" should come before "b"\n` +
+				`.*file\.go:34:3: var block is not sorted; "x" should come before "y"$`),
+		},
+		{
+			Checker:  sortdecls.Checker{Const: true},
+			Content:  []byte("package sortdeclstest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
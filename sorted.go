@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"sort"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Sorted returns a Checker that runs c and sorts its errors by the leading
+//
+//	file.go:line:col
+//
+// token, first by file path and then numerically by line and column. The
+// checker prefix added by Group.Check is ignored when sorting. Errors without
+// a file position are placed at the end in their original order. Errors that do
+// not implement the errors interface described in Skip are returned unmodified.
+func Sorted(c Checker) Checker {
+	return sorted{checker: c}
+}
+
+type sorted struct {
+	checker Checker
+}
+
+func (s sorted) unwrap() interface{} { return s.checker }
+
+// Name returns the name of the wrapped checker.
+func (s sorted) Name() string { return checkerName(s.checker) }
+
+// Check runs the wrapped checker and sorts its errors.
+func (s sorted) Check(pkgs ...string) error {
+	err := s.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok {
+		return err
+	}
+	errs := list.Errors()
+	// Diagnostics skips summaries, so errors are parsed one by one to keep diags
+	// in step with errs.
+	diags := make([]Diagnostic, len(errs))
+	for i, e := range errs {
+		diags[i] = ParseDiagnostic(e)
+	}
+	sort.Stable(byPosition{errs: errs, diags: diags})
+	return checkers.Error(errs...)
+}
+
+type byPosition struct {
+	errs  []string
+	diags []Diagnostic
+}
+
+func (b byPosition) Len() int { return len(b.errs) }
+
+func (b byPosition) Swap(i, j int) {
+	b.errs[i], b.errs[j] = b.errs[j], b.errs[i]
+	b.diags[i], b.diags[j] = b.diags[j], b.diags[i]
+}
+
+func (b byPosition) Less(i, j int) bool {
+	di, dj := b.diags[i], b.diags[j]
+	switch {
+	case di.File == "" || dj.File == "":
+		return dj.File == "" && di.File != ""
+	case di.File != dj.File:
+		return di.File < dj.File
+	case di.Line != dj.Line:
+		return di.Line < dj.Line
+	default:
+		return di.Col < dj.Col
+	}
+}
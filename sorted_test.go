@@ -0,0 +1,53 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestSorted(t *testing.T) {
+	unsorted := checkFn(func(...string) error {
+		return checkers.Error(
+			"no position",
+			"b.go:10: ten",
+			"b.go:2:5: two col five",
+			"a.go:3: three",
+			"also no position",
+			"b.go:2:1: two col one",
+		)
+	})
+	expected := []string{
+		"a.go:3: three",
+		"b.go:2:1: two col one",
+		"b.go:2:5: two col five",
+		"b.go:10: ten",
+		"no position",
+		"also no position",
+	}
+	err := lint.Sorted(unsorted).Check("./...")
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	// Sorting ignores the checker prefix.
+	first := otherFn(func(...string) error { return checkers.Error("b.go:1: b") })
+	second := checkFn(func(...string) error { return checkers.Error("a.go:1: a") })
+	err = lint.Sorted(lint.Group{first, second}).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: a.go:1: a\nlint_test.otherFn: b.go:1: b",
+		fmt.Sprintf("%v", err))
+
+	// Summaries are kept after the errors they count.
+	err = lint.Sorted(lint.Summarized(lint.Group{first, second})).Check("./...")
+	assert(t,
+		err != nil && err.Error() == "lint_test.checkFn: a.go:1: a\nlint_test.otherFn: b.go:1: b\n2 problems (1 lint_test.otherFn, 1 lint_test.checkFn)",
+		fmt.Sprintf("%v", err))
+
+	err = lint.Sorted(ungroupedError).Check("./...")
+	assert(t, err != nil && err.Error() == "ungrouped: 1", fmt.Sprintf("%v", err))
+
+	err = lint.Sorted(expectRecursive).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,42 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// SourceChecker is implemented by checkers that can check the source of a single
+// file without reading it from disk, such as gofmt.Native or linelength.Checker.
+type SourceChecker interface {
+	CheckSource(filename string, src []byte) error
+}
+
+// CheckSource checks src, the contents of filename, using c. filename is only
+// used to parse src and report positions, so it need not exist. This lets
+// editors check unsaved buffers without touching the file system.
+//
+// Checkers that do not implement SourceChecker need a whole package, and
+// CheckSource returns an error such as
+//
+//	golint.Native requires package context
+//
+// rather than running them on part of a package.
+func CheckSource(c Checker, filename string, src []byte) error {
+	c, done := untimed(c)
+	defer done()
+	if sc, ok := c.(SourceChecker); ok {
+		return sc.CheckSource(filename, src)
+	}
+	return fmt.Errorf("%s requires package context", checkerName(c))
+}
+
+// CheckSource applies each of the checkers in g to src as described in the
+// function CheckSource. Errors are prefixed as they are by Check.
+func (g Group) CheckSource(filename string, src []byte) error {
+	var errs []string
+	for _, checker := range g.enabled() {
+		errs = append(errs, prefixErrors(prefixName(checker), CheckSource(checker, filename, src))...)
+	}
+	return checkers.Error(errs...)
+}
@@ -0,0 +1,36 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/gocyclo"
+	"github.com/surullabs/lint/gofmt"
+	"github.com/surullabs/lint/golint"
+	"github.com/surullabs/lint/linelength"
+)
+
+func TestCheckSource(t *testing.T) {
+	src := []byte("package a\n\nvar  a = 1\n")
+	g := lint.Group{
+		gofmt.Native{},
+		lint.SkipChecker(linelength.Checker{Max: 9}, lint.RegexpMatch(`nosuchline`)),
+		lint.WithSeverity(lint.SeverityWarning, gocyclo.Checker{Max: 1}),
+		golint.Native{},
+	}
+	err := lint.CheckSource(g, "unsaved.go", src)
+	expected := []string{
+		"gofmt.Native: unsaved.go: not gofmt-ed",
+		"linelength.Checker: unsaved.go:3: line too long (10 > 9)",
+		"golint.Native: golint.Native requires package context",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+
+	err = lint.CheckSource(gofmt.Native{}, "unsaved.go", []byte("package a\n\nvar a = 1\n"))
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.CheckSource(lint.WithSeverity(lint.SeverityWarning, gofmt.Native{}), "unsaved.go", []byte("package"))
+	assert(t, err != nil && strings.HasPrefix(err.Error(), "warning: unsaved.go:1:8: expected 'IDENT'"), fmt.Sprintf("%v", err))
+}
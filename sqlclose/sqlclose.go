@@ -0,0 +1,217 @@
+// Package sqlclose provides lint integration for finding database/sql rows and
+// statements that are never closed.
+package sqlclose
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultConstructors holds the methods of package database/sql whose results
+// are always tracked by Checker.
+var DefaultConstructors = []string{
+	"database/sql.Conn.PrepareContext",
+	"database/sql.Conn.QueryContext",
+	"database/sql.DB.Prepare",
+	"database/sql.DB.PrepareContext",
+	"database/sql.DB.Query",
+	"database/sql.DB.QueryContext",
+	"database/sql.Stmt.Query",
+	"database/sql.Stmt.QueryContext",
+	"database/sql.Tx.Prepare",
+	"database/sql.Tx.PrepareContext",
+	"database/sql.Tx.Query",
+	"database/sql.Tx.QueryContext",
+}
+
+// Checker implements lint.Checker and reports *sql.Rows and *sql.Stmt values
+// opened in .go files that are never closed. A value is tracked if it is the
+// result of one of DefaultConstructors or Constructors assigned to a variable,
+// and is considered closed if Close is called on the variable anywhere in the
+// function assigning it, including in defer statements and function literals.
+// A value that is returned or assigned to another variable or field is assumed
+// to be closed by its new owner. Types are resolved using go/types, so only the
+// types of package database/sql are tracked.
+//
+// The check is flow-insensitive, so a value closed on only some paths is not
+// reported. Reports can be suppressed with a comment on the line of the call or
+// the line before it of the form
+//
+//	//nolint:sqlclose
+type Checker struct {
+	// Constructors holds other functions and methods returning a *sql.Rows or
+	// *sql.Stmt to track, such as those of a package wrapping database/sql,
+	// as the import path of their package and their name, optionally qualified
+	// by the receiver type, such as "example.com/db.Query" or
+	// "example.com/db.Store.Query".
+	Constructors []string
+}
+
+// Check reports each *sql.Rows or *sql.Stmt in pkgs that is never closed as
+//
+//	file.go:line:col: sql.Rows from Query is never Closed
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each *sql.Rows or *sql.Stmt in pkgs
+// that is never closed.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports *sql.Rows and *sql.Stmt values that are never closed in
+// the packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	constructors := map[string]bool{}
+	for _, name := range append(append([]string{}, DefaultConstructors...), c.Constructors...) {
+		constructors[name] = true
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(false)
+		var diags []checkers.Diagnostic
+		for _, f := range s.Files {
+			suppressed := checkers.NoLintLines(s.Fset, f, "sqlclose.Checker")
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					assign, ok := n.(*ast.AssignStmt)
+					if !ok || len(assign.Rhs) != 1 {
+						return true
+					}
+					call, ok := assign.Rhs[0].(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					callee := calledFunc(info, call)
+					if callee == nil || !constructors[funcName(callee)] {
+						return true
+					}
+					index, typ := sqlResult(callee)
+					if index < 0 || index >= len(assign.Lhs) {
+						return true
+					}
+					line := s.Fset.Position(call.Pos()).Line
+					if suppressed[line] || suppressed[line-1] {
+						return true
+					}
+					id, ok := assign.Lhs[index].(*ast.Ident)
+					if !ok {
+						// Assigned to a field or element, which owns it.
+						return true
+					}
+					if id.Name != "_" {
+						if v := info.ObjectOf(id); v == nil || closed(info, fn.Body, v) {
+							return true
+						}
+					}
+					diags = append(diags, s.Diagnostic(call.Pos(), "sql.%s from %s is never Closed", typ, callee.Name()))
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// calledFunc returns the function or method called by call, or nil.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	return fn
+}
+
+// funcName returns the name of fn as used in Constructors.
+func funcName(fn *types.Func) string {
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		named, ok := types.Unalias(typ).(*types.Named)
+		if !ok {
+			return ""
+		}
+		name = named.Obj().Name() + "." + name
+	}
+	return fn.Pkg().Path() + "." + name
+}
+
+// sqlResult returns the index of the first result of fn that is a *sql.Rows or
+// *sql.Stmt and the name of its type, or -1 if there is none.
+func sqlResult(fn *types.Func) (int, string) {
+	results := fn.Type().(*types.Signature).Results()
+	for i := 0; i < results.Len(); i++ {
+		ptr, ok := results.At(i).Type().(*types.Pointer)
+		if !ok {
+			continue
+		}
+		named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "database/sql" {
+			continue
+		}
+		if name := named.Obj().Name(); name == "Rows" || name == "Stmt" {
+			return i, name
+		}
+	}
+	return -1, ""
+}
+
+// closed reports whether v is closed or handed over to another owner in body.
+func closed(info *types.Info, body *ast.BlockStmt, v types.Object) bool {
+	refers := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && info.Uses[id] == v
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Close" {
+				found = refers(sel.X)
+			}
+		case *ast.ReturnStmt:
+			for _, r := range n.Results {
+				found = found || refers(r)
+			}
+		case *ast.AssignStmt:
+			for _, r := range n.Rhs {
+				found = found || refers(r)
+			}
+		case *ast.KeyValueExpr:
+			found = refers(n.Value)
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				found = found || refers(elt)
+			}
+		case *ast.SendStmt:
+			found = refers(n.Value)
+		}
+		return true
+	})
+	return found
+}
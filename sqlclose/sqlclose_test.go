@@ -0,0 +1,72 @@
+package sqlclose_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/sqlclose"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package sqlclosetest
+
+import "database/sql"
+
+type Store struct{ db *sql.DB }
+
+func (s Store) Query(q string) (*sql.Rows, error) { return s.db.Query(q) }
+
+type holder struct{ stmt *sql.Stmt }
+
+func Leaked(db *sql.DB) {
+	rows, _ := db.Query("SELECT 1")
+	for rows.Next() {
+	}
+}
+
+func Deferred(db *sql.DB) error {
+	rows, err := db.Query("SELECT 1")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return nil
+}
+
+func Prepared(tx *sql.Tx, h *holder) {
+	stmt, _ := tx.Prepare("SELECT 1")
+	_, _ = stmt.Exec()
+	h.stmt, _ = tx.Prepare("SELECT 2")
+	_, _ = tx.Query("SELECT 3") //nolint:sqlclose
+	_ = db().QueryRow("SELECT 4")
+}
+
+func Wrapped(s Store) {
+	rows, _ := s.Query("SELECT 1")
+	rows.Next()
+}
+
+func db() *sql.DB { return nil }
+`
+
+func TestSQLClose(t *testing.T) {
+	testutil.Test(t, "sqlclosetest", []testutil.StaticCheckTest{
+		{
+			Checker: sqlclose.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:12:13: sql\.Rows from Query is never Closed\n` +
+				`[^\n]*file\.go:27:13: sql\.Stmt from Prepare is never Closed$`),
+		},
+		{
+			Checker: sqlclose.Checker{Constructors: []string{"sqlclosetest.Store.Query"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:12:13: sql\.Rows from Query is never Closed\n` +
+				`[^\n]*file\.go:27:13: sql\.Stmt from Prepare is never Closed\n` +
+				`[^\n]*file\.go:35:13: sql\.Rows from Query is never Closed$`),
+		},
+		{
+			Checker:  sqlclose.Checker{},
+			Content:  []byte("package sqlclosetest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
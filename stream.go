@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// streamer is implemented by checkers that can report messages as they are
+// found rather than all at once when they finish. govet.Checker, golint.Native
+// and gocyclo.Checker report the messages for each package once it has been
+// checked, and groups report those of each of their checkers.
+type streamer interface {
+	CheckStream(pkgs []string, out func(msg string)) error
+}
+
+// CheckStream runs c for pkgs, calling out with each error message as soon as
+// it is found, and returns the same error as c.Check(pkgs...). Checkers that
+// cannot report messages as they go, such as those running an external tool,
+// have their messages passed to out when they finish.
+//
+// Messages from checkers run concurrently, such as by ParallelGroup, are passed
+// to out one at a time in the order they are found, while the returned error
+// keeps the order described in ParallelGroup.
+func CheckStream(c Checker, pkgs []string, out func(msg string)) error {
+	c, done := untimed(c)
+	defer done()
+	if s, ok := c.(streamer); ok {
+		return s.CheckStream(pkgs, out)
+	}
+	err := c.Check(pkgs...)
+	for _, msg := range prefixErrors("", err) {
+		out(msg)
+	}
+	return err
+}
+
+// streamErrors runs checker with CheckStream, calling out with each message
+// prefixed as in Group.Check, and returns the prefixed messages.
+func streamErrors(checker Checker, pkgs []string, out func(msg string)) []string {
+	prefix := prefixName(checker)
+	if prefix != "" {
+		prefix += ": "
+	}
+	return prefixErrors(prefixName(checker), CheckStream(checker, pkgs, func(msg string) {
+		out(prefix + msg)
+	}))
+}
+
+// CheckStream is like Check but calls out with the messages of each checker as
+// they are found.
+func (g Group) CheckStream(pkgs []string, out func(msg string)) error {
+	var errs []string
+	for _, checker := range g.enabled() {
+		errs = append(errs, streamErrors(checker, pkgs, out)...)
+	}
+	return checkers.Error(errs...)
+}
+
+// CheckStream is like Check but calls out with the messages of each checker as
+// they are found. Calls to out are serialized.
+func (g parallelGroup) CheckStream(pkgs []string, out func(msg string)) error {
+	var mu sync.Mutex
+	serial := func(msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		out(msg)
+	}
+	results := make([][]string, len(g.checkers))
+	sem := make(chan struct{}, g.limit)
+	var wg sync.WaitGroup
+	for i, checker := range g.checkers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = streamErrors(safeChecker{checker}, pkgs, serial)
+		}(i, checker)
+	}
+	wg.Wait()
+	var errs []string
+	for _, r := range results {
+		errs = append(errs, r...)
+	}
+	return checkers.Error(errs...)
+}
+
+// CheckStream is like Check but calls out with the messages of each checker as
+// they are found.
+func (f failFast) CheckStream(pkgs []string, out func(msg string)) error {
+	for _, checker := range f {
+		if errs := streamErrors(checker, pkgs, out); len(errs) > 0 {
+			return checkers.Error(errs...)
+		}
+	}
+	return nil
+}
+
+// safeChecker converts panics in the wrapped checker into errors, as safeCheck
+// does, while keeping its name and prefix.
+type safeChecker struct {
+	checker Checker
+}
+
+func (s safeChecker) unwrap() interface{} { return s.checker }
+
+// Name returns the name of the wrapped checker.
+func (s safeChecker) Name() string { return checkerName(s.checker) }
+
+// Check runs the wrapped checker, converting a panic into an error.
+func (s safeChecker) Check(pkgs ...string) error { return safeCheck(s.checker, pkgs) }
+
+// CheckStream runs the wrapped checker with CheckStream, converting a panic into
+// an error.
+func (s safeChecker) CheckStream(pkgs []string, out func(msg string)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			out(err.Error())
+		}
+	}()
+	return CheckStream(s.checker, pkgs, out)
+}
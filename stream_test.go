@@ -0,0 +1,71 @@
+package lint_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+type streamCheck struct {
+	events *[]string
+	msgs   []string
+}
+
+func (s streamCheck) Check(pkgs ...string) error { return checkers.Error(s.msgs...) }
+
+func (s streamCheck) CheckStream(pkgs []string, out func(msg string)) error {
+	for _, msg := range s.msgs {
+		out(msg)
+		*s.events = append(*s.events, "sent "+msg)
+	}
+	return checkers.Error(s.msgs...)
+}
+
+func TestCheckStream(t *testing.T) {
+	var events []string
+	out := func(msg string) { events = append(events, msg) }
+	g := lint.Group{
+		streamCheck{events: &events, msgs: []string{"a.go:1: one", "a.go:2: two"}},
+		checkFn(func(...string) error { return checkers.Error("b.go:3: three") }),
+	}
+	err := lint.CheckStream(g, nil, out)
+	assert(t, err != nil && err.Error() == g.Check().Error(), fmt.Sprintf("%v", err))
+	expected := []string{
+		"lint_test.streamCheck: a.go:1: one", "sent a.go:1: one",
+		"lint_test.streamCheck: a.go:2: two", "sent a.go:2: two",
+		"lint_test.checkFn: b.go:3: three",
+	}
+	assert(t, fmt.Sprint(events) == fmt.Sprint(expected), strings.Join(events, "\n"))
+
+	// Plain checkers report their messages when they finish.
+	events = nil
+	err = lint.CheckStream(checkFn(func(...string) error { return fmt.Errorf("bad") }), nil, out)
+	assert(t, err != nil && fmt.Sprint(events) == "[bad]", fmt.Sprintf("%v %v", err, events))
+
+	events = nil
+	assert(t, lint.CheckStream(lint.Group{}, nil, out) == nil && len(events) == 0, fmt.Sprint(events))
+}
+
+func TestCheckStreamParallel(t *testing.T) {
+	var msgs []string
+	p := lint.ParallelGroup(
+		checkFn(func(...string) error { return checkers.Error("one", "two") }),
+		checkFn(func(...string) error { panic("boom") }),
+		namedCheck{},
+	)
+	err := lint.CheckStream(p, nil, func(msg string) { msgs = append(msgs, msg) })
+	expected := []string{
+		"lint_test.checkFn: one",
+		"lint_test.checkFn: two",
+		"lint_test.checkFn: panic: boom",
+		"govet.Checker: file.go:23: shadowed",
+	}
+	assert(t, err != nil && err.Error() == strings.Join(expected, "\n"), fmt.Sprintf("%v", err))
+	sort.Strings(msgs)
+	sort.Strings(expected)
+	assert(t, fmt.Sprint(msgs) == fmt.Sprint(expected), strings.Join(msgs, "\n"))
+}
@@ -0,0 +1,167 @@
+// Package structtags provides lint integration for checking struct field tags.
+package structtags
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultKnownKeys are the tag keys allowed by Checker if KnownKeys is empty.
+var DefaultKnownKeys = []string{"json", "xml", "yaml", "db"}
+
+// Checker implements lint.Checker and reports struct field tags, in .go files
+// including test files, that are not in the conventional format understood by
+// reflect.StructTag.Get, that use a key not in KnownKeys or that repeat a key.
+type Checker struct {
+	// KnownKeys holds the allowed tag keys. If it is empty, DefaultKnownKeys is
+	// used.
+	KnownKeys []string
+}
+
+// Check reports each problem with a struct tag in pkgs as one of
+//
+//	file.go:line:col: malformed struct tag
+//	file.go:line:col: unknown tag key "jsno"
+//	file.go:line:col: duplicate tag key "json"
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each problem with a struct tag in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports problems with struct tags in files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// CheckSource reports problems with struct tags in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	return checkers.DiagnosticsError(c.sourceDiagnostics(filename, src), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, file := range files {
+		diags = append(diags, c.sourceDiagnostics(file, nil)...)
+	}
+	return diags
+}
+
+// sourceDiagnostics checks src, or the contents of file if src is nil. src is
+// passed to parser.ParseFile.
+func (c Checker) sourceDiagnostics(file string, src interface{}) []checkers.Diagnostic {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, 0)
+	if err != nil {
+		return checkers.ErrorDiagnostics(err)
+	}
+	known := c.KnownKeys
+	if len(known) == 0 {
+		known = DefaultKnownKeys
+	}
+	allowed := make(map[string]bool, len(known))
+	for _, key := range known {
+		allowed[key] = true
+	}
+	var diags []checkers.Diagnostic
+	report := func(pos token.Pos, format string, args ...interface{}) {
+		p := fset.Position(pos)
+		diags = append(diags, checkers.Diagnostic{
+			File:    p.Filename,
+			Line:    p.Line,
+			Col:     p.Column,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				report(field.Tag.Pos(), "malformed struct tag")
+				continue
+			}
+			keys, ok := parseTag(tag)
+			if !ok {
+				report(field.Tag.Pos(), "malformed struct tag")
+				continue
+			}
+			seen := map[string]bool{}
+			for _, key := range keys {
+				switch {
+				case seen[key]:
+					report(field.Tag.Pos(), "duplicate tag key %q", key)
+				case !allowed[key]:
+					report(field.Tag.Pos(), "unknown tag key %q", key)
+				}
+				seen[key] = true
+			}
+		}
+		return true
+	})
+	return diags
+}
+
+// parseTag returns the keys of tag, which must be a sequence of key:"value"
+// pairs separated by spaces as described in reflect.StructTag. It returns false
+// if tag is not in that format.
+func parseTag(tag string) ([]string, bool) {
+	var keys []string
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return keys, true
+		}
+		// A key is a non-empty run of characters other than spaces, quotes,
+		// colons and control characters.
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, false
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		// The value is a quoted string ending at the first unescaped quote.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, false
+		}
+		if _, err := strconv.Unquote(tag[:i+1]); err != nil {
+			return nil, false
+		}
+		keys = append(keys, key)
+		tag = tag[i+1:]
+		if tag != "" && tag[0] != ' ' {
+			return nil, false
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package structtags_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/structtags"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = "package structtagstest\n" +
+	"\n" +
+	"type T struct {\n" +
+	"\tA int `json:\"a,omitempty\" db:\"a\"`\n" +
+	"\tB int \"json:\\\"b\\\"\"\n" +
+	"\tC int `jsno:\"c\"`\n" +
+	"\tD int `json:\"d\" json:\"e\"`\n" +
+	"\tE int `json:d`\n" +
+	"\tF int `json:\"f\"yaml:\"f\"`\n" +
+	"\tG struct {\n" +
+	"\t\tH int `mapstructure:\"h\"`\n" +
+	"\t}\n" +
+	"\tI int `json:\"i\\\"x\"`\n" +
+	"}\n"
+
+func TestStructTags(t *testing.T) {
+	testutil.Test(t, "structtagstest", []testutil.StaticCheckTest{
+		{
+			Checker:  structtags.Checker{},
+			Content:  []byte("package structtagstest\n\ntype T struct {\n\tA int `json:\"a\" xml:\"a,attr\" yaml:\"a\"`\n\tB int\n}\n"),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: structtags.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:6:8: unknown tag key "jsno"\n` +
+				`.*file\.go:7:8: duplicate tag key "json"\n` +
+				`.*file\.go:8:8: malformed struct tag\n` +
+				`.*file\.go:9:8: malformed struct tag\n` +
+				`.*file\.go:11:9: unknown tag key "mapstructure"$`),
+		},
+		{
+			Checker: structtags.Checker{KnownKeys: []string{"json", "mapstructure"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:4:8: unknown tag key "db"\n` +
+				`.*file\.go:6:8: unknown tag key "jsno"\n` +
+				`.*file\.go:7:8: duplicate tag key "json"\n` +
+				`.*file\.go:8:8: malformed struct tag\n` +
+				`.*file\.go:9:8: malformed struct tag$`),
+		},
+		{
+			Checker:  structtags.Checker{},
+			Content:  []byte("package structtagstest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
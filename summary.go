@@ -0,0 +1,86 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// summaryRE matches the summary line added by Summarized.
+var summaryRE = regexp.MustCompile(`^\d+ problems?(?: \(\d+ [^\s,()]+(?:, \d+ [^\s,()]+)*\))?$`)
+
+// Summarized returns a Checker that runs c and, if it finds any problems,
+// appends a summary of the number of problems found by each checker, such as
+//
+//	12 problems (8 govet.Check, 4 golint.Check)
+//
+// Checkers are taken from the prefixes added by Group.Check and listed from the
+// most problems to the fewest. Errors without a prefix are counted in the total
+// only. Errors that do not implement the errors interface described in Skip are
+// returned unmodified.
+//
+// The summary can be recognized using IsSummary. Diagnostics skips it, so
+// MarshalJSON and the exporters that use Diagnostics do not report it.
+func Summarized(c Checker) Checker {
+	return summarized{checker: c}
+}
+
+// IsSummary reports whether msg is a summary added by Summarized.
+func IsSummary(msg string) bool {
+	return summaryRE.MatchString(msg)
+}
+
+type summarized struct {
+	checker Checker
+}
+
+func (s summarized) unwrap() interface{} { return s.checker }
+
+// Name returns the name of the wrapped checker.
+func (s summarized) Name() string { return checkerName(s.checker) }
+
+// Check runs the wrapped checker and appends a summary of its errors.
+func (s summarized) Check(pkgs ...string) error {
+	err := s.checker.Check(pkgs...)
+	list, ok := err.(errors)
+	if !ok {
+		return err
+	}
+	errs := list.Errors()
+	if len(errs) == 0 {
+		return err
+	}
+	return checkers.Error(append(append([]string{}, errs...), summarize(errs))...)
+}
+
+// summarize returns the summary line for errs.
+func summarize(errs []string) string {
+	var names []string
+	counts := map[string]int{}
+	for _, e := range errs {
+		name := ParseDiagnostic(e).Checker
+		if name == "" {
+			continue
+		}
+		if counts[name] == 0 {
+			names = append(names, name)
+		}
+		counts[name]++
+	}
+	sort.SliceStable(names, func(i, j int) bool { return counts[names[i]] > counts[names[j]] })
+	summary := fmt.Sprintf("%d problems", len(errs))
+	if len(errs) == 1 {
+		summary = "1 problem"
+	}
+	if len(names) == 0 {
+		return summary
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%d %s", counts[name], name)
+	}
+	return summary + " (" + strings.Join(parts, ", ") + ")"
+}
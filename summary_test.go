@@ -0,0 +1,33 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestSummarized(t *testing.T) {
+	one := checkFn(func(...string) error { return checkers.Error("file.go:1: a") })
+	two := otherFn(func(...string) error { return checkers.Error("file.go:2: b", "file.go:3: c") })
+	err := lint.Summarized(lint.Group{one, two, expectRecursive}).Check("./...")
+	errs := strings.Split(fmt.Sprint(err), "\n")
+	assert(t, len(errs) == 4 && errs[3] == "3 problems (2 lint_test.otherFn, 1 lint_test.checkFn)", fmt.Sprintf("%v", err))
+	assert(t, lint.IsSummary(errs[3]) && !lint.IsSummary(errs[0]), "summary not recognized")
+	assert(t, len(lint.Diagnostics(err)) == 3, fmt.Sprintf("%v", lint.Diagnostics(err)))
+
+	err = lint.Summarized(one).Check("./...")
+	assert(t, err != nil && err.Error() == "file.go:1: a\n1 problem", fmt.Sprintf("%v", err))
+
+	err = lint.Summarized(lint.Group{expectRecursive}).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Summarized(ungroupedError).Check("./...")
+	assert(t, err != nil && err.Error() == "ungrouped: 1", fmt.Sprintf("%v", err))
+
+	// Summaries are not prefixed by an enclosing Group.
+	err = lint.Group{lint.Summarized(lint.Group{one})}.Check("./...")
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: file.go:1: a\n1 problem (1 lint_test.checkFn)", fmt.Sprintf("%v", err))
+}
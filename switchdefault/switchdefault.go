@@ -0,0 +1,183 @@
+// Package switchdefault provides lint integration for finding switch
+// statements that do not handle every value.
+package switchdefault
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and checks switch statements in .go files,
+// including test files. A value switch is a switch statement with a tag, as in
+// switch x, and a type switch is a switch on x.(type). Each check is enabled by
+// its field and a zero Checker runs the Exhaustive check, which only reports
+// switches that miss a declared value.
+type Checker struct {
+	// TypeSwitch reports type switches without a default case.
+	TypeSwitch bool
+	// ValueSwitch reports value switches without a default case.
+	ValueSwitch bool
+	// Exhaustive reports value switches without a default case on a named type
+	// with constants, such as an enum declared using iota, that do not have a
+	// case for each constant of the type declared in its package. Types are
+	// resolved using go/types and constants with the same value need only one
+	// case.
+	Exhaustive bool
+}
+
+// enabled returns c, or c with Exhaustive set if no check is enabled.
+func (c Checker) enabled() Checker {
+	if !c.TypeSwitch && !c.ValueSwitch && !c.Exhaustive {
+		c.Exhaustive = true
+	}
+	return c
+}
+
+// Check reports each switch statement in pkgs that does not handle every value
+// as one of
+//
+//	file.go:line:col: switch statement has no default case
+//	file.go:line:col: switch on Color is missing cases Blue, Green
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each switch statement in pkgs that
+// does not handle every value.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports switch statements that do not handle every value in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	c = c.enabled()
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				var found []checkers.Diagnostic
+				if c.TypeSwitch || c.ValueSwitch {
+					found = c.missingDefaults(s, f)
+				}
+				if c.Exhaustive {
+					found = append(found, missingConstants(s, f)...)
+				}
+				sort.SliceStable(found, func(i, j int) bool {
+					if found[i].Line != found[j].Line {
+						return found[i].Line < found[j].Line
+					}
+					return found[i].Col < found[j].Col
+				})
+				diags = append(diags, found...)
+			}
+		}
+		return diags
+	})
+}
+
+// missingDefaults returns a diagnostic for each switch statement in f without a
+// default case, using only the syntax of f.
+func (c Checker) missingDefaults(s *checkers.Source, f *ast.File) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	ast.Inspect(f, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.TypeSwitchStmt:
+			if c.TypeSwitch {
+				body = n.Body
+			}
+		case *ast.SwitchStmt:
+			if c.ValueSwitch && n.Tag != nil {
+				body = n.Body
+			}
+		}
+		if body != nil && !hasDefault(body) {
+			diags = append(diags, s.Diagnostic(n.Pos(), "switch statement has no default case"))
+		}
+		return true
+	})
+	return diags
+}
+
+// missingConstants returns a diagnostic for each value switch in f without a
+// default case that does not have a case for each constant of its type.
+func missingConstants(s *checkers.Source, f *ast.File) []checkers.Diagnostic {
+	pkg, info, _ := s.TypeCheck(true)
+	qualifier := func(p *types.Package) string {
+		if pkg != nil && p.Path() == pkg.Path() {
+			return ""
+		}
+		return p.Name()
+	}
+	var diags []checkers.Diagnostic
+	ast.Inspect(f, func(n ast.Node) bool {
+		sw, ok := n.(*ast.SwitchStmt)
+		if !ok || sw.Tag == nil || hasDefault(sw.Body) {
+			return true
+		}
+		named, ok := types.Unalias(info.TypeOf(sw.Tag)).(*types.Named)
+		if !ok {
+			return true
+		}
+		covered := map[string]bool{}
+		for _, stmt := range sw.Body.List {
+			for _, e := range stmt.(*ast.CaseClause).List {
+				if v := info.Types[e].Value; v != nil {
+					covered[v.ExactString()] = true
+				}
+			}
+		}
+		var missing []string
+		for _, obj := range constants(named) {
+			if key := obj.Val().ExactString(); !covered[key] {
+				covered[key] = true
+				missing = append(missing, obj.Name())
+			}
+		}
+		if len(missing) > 0 {
+			diags = append(diags, s.Diagnostic(sw.Pos(), "switch on %s is missing cases %s",
+				types.TypeString(named, qualifier), strings.Join(missing, ", ")))
+		}
+		return true
+	})
+	return diags
+}
+
+// constants returns the package-level constants of type named declared in its
+// package, in the order they are declared.
+func constants(named *types.Named) []*types.Const {
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return nil
+	}
+	var consts []*types.Const
+	scope := obj.Pkg().Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) && c.Val().Kind() != constant.Unknown {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	return consts
+}
+
+// hasDefault reports whether body, the body of a switch statement, has a
+// default case.
+func hasDefault(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		if clause, ok := stmt.(*ast.CaseClause); ok && clause.List == nil {
+			return true
+		}
+	}
+	return false
+}
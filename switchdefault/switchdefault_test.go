@@ -0,0 +1,77 @@
+package switchdefault_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/switchdefault"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package switchdefaulttest
+
+import "time"
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+	Crimson = Red
+)
+
+func Describe(c Color, v interface{}, d time.Month) string {
+	switch c {
+	case Red:
+		return "red"
+	}
+	switch c {
+	case Red, Green, Blue:
+	default:
+	}
+	switch v.(type) {
+	case int:
+	}
+	switch {
+	case c > Red:
+	}
+	switch d {
+	case time.January:
+	}
+	return ""
+}
+`
+
+func TestSwitchDefault(t *testing.T) {
+	testutil.Test(t, "switchdefaulttest", []testutil.StaticCheckTest{
+		{
+			Checker: switchdefault.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:2: switch on Color is missing cases Green, Blue\n` +
+				`[^\n]*file\.go:29:2: switch on time\.Month is missing cases February, March, April, May, June, July, August, September, October, November, December$`),
+		},
+		{
+			Checker:  switchdefault.Checker{TypeSwitch: true},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:23:2: switch statement has no default case$`),
+		},
+		{
+			Checker: switchdefault.Checker{ValueSwitch: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:2: switch statement has no default case\n` +
+				`[^\n]*file\.go:29:2: switch statement has no default case$`),
+		},
+		{
+			Checker: switchdefault.Checker{TypeSwitch: true, Exhaustive: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:2: switch on Color is missing cases Green, Blue\n` +
+				`[^\n]*file\.go:23:2: switch statement has no default case\n` +
+				`[^\n]*file\.go:29:2: switch on time\.Month is missing cases February, March, April, May, June, July, August, September, October, November, December$`),
+		},
+		{
+			Checker:  switchdefault.Checker{TypeSwitch: true},
+			Content:  []byte("package switchdefaulttest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
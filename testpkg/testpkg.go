@@ -0,0 +1,95 @@
+// Package testpkg provides lint integration for finding tests that could be in
+// an external _test package.
+package testpkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports _test.go files that are in the
+// package they test rather than in an external _test package, when they only
+// use the exported API of the package. Black-box tests in a _test package check
+// the API the way other packages use it.
+//
+// A test file is considered to test internals, and is not reported, if it uses
+// an unexported identifier of the package declared outside the file, or
+// declares methods. Files in packages without non-test files are never
+// reported.
+type Checker struct {
+	// RequireExternal reports every test file in the package it tests, whether
+	// or not it uses unexported identifiers.
+	RequireExternal bool
+}
+
+// Check reports each test file in pkgs that could be in an external test
+// package as
+//
+//	file_test.go:1: test file uses internal package foo; prefer foo_test for public API tests
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each test file in pkgs that could
+// be in an external test package.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports test files that could be in an external test package in
+// the packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		if len(s.Files) == 0 || len(s.TestFiles) == 0 {
+			return nil
+		}
+		var info *types.Info
+		var pkg *types.Package
+		if !c.RequireExternal {
+			pkg, info, _ = s.TypeCheck(true)
+		}
+		var diags []checkers.Diagnostic
+		for _, f := range s.TestFiles {
+			if !c.RequireExternal && testsInternals(pkg, info, f) {
+				continue
+			}
+			p := s.Fset.Position(f.Package)
+			msg := fmt.Sprintf("test file uses internal package %s; prefer %s_test for public API tests", f.Name.Name, f.Name.Name)
+			diags = append(diags, checkers.Diagnostic{File: p.Filename, Line: p.Line, Message: msg})
+		}
+		return diags
+	})
+}
+
+// testsInternals reports whether f, a test file of pkg, declares methods or uses
+// unexported identifiers of pkg declared outside f.
+func testsInternals(pkg *types.Package, info *types.Info, f *ast.File) bool {
+	if pkg == nil {
+		return true
+	}
+	internal := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		if internal {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			internal = n.Recv != nil
+		case *ast.Ident:
+			obj := info.Uses[n]
+			if obj == nil || obj.Pkg() != pkg || obj.Exported() {
+				return true
+			}
+			internal = obj.Pos() < f.Pos() || obj.Pos() > f.End()
+		}
+		return true
+	})
+	return internal
+}
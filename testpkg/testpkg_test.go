@@ -0,0 +1,86 @@
+package testpkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testpkg"
+	"github.com/surullabs/lint/testutil"
+)
+
+const src = `package testpkgtest
+
+type T struct{ n int }
+
+func New() *T { return &T{} }
+
+func (t *T) Len() int { return t.n }
+
+func helper() int { return 1 }
+`
+
+const public = `package testpkgtest
+
+import "testing"
+
+func TestLen(t *testing.T) {
+	if n := New().Len(); n != 0 {
+		t.Fatal(n)
+	}
+}
+
+func local() int { return 2 }
+`
+
+const internal = `package testpkgtest
+
+import "testing"
+
+func TestHelper(t *testing.T) {
+	if helper() != 1 || (&T{n: 2}).Len() != 2 {
+		t.Fatal()
+	}
+}
+`
+
+const method = `package testpkgtest
+
+func (t *T) reset() { t.n = 0 }
+`
+
+const external = `package testpkgtest_test
+
+import "testing"
+
+func TestExternal(t *testing.T) {}
+`
+
+func TestTestPkg(t *testing.T) {
+	checkers.Unload("testpkgtest")
+	tmp, err := testutil.NewGopath("testpkgtest", []testutil.SourceFile{
+		{Content: []byte(src), Dest: filepath.Join("testpkgtest", "file.go")},
+		{Content: []byte(public), Dest: filepath.Join("testpkgtest", "public_test.go")},
+		{Content: []byte(internal), Dest: filepath.Join("testpkgtest", "internal_test.go")},
+		{Content: []byte(method), Dest: filepath.Join("testpkgtest", "method_test.go")},
+		{Content: []byte(external), Dest: filepath.Join("testpkgtest", "x_test.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	tests := []struct {
+		checker  testpkg.Checker
+		validate func(error) error
+	}{
+		{testpkg.Checker{}, testutil.MatchesRegexp(`^[^\n]*public_test\.go:1: test file uses internal package testpkgtest; prefer testpkgtest_test for public API tests$`)},
+		{testpkg.Checker{RequireExternal: true}, testutil.MatchesRegexp(`^[^\n]*internal_test\.go:1: test file uses internal package testpkgtest; prefer testpkgtest_test for public API tests\n` +
+			`[^\n]*method_test\.go:1: .*\n` +
+			`[^\n]*public_test\.go:1: .*$`)},
+	}
+	for i, test := range tests {
+		if err := test.validate(test.checker.Check("testpkgtest")); err != nil {
+			t.Errorf("%d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package testutil
+
+import (
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// SourceFile is a file written to a temporary GOPATH by NewGopath.
+type SourceFile struct {
+	// Src is a file to copy instead of using Content.
+	Src string
+	// Content is the content of the file.
+	Content []byte
+	// Dest is the path of the file below the src directory of the GOPATH.
+	Dest string
+}
+
+// Gopath is a temporary GOPATH created by NewGopath.
+type Gopath struct {
+	// Path is the root of the GOPATH.
+	Path string
+
+	build string
+	env   map[string]*string
+}
+
+// gopathEnv holds the environment variables set by NewGopath.
+var gopathEnv = []string{"GOPATH", "GO111MODULE"}
+
+// NewGopath writes files to a new temporary directory whose name starts with
+// prefix and puts it at the front of the GOPATH used by go/build and the go
+// command. GO111MODULE is set to off so that the go command finds the packages
+// in it even when tests are run in module mode. Reset restores the environment
+// and removes the directory.
+func NewGopath(prefix string, files []SourceFile) (*Gopath, error) {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gopath{Path: dir, build: build.Default.GOPATH, env: map[string]*string{}}
+	for _, f := range files {
+		if err := writeSourceFile(dir, f); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+	for _, name := range gopathEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			g.env[name] = &value
+		} else {
+			g.env[name] = nil
+		}
+	}
+	gopath := dir + string(os.PathListSeparator) + g.build
+	build.Default.GOPATH = gopath
+	os.Setenv("GOPATH", gopath)
+	os.Setenv("GO111MODULE", "off")
+	return g, nil
+}
+
+func writeSourceFile(dir string, f SourceFile) error {
+	data := f.Content
+	if f.Src != "" {
+		var err error
+		if data, err = ioutil.ReadFile(f.Src); err != nil {
+			return err
+		}
+	}
+	dest := filepath.Join(dir, "src", f.Dest)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dest, data, 0644)
+}
+
+// Reset restores the GOPATH and environment in use before NewGopath and removes
+// the temporary GOPATH.
+func (g *Gopath) Reset() {
+	build.Default.GOPATH = g.build
+	for name, value := range g.env {
+		if value == nil {
+			os.Unsetenv(name)
+		} else {
+			os.Setenv(name, *value)
+		}
+	}
+	os.RemoveAll(g.Path)
+}
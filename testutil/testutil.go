@@ -12,7 +12,6 @@ import (
 
 	"reflect"
 
-	"github.com/sridharv/fakegopath"
 	"github.com/surullabs/lint"
 	"github.com/surullabs/lint/checkers"
 )
@@ -32,7 +31,7 @@ type StaticCheckTest struct {
 // Test runs the test for pkg.
 func (s StaticCheckTest) Test(pkg string) error {
 	checkers.Unload(pkg)
-	tmp, err := fakegopath.NewTemporaryWithFiles(pkg, []fakegopath.SourceFile{
+	tmp, err := NewGopath(pkg, []SourceFile{
 		{Src: s.File, Content: s.Content, Dest: filepath.Join(pkg, "file.go")},
 	})
 	if err != nil {
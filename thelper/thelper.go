@@ -0,0 +1,200 @@
+// Package thelper provides lint integration for finding test helpers that do
+// not call t.Helper.
+package thelper
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports functions in _test.go files that
+// take a *testing.T, *testing.B, *testing.F or testing.TB parameter but do not
+// call its Helper method as their first statement. Without it, failures are
+// reported at the line of the helper rather than at the line of its caller.
+// Test, benchmark and fuzz functions themselves are not reported.
+//
+// Parameter types are resolved using go/types, so a parameter of a type named T
+// from another package is not considered. Reports can be suppressed with a
+// comment on the line of the function or the line before it of the form
+//
+//	//nolint:thelper
+type Checker struct {
+	// Allow holds path.Match patterns for the names of functions that need not
+	// call Helper, such as "setup*". Methods match both their name and their
+	// name qualified by the receiver type, as in "suite.check".
+	Allow []string
+}
+
+// Check reports each test helper in pkgs that does not call Helper as
+//
+//	file.go:line:col: test helper checkFoo should call t.Helper()
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each test helper in pkgs that does
+// not call Helper.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports test helpers that do not call Helper in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	for _, pattern := range c.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				suppressed := checkers.NoLintLines(s.Fset, f, "thelper.Checker")
+				for _, decl := range f.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok || fn.Body == nil || isTestFunc(fn) || c.allowed(fn) {
+						continue
+					}
+					param := testingParam(info, fn)
+					if param == "" || callsHelper(fn.Body, param) {
+						continue
+					}
+					if line := s.Fset.Position(fn.Pos()).Line; suppressed[line] || suppressed[line-1] {
+						continue
+					}
+					diags = append(diags, s.Diagnostic(fn.Name.Pos(), "test helper %s should call %s.Helper()", fn.Name.Name, param))
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// allowed reports whether fn need not call Helper.
+func (c Checker) allowed(fn *ast.FuncDecl) bool {
+	names := []string{fn.Name.Name}
+	if recv := recvName(fn); recv != "" {
+		names = append(names, recv+"."+fn.Name.Name)
+	}
+	for _, pattern := range c.Allow {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTestFunc reports whether fn is a test, benchmark or fuzz function, named as
+// go test expects them to be.
+func isTestFunc(fn *ast.FuncDecl) bool {
+	if fn.Recv != nil {
+		return false
+	}
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz"} {
+		name := fn.Name.Name
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if len(name) == len(prefix) {
+			return true
+		}
+		if r, _ := utf8.DecodeRuneInString(name[len(prefix):]); !unicode.IsLower(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// testingParam returns the name of the first parameter of fn of a testing type,
+// or "" if there is none or it is unnamed.
+func testingParam(info *types.Info, fn *ast.FuncDecl) string {
+	for _, field := range fn.Type.Params.List {
+		if !isTesting(info.TypeOf(field.Type)) {
+			continue
+		}
+		if len(field.Names) == 0 || field.Names[0].Name == "_" {
+			return ""
+		}
+		return field.Names[0].Name
+	}
+	return ""
+}
+
+// isTesting reports whether typ is *testing.T, *testing.B, *testing.F or
+// testing.TB.
+func isTesting(typ types.Type) bool {
+	ptr, isPtr := typ.(*types.Pointer)
+	if isPtr {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "testing" {
+		return false
+	}
+	switch named.Obj().Name() {
+	case "T", "B", "F":
+		return isPtr
+	case "TB":
+		return !isPtr
+	}
+	return false
+}
+
+// callsHelper reports whether the first statement of body is a call to the
+// Helper method of param.
+func callsHelper(body *ast.BlockStmt, param string) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+	stmt, ok := body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := stmt.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Helper" {
+		return false
+	}
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	return ok && id.Name == param
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
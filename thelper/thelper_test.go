@@ -0,0 +1,92 @@
+package thelper_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/thelper"
+)
+
+const lib = `package thelpertest
+
+import "testing"
+
+func Check(t *testing.T) {}
+`
+
+const internal = `package thelpertest
+
+import "testing"
+
+func checkFoo(t *testing.T, foo int) {
+	if foo != 1 {
+		t.Fatal(foo)
+	}
+}
+
+func checkBar(tb testing.TB) {
+	tb.Helper()
+}
+
+func setupDB(b *testing.B) {
+	b.Log("setup")
+	b.Helper()
+}
+
+func ignored(_ *testing.T) {}
+
+func TestFoo(t *testing.T) {
+	checkFoo(t, 1)
+}
+
+func BenchmarkBar(b *testing.B) {}
+
+func Testify(t *testing.T) {}
+
+//nolint:thelper
+func quiet(t *testing.T) {}
+`
+
+const external = `package thelpertest_test
+
+import "testing"
+
+type suite struct{}
+
+func (suite) check(t *testing.T) {}
+
+func FuzzParse(f *testing.F) {}
+
+func seed(f *testing.F) {
+	f.Add(1)
+}
+`
+
+func TestTHelper(t *testing.T) {
+	checkers.Unload("thelpertest")
+	tmp, err := testutil.NewGopath("thelpertest", []testutil.SourceFile{
+		{Content: []byte(lib), Dest: filepath.Join("thelpertest", "lib.go")},
+		{Content: []byte(internal), Dest: filepath.Join("thelpertest", "lib_test.go")},
+		{Content: []byte(external), Dest: filepath.Join("thelpertest", "ext_test.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Reset()
+	if err := testutil.MatchesRegexp(`^[^\n]*lib_test\.go:5:6: test helper checkFoo should call t\.Helper\(\)\n` +
+		`[^\n]*lib_test\.go:15:6: test helper setupDB should call b\.Helper\(\)\n` +
+		`[^\n]*lib_test\.go:28:6: test helper Testify should call t\.Helper\(\)\n` +
+		`[^\n]*ext_test\.go:7:14: test helper check should call t\.Helper\(\)\n` +
+		`[^\n]*ext_test\.go:11:6: test helper seed should call f\.Helper\(\)$`)(thelper.Checker{}.Check("thelpertest")); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.MatchesRegexp(`^[^\n]*lib_test\.go:5:6: test helper checkFoo should call t\.Helper\(\)$`)(
+		thelper.Checker{Allow: []string{"setup*", "Testify", "suite.check", "seed"}}.Check("thelpertest")); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.Contains("syntax error in pattern")(thelper.Checker{Allow: []string{"["}}.Check("thelpertest")); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,115 @@
+// Package timecheck provides lint integration for finding durations computed
+// from time.Now that have simpler forms.
+package timecheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports subtractions involving time.Now in
+// .go files, including test files, that are clearer written with time.Since or
+// time.Until. Calls are resolved using go/types, so only calls to Sub on a
+// time.Time are reported and functions or methods of other packages named Now
+// are not. Each rewrite is enabled by its field and a zero Checker reports
+// both.
+type Checker struct {
+	// Since reports time.Now().Sub(t), which is time.Since(t).
+	Since bool
+	// Until reports t.Sub(time.Now()), which is time.Until(t).
+	Until bool
+}
+
+// all returns c with every rewrite enabled if none is.
+func (c Checker) all() Checker {
+	if !c.Since && !c.Until {
+		return Checker{Since: true, Until: true}
+	}
+	return c
+}
+
+// Check reports each subtraction in pkgs with a simpler form as
+//
+//	file.go:line:col: use time.Since(t) instead of time.Now().Sub(t)
+//	file.go:line:col: use time.Until(t) instead of t.Sub(time.Now())
+//
+// with t replaced by the expression used.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each subtraction in pkgs with a
+// simpler form.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports subtractions with a simpler form in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	c = c.all()
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok || len(call.Args) != 1 {
+						return true
+					}
+					sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+					if !ok || !isTimeSub(info, sel) {
+						return true
+					}
+					switch {
+					case c.Since && isNow(info, sel.X):
+						t := types.ExprString(call.Args[0])
+						diags = append(diags, s.Diagnostic(call.Pos(), "use time.Since(%s) instead of time.Now().Sub(%s)", t, t))
+					case c.Until && isNow(info, call.Args[0]):
+						t := types.ExprString(sel.X)
+						diags = append(diags, s.Diagnostic(call.Pos(), "use time.Until(%s) instead of %s.Sub(time.Now())", t, t))
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// isTimeSub reports whether sel selects the Sub method of time.Time.
+func isTimeSub(info *types.Info, sel *ast.SelectorExpr) bool {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	fn := selection.Obj()
+	return fn.Name() == "Sub" && fn.Pkg() != nil && fn.Pkg().Path() == "time" && isTime(selection.Recv())
+}
+
+// isTime reports whether typ is time.Time.
+func isTime(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// isNow reports whether e is a call to time.Now.
+func isNow(info *types.Info, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	return ok && fn.Name() == "Now" && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Type().(*types.Signature).Recv() == nil
+}
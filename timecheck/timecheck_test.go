@@ -0,0 +1,61 @@
+package timecheck_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/timecheck"
+)
+
+const src = `package timechecktest
+
+import "time"
+
+type clock struct{}
+
+func (clock) Now() time.Time { return time.Time{} }
+
+type span struct{}
+
+func (span) Sub(t time.Time) time.Duration { return 0 }
+
+func Elapsed(start, deadline time.Time) []time.Duration {
+	var c clock
+	return []time.Duration{
+		time.Now().Sub(start),
+		deadline.Sub(time.Now()),
+		c.Now().Sub(start),
+		span{}.Sub(time.Now()),
+		time.Since(start),
+		(time.Now()).Sub(start.Add(time.Second)),
+	}
+}
+`
+
+func TestTimeCheck(t *testing.T) {
+	testutil.Test(t, "timechecktest", []testutil.StaticCheckTest{
+		{
+			Checker: timecheck.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:16:3: use time\.Since\(start\) instead of time\.Now\(\)\.Sub\(start\)\n` +
+				`[^\n]*file\.go:17:3: use time\.Until\(deadline\) instead of deadline\.Sub\(time\.Now\(\)\)\n` +
+				`[^\n]*file\.go:21:3: use time\.Since\(start\.Add\(time\.Second\)\) instead of time\.Now\(\)\.Sub\(start\.Add\(time\.Second\)\)$`),
+		},
+		{
+			Checker: timecheck.Checker{Since: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:16:3: use time\.Since\(start\) instead of time\.Now\(\)\.Sub\(start\)\n` +
+				`[^\n]*file\.go:21:3: use time\.Since\(start\.Add\(time\.Second\)\) instead of time\.Now\(\)\.Sub\(start\.Add\(time\.Second\)\)$`),
+		},
+		{
+			Checker:  timecheck.Checker{Until: true},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:17:3: use time\.Until\(deadline\) instead of deadline\.Sub\(time\.Now\(\)\)$`),
+		},
+		{
+			Checker:  timecheck.Checker{Since: true},
+			Content:  []byte("package timechecktest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Timeout returns a Checker that runs c and gives up if it does not finish
+// within d. A timed out check returns an error implementing the errors interface
+// described in Skip with a single entry
+//
+//	timed out after 30s
+//
+// The timed out check is left to finish in the background and its result is
+// discarded. A panic in c is returned as an error. If d <= 0, c is run without a
+// timeout.
+func Timeout(d time.Duration, c Checker) Checker {
+	return timeout{checker: c, d: d}
+}
+
+type timeout struct {
+	checker Checker
+	d       time.Duration
+}
+
+func (t timeout) unwrap() interface{} { return t.checker }
+
+// Name returns the name of the wrapped checker.
+func (t timeout) Name() string { return checkerName(t.checker) }
+
+// Check runs the wrapped checker with a timeout.
+func (t timeout) Check(pkgs ...string) error {
+	if t.d <= 0 {
+		return t.checker.Check(pkgs...)
+	}
+	// Buffered so that a check that finishes after the timeout does not block forever.
+	done := make(chan error, 1)
+	go func() { done <- safeCheck(t.checker, pkgs) }()
+	timer := time.NewTimer(t.d)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return checkers.Error(fmt.Sprintf("timed out after %v", t.d))
+	}
+}
@@ -0,0 +1,38 @@
+package lint_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint"
+)
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hangs := checkFn(func(...string) error {
+		<-release
+		return nil
+	})
+
+	err := lint.Timeout(10*time.Millisecond, hangs).Check("./...")
+	assert(t, err != nil && err.Error() == "timed out after 10ms", fmt.Sprintf("%v", err))
+
+	// Flattened by Group
+	err = lint.Group{lint.Timeout(10*time.Millisecond, hangs)}.Check("./...")
+	assert(t, err != nil && err.Error() == "lint_test.checkFn: timed out after 10ms", fmt.Sprintf("%v", err))
+
+	err = lint.Timeout(time.Second, twoErrors).Check("./...")
+	assert(t, err != nil && err.Error() == "err1\nerr2", fmt.Sprintf("%v", err))
+
+	err = lint.Timeout(time.Second, expectRecursive).Check("./...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Timeout(time.Second, panics).Check("./...")
+	assert(t, err != nil && err.Error() == "panic: checker exploded", fmt.Sprintf("%v", err))
+
+	// No timeout
+	err = lint.Timeout(0, twoErrors).Check("./...")
+	assert(t, err != nil && err.Error() == "err1\nerr2", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timings records the wall-clock time taken by the checkers run by a checker
+// returned by WithTiming. The zero value is ready to use and it is safe for
+// concurrent use.
+type Timings struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+func (t *Timings) add(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.durations == nil {
+		t.durations = map[string]time.Duration{}
+	}
+	t.durations[name] += d
+}
+
+// Durations returns a copy of the time taken by each checker, keyed by its name.
+func (t *Timings) Durations() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	durations := make(map[string]time.Duration, len(t.durations))
+	for name, d := range t.durations {
+		durations[name] = d
+	}
+	return durations
+}
+
+// WithTiming returns a Checker that runs c and records the time taken by the
+// checkers in it to t, such as to find which checkers of a large Group are
+// worth keeping. The errors returned are those of c.
+//
+// If c is a group returned by this package, such as a Group, ParallelGroup or
+// FailFast, each checker in it is timed on its own, including those in nested
+// groups, so times are accurate even when checkers run concurrently. Any other
+// checker is timed as a whole. Checkers are keyed by the name used to prefix
+// their errors, as described in Group.Check, and the times of checkers with the
+// same name are added up.
+//
+// Checkers in a group are timed however the group runs them, so a checker keeps
+// using CheckLoaded in a group returned by GroupWithLoader, and CheckDiagnostics,
+// CheckFiles, CheckSource, CheckStream, WithProgress and Fix use the methods of
+// the checkers they would without timing.
+func WithTiming(c Checker, t *Timings) Checker {
+	g, ok := c.(composite)
+	if !ok {
+		return timed{checker: c, timings: t}
+	}
+	children := g.children()
+	wrapped := make([]Checker, len(children))
+	for i, child := range children {
+		wrapped[i] = WithTiming(child, t)
+	}
+	return g.withChildren(wrapped)
+}
+
+// CheckTimed runs c for pkgs using WithTiming and returns the time taken by each
+// checker along with the errors of c.
+func CheckTimed(c Checker, pkgs ...string) (map[string]time.Duration, error) {
+	t := &Timings{}
+	err := WithTiming(c, t).Check(pkgs...)
+	return t.Durations(), err
+}
+
+type timed struct {
+	checker Checker
+	timings *Timings
+}
+
+func (c timed) unwrap() interface{} { return c.checker }
+
+// Name returns the name of the wrapped checker.
+func (c timed) Name() string { return checkerName(c.checker) }
+
+// Check runs the wrapped checker and records how long it took.
+func (c timed) Check(pkgs ...string) error {
+	start := time.Now()
+	defer func() { c.timings.add(checkerName(c.checker), time.Since(start)) }()
+	return c.checker.Check(pkgs...)
+}
+
+// untimed returns the checker wrapped by c if c is timed, and a function that
+// records the time since untimed was called for it. Groups use it so that the
+// optional interfaces of a timed checker are used when running it.
+func untimed(c Checker) (Checker, func()) {
+	t, ok := c.(timed)
+	if !ok {
+		return c, func() {}
+	}
+	start := time.Now()
+	return t.checker, func() { t.timings.add(checkerName(t.checker), time.Since(start)) }
+}
+
+// timedFixer is the Fixer of a timed checker.
+type timedFixer struct {
+	timed timed
+	fixer Fixer
+}
+
+// Fix runs the Fixer of the timed checker and records how long it took.
+func (f timedFixer) Fix(pkgs ...string) error {
+	_, done := untimed(f.timed)
+	defer done()
+	return f.fixer.Fix(pkgs...)
+}
+
+// WriteTimings writes durations, as returned by CheckTimed, to w as a table
+// with one checker per line, from the slowest to the fastest, such as
+//
+//	govet.Check   1.204s
+//	golint.Check  310.5ms
+//	gofmt.Check   12.01ms
+//
+// Checkers taking the same time are ordered by name.
+func WriteTimings(w io.Writer, durations map[string]time.Duration) error {
+	names := make([]string, 0, len(durations))
+	width := 0
+	for name := range durations {
+		names = append(names, name)
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if durations[names[i]] != durations[names[j]] {
+			return durations[names[i]] > durations[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%-*s  %v\n", width, name, durations[name].Round(10*time.Microsecond)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/gofmt"
+)
+
+type sleepCheck struct {
+	name  string
+	sleep time.Duration
+	err   error
+}
+
+func (s sleepCheck) Check(pkgs ...string) error {
+	time.Sleep(s.sleep)
+	return s.err
+}
+
+func (s sleepCheck) Name() string { return s.name }
+
+func TestCheckTimed(t *testing.T) {
+	slow := sleepCheck{name: "slow", sleep: 40 * time.Millisecond}
+	fast := sleepCheck{name: "fast", sleep: 10 * time.Millisecond, err: fmt.Errorf("bad")}
+
+	durations, err := lint.CheckTimed(lint.Group{slow, lint.Group{fast}}, "pkg")
+	assert(t, err != nil && err.Error() == "fast: bad", fmt.Sprintf("%v", err))
+	assert(t, len(durations) == 2 && durations["slow"] >= slow.sleep && durations["fast"] >= fast.sleep, fmt.Sprint(durations))
+
+	// Checkers running concurrently are timed on their own.
+	durations, err = lint.CheckTimed(lint.ParallelGroup(slow, fast), "pkg")
+	assert(t, err != nil && err.Error() == "fast: bad", fmt.Sprintf("%v", err))
+	assert(t, durations["slow"] >= slow.sleep && durations["fast"] >= fast.sleep && durations["fast"] < slow.sleep, fmt.Sprint(durations))
+
+	// Checkers with the same name are added up and other checkers are timed whole.
+	timings := &lint.Timings{}
+	err = lint.WithTiming(lint.FailFast(fast, slow), timings).Check("pkg")
+	assert(t, err != nil && len(timings.Durations()) == 1, fmt.Sprint(err, timings.Durations()))
+	err = lint.WithTiming(fast, timings).Check("pkg")
+	assert(t, err != nil && err.Error() == "bad", fmt.Sprintf("%v", err))
+	assert(t, timings.Durations()["fast"] >= 2*fast.sleep, fmt.Sprint(timings.Durations()))
+
+	durations, err = lint.CheckTimed(namedCheck{})
+	_, ok := durations["govet.Checker"]
+	assert(t, err != nil && ok && len(durations) == 1, fmt.Sprint(err, durations))
+}
+
+func TestWithTimingInterfaces(t *testing.T) {
+	// Timed checkers are still run with CheckLoaded by GroupWithLoader.
+	c := loadedCheck{mu: &sync.Mutex{}, loaders: map[*lint.Loader][]*checkers.Source{}}
+	timings := &lint.Timings{}
+	err := lint.WithTiming(lint.GroupWithLoader(lint.NewLoader("github.com/surullabs/lint/checkers"), c), timings).Check()
+	assert(t, err == nil && len(c.loaders) == 1, fmt.Sprintf("%v %d", err, len(c.loaders)))
+	_, ok := timings.Durations()["lint_test.loadedCheck"]
+	assert(t, ok, fmt.Sprint(timings.Durations()))
+
+	timings = &lint.Timings{}
+	err = lint.CheckSource(lint.WithTiming(lint.Group{gofmt.Native{}}, timings), "unsaved.go", []byte("package a\n\nvar  a = 1\n"))
+	assert(t, err != nil && err.Error() == "gofmt.Native: unsaved.go: not gofmt-ed", fmt.Sprintf("%v", err))
+	_, ok = timings.Durations()["gofmt.Native"]
+	assert(t, ok, fmt.Sprint(timings.Durations()))
+
+	var events []string
+	timings = &lint.Timings{}
+	g := lint.WithTiming(lint.Group{streamCheck{events: &events, msgs: []string{"a.go:1: one"}}}, timings)
+	err = lint.CheckStream(g, nil, func(msg string) { events = append(events, msg) })
+	assert(t, err != nil && fmt.Sprint(events) == "[lint_test.streamCheck: a.go:1: one sent a.go:1: one]", fmt.Sprint(err, events))
+	_, ok = timings.Durations()["lint_test.streamCheck"]
+	assert(t, ok && len(timings.Durations()) == 1, fmt.Sprint(timings.Durations()))
+}
+
+func TestWriteTimings(t *testing.T) {
+	var buf bytes.Buffer
+	err := lint.WriteTimings(&buf, map[string]time.Duration{
+		"b":            2 * time.Second,
+		"golint.Check": 1500*time.Millisecond + 123*time.Nanosecond,
+		"a":            2 * time.Second,
+	})
+	expected := "a             2s\n" +
+		"b             2s\n" +
+		"golint.Check  1.5s\n"
+	assert(t, err == nil && buf.String() == expected, buf.String())
+
+	buf.Reset()
+	err = lint.WriteTimings(&buf, nil)
+	assert(t, err == nil && buf.Len() == 0, buf.String())
+}
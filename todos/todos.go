@@ -0,0 +1,163 @@
+// Package todos provides lint integration for checking TODO comments.
+package todos
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultTags are the markers that are always checked.
+var DefaultTags = []string{"TODO", "FIXME"}
+
+// Checker implements lint.Checker and checks marker comments, such as TODO and
+// FIXME, in .go files, including test files. Only comments are checked, so
+// markers in string literals are ignored.
+type Checker struct {
+	// RequireOwner reports markers that do not name an owner, as in
+	// TODO(alice). If it is false Check reports nothing and Count can be used
+	// to track markers instead.
+	RequireOwner bool
+	// Tags are markers to check in addition to DefaultTags, such as HACK or
+	// XXX. Markers are case sensitive and must appear as whole words.
+	Tags []string
+}
+
+// Check reports each marker without an owner in pkgs as
+//
+//	file.go:line: TODO without owner
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each marker without an owner in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	if !c.RequireOwner {
+		return nil, nil
+	}
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.fileDiagnostics(files), nil
+}
+
+// CheckFiles reports markers without an owner in files as described in Check.
+func (c Checker) CheckFiles(files ...string) error {
+	if !c.RequireOwner {
+		return nil
+	}
+	return checkers.DiagnosticsError(c.fileDiagnostics(files), nil)
+}
+
+// Count returns the number of comments in pkgs containing each marker, whether
+// or not they name an owner. Markers that do not appear are not included.
+func (c Checker) Count(pkgs ...string) (map[string]int, error) {
+	files, err := checkers.AllGoFiles(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	re := c.regexp()
+	for _, file := range files {
+		found, err := findMarkers(re, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range found {
+			counts[m.tag]++
+		}
+	}
+	return counts, nil
+}
+
+// CheckSource reports markers without an owner in src, the contents of
+// filename, as described in Check.
+func (c Checker) CheckSource(filename string, src []byte) error {
+	if !c.RequireOwner {
+		return nil
+	}
+	return checkers.DiagnosticsError(unowned(filename, sourceMarkers(c.regexp(), filename, src)), nil)
+}
+
+func (c Checker) fileDiagnostics(files []string) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	re := c.regexp()
+	for _, file := range files {
+		found, err := findMarkers(re, file)
+		if err != nil {
+			diags = append(diags, checkers.ErrorDiagnostics(err)...)
+			continue
+		}
+		diags = append(diags, unowned(file, found)...)
+	}
+	return diags
+}
+
+// unowned returns a diagnostic for each marker in file without an owner.
+func unowned(file string, found []marker) []checkers.Diagnostic {
+	var diags []checkers.Diagnostic
+	for _, m := range found {
+		if m.owner == "" {
+			diags = append(diags, checkers.Diagnostic{File: file, Line: m.line, Message: fmt.Sprintf("%s without owner", m.tag)})
+		}
+	}
+	return diags
+}
+
+// regexp returns an expression matching any of the checked markers, followed by
+// an optional owner in parentheses.
+func (c Checker) regexp() *regexp.Regexp {
+	var tags []string
+	for _, tag := range append(append([]string{}, DefaultTags...), c.Tags...) {
+		tags = append(tags, regexp.QuoteMeta(tag))
+	}
+	return regexp.MustCompile(`\b(` + strings.Join(tags, "|") + `)\b(?:\(([^)]*)\))?`)
+}
+
+type marker struct {
+	tag   string
+	owner string
+	line  int
+}
+
+// findMarkers returns the markers matching re in the comments of file.
+func findMarkers(re *regexp.Regexp, file string) ([]marker, error) {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return sourceMarkers(re, file, src), nil
+}
+
+// sourceMarkers returns the markers matching re in the comments of src, the
+// contents of file.
+func sourceMarkers(re *regexp.Regexp, file string, src []byte) []marker {
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile(file, fset.Base(), len(src)), src, func(token.Position, string) {}, scanner.ScanComments)
+	var found []marker
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.COMMENT {
+			continue
+		}
+		line := fset.Position(pos).Line
+		for _, m := range re.FindAllStringSubmatchIndex(lit, -1) {
+			mk := marker{tag: lit[m[2]:m[3]], line: line + strings.Count(lit[:m[0]], "\n")}
+			if m[4] >= 0 {
+				mk.owner = strings.TrimSpace(lit[m[4]:m[5]])
+			}
+			found = append(found, mk)
+		}
+	}
+	return found
+}
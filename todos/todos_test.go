@@ -0,0 +1,63 @@
+package todos_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/todos"
+)
+
+const src = `package todostest
+
+// TODO(alice): owned.
+// TODO: no owner.
+var s = "// TODO: not a comment"
+
+/*
+FIXME()
+HACK here
+*/
+func f() {} // XXX
+`
+
+func TestTodos(t *testing.T) {
+	testutil.Test(t, "todostest", []testutil.StaticCheckTest{
+		{
+			Checker:  todos.Checker{},
+			Content:  []byte(src),
+			Validate: testutil.NoError,
+		},
+		{
+			Checker: todos.Checker{RequireOwner: true},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:4: TODO without owner\n` +
+				`.*file\.go:8: FIXME without owner$`),
+		},
+		{
+			Checker: todos.Checker{RequireOwner: true, Tags: []string{"HACK", "XXX"}},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`file\.go:4: TODO without owner\n` +
+				`.*file\.go:8: FIXME without owner\n` +
+				`.*file\.go:9: HACK without owner\n` +
+				`.*file\.go:11: XXX without owner$`),
+		},
+	})
+}
+
+func TestCount(t *testing.T) {
+	checkers.Unload("todostest")
+	tmp, err := testutil.NewGopath("todostest", []testutil.SourceFile{
+		{Content: []byte(src), Dest: filepath.Join("todostest", "file.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	counts, err := todos.Checker{Tags: []string{"HACK"}}.Count("todostest")
+	if err != nil || fmt.Sprint(counts) != "map[FIXME:1 HACK:1 TODO:2]" {
+		t.Errorf("unexpected counts: %v %v", counts, err)
+	}
+}
@@ -0,0 +1,42 @@
+package lint
+
+import "github.com/surullabs/lint/checkers"
+
+// Transform returns a Checker that runs c and replaces each of its errors with
+// the result of fn, such as to add a link to a rule or shorten file names. Errors
+// for which fn returns "" are dropped, and if every error is dropped Check
+// returns nil. An error that does not implement the errors interface described
+// in Skip is transformed as a single message. The returned error always
+// implements the errors interface.
+func Transform(c Checker, fn func(msg string) string) Checker {
+	return transform{checker: c, fn: fn}
+}
+
+type transform struct {
+	checker Checker
+	fn      func(msg string) string
+}
+
+func (t transform) unwrap() interface{} { return t.checker }
+
+// Name returns the name of the wrapped checker.
+func (t transform) Name() string { return checkerName(t.checker) }
+
+// Check runs the wrapped checker and transforms its errors.
+func (t transform) Check(pkgs ...string) error {
+	err := t.checker.Check(pkgs...)
+	if err == nil {
+		return nil
+	}
+	msgs := []string{err.Error()}
+	if list, ok := err.(errors); ok {
+		msgs = list.Errors()
+	}
+	var errs []string
+	for _, msg := range msgs {
+		if msg = t.fn(msg); msg != "" {
+			errs = append(errs, msg)
+		}
+	}
+	return checkers.Error(errs...)
+}
@@ -0,0 +1,41 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/checkers"
+)
+
+func TestTransform(t *testing.T) {
+	three := checkFn(func(pkgs ...string) error {
+		return checkers.Error("/src/a.go:1: one", "/src/b.go:2: two", "/src/c.go:3: three")
+	})
+	trim := func(msg string) string { return strings.TrimPrefix(msg, "/src/") }
+
+	err := lint.Transform(three, trim).Check()
+	assert(t, err != nil && err.Error() == "a.go:1: one\nb.go:2: two\nc.go:3: three", fmt.Sprintf("%v", err))
+	list, ok := err.(interface{ Errors() []string })
+	assert(t, ok && len(list.Errors()) == 3, fmt.Sprintf("%v", err))
+
+	dropTwo := func(msg string) string {
+		if strings.HasSuffix(msg, "two") {
+			return ""
+		}
+		return msg + " (see https://example.com/rules)"
+	}
+	err = lint.Transform(lint.Transform(three, trim), dropTwo).Check()
+	expected := "a.go:1: one (see https://example.com/rules)\nc.go:3: three (see https://example.com/rules)"
+	assert(t, err != nil && err.Error() == expected, fmt.Sprintf("%v", err))
+
+	err = lint.Transform(three, func(string) string { return "" }).Check()
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	err = lint.Transform(checkFn(func(pkgs ...string) error { return fmt.Errorf("/src/failed") }), trim).Check()
+	assert(t, err != nil && err.Error() == "failed", fmt.Sprintf("%v", err))
+
+	err = lint.Group{lint.Transform(three, trim)}.Check()
+	assert(t, err != nil && strings.HasPrefix(err.Error(), "lint_test.checkFn: a.go:1: one\n"), fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,188 @@
+// Package typednil provides lint integration for finding possibly nil pointers
+// returned as interfaces.
+package typednil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports pointer variables returned as an
+// interface result in .go files, including test files. An interface holding a
+// nil pointer is not nil, so a function declared to return error that returns a
+// nil *MyError returns an error that compares != nil.
+//
+// The check is conservative. A variable is reported unless it is declared in the
+// function and only ever assigned the address of a value or the result of new.
+// Parameters, fields and package-level variables are always reported, as are
+// variables assigned the result of other calls. Reports can be suppressed with a
+// comment on the line of the return statement or the line before it of the form
+//
+//	//nolint:typednil
+type Checker struct{}
+
+// Check reports each possibly nil pointer returned as an interface in pkgs as
+//
+//	file.go:line:col: returning possibly-nil *T as error interface
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each possibly nil pointer returned
+// as an interface in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports possibly nil pointers returned as interfaces in the
+// packages of l, which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		pkg, info, _ := s.TypeCheck(true)
+		qualifier := func(p *types.Package) string {
+			if pkg != nil && p.Path() == pkg.Path() {
+				return ""
+			}
+			return p.Name()
+		}
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				suppressed := checkers.NoLintLines(s.Fset, f, "typednil.Checker")
+				ast.Inspect(f, func(n ast.Node) bool {
+					var typ types.Type
+					var body *ast.BlockStmt
+					switch fn := n.(type) {
+					case *ast.FuncDecl:
+						if obj := info.Defs[fn.Name]; obj != nil {
+							typ = obj.Type()
+						}
+						body = fn.Body
+					case *ast.FuncLit:
+						typ, body = info.TypeOf(fn), fn.Body
+					default:
+						return true
+					}
+					sig, ok := typ.(*types.Signature)
+					if !ok || body == nil {
+						return true
+					}
+					nonNil := nonNilVars(info, body)
+					ast.Inspect(body, func(n ast.Node) bool {
+						switch n := n.(type) {
+						case *ast.FuncLit:
+							return false
+						case *ast.ReturnStmt:
+							if len(n.Results) != sig.Results().Len() {
+								return true
+							}
+							line := s.Fset.Position(n.Pos()).Line
+							if suppressed[line] || suppressed[line-1] {
+								return true
+							}
+							for i, r := range n.Results {
+								iface := sig.Results().At(i).Type()
+								if !types.IsInterface(iface) {
+									continue
+								}
+								obj := variable(info, r)
+								if obj == nil || nonNil[obj] {
+									continue
+								}
+								if _, ok := obj.Type().Underlying().(*types.Pointer); !ok {
+									continue
+								}
+								diags = append(diags, s.Diagnostic(r.Pos(), "returning possibly-nil %s as %s interface",
+									types.TypeString(obj.Type(), qualifier), types.TypeString(iface, qualifier)))
+							}
+						}
+						return true
+					})
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// variable returns the variable e refers to, or nil if e is not a variable.
+func variable(info *types.Info, e ast.Expr) *types.Var {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+	v, _ := info.Uses[id].(*types.Var)
+	return v
+}
+
+// nonNilVars returns the variables declared in body that are only assigned
+// values that cannot be nil.
+func nonNilVars(info *types.Info, body *ast.BlockStmt) map[*types.Var]bool {
+	fresh, nilable := map[*types.Var]bool{}, map[*types.Var]bool{}
+	assign := func(lhs []ast.Expr, rhs []ast.Expr) {
+		for i, l := range lhs {
+			id, ok := l.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			v, ok := info.ObjectOf(id).(*types.Var)
+			if !ok {
+				continue
+			}
+			if len(lhs) == len(rhs) && notNil(info, rhs[i]) {
+				fresh[v] = true
+			} else {
+				nilable[v] = true
+			}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			assign(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			assign(lhs, n.Values)
+		}
+		return true
+	})
+	vars := map[*types.Var]bool{}
+	for v := range fresh {
+		if !nilable[v] && body.Pos() <= v.Pos() && v.Pos() < body.End() {
+			vars[v] = true
+		}
+	}
+	return vars
+}
+
+// notNil reports whether e is the address of a value or a call to new.
+func notNil(info *types.Info, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.UnaryExpr:
+		return e.Op == token.AND
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		b, ok := info.Uses[id].(*types.Builtin)
+		return ok && b.Name() == "new"
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package typednil_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/typednil"
+)
+
+const src = `package typedniltest
+
+import (
+	"bytes"
+	"io"
+)
+
+type MyError struct{}
+
+func (*MyError) Error() string { return "" }
+
+var global *MyError
+
+func Param(e *MyError) error {
+	return e
+}
+
+func Declared() error {
+	var e *MyError
+	if global != nil {
+		e = global
+	}
+	return e
+}
+
+func Fresh() (int, error) {
+	e := &MyError{}
+	n := new(MyError)
+	if n != nil {
+		return 0, n
+	}
+	return 1, e
+}
+
+func Concrete() *MyError {
+	return global
+}
+
+func Literal() error {
+	return &MyError{}
+}
+
+func Nil() error {
+	return nil
+}
+
+func Reader(b *bytes.Buffer) io.Reader {
+	f := func() error {
+		return global
+	}
+	_ = f
+	//nolint:typednil
+	return b
+}
+
+func Suppressed() error {
+	return global //nolint
+}
+`
+
+func TestTypedNil(t *testing.T) {
+	testutil.Test(t, "typedniltest", []testutil.StaticCheckTest{
+		{
+			Checker: typednil.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:9: returning possibly-nil \*MyError as error interface\n` +
+				`[^\n]*file\.go:23:9: returning possibly-nil \*MyError as error interface\n` +
+				`[^\n]*file\.go:49:10: returning possibly-nil \*MyError as error interface$`),
+		},
+		{
+			Checker:  typednil.Checker{},
+			Content:  []byte("package typedniltest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,251 @@
+// Package unexport provides lint integration for finding exported identifiers
+// that are not used outside their package.
+package unexport
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and reports exported identifiers that are
+// never used outside the package declaring them. Package level functions,
+// types, variables and constants and the exported methods of exported types are
+// checked. Struct fields are not.
+//
+// Uses are found by type checking every package searched, so Checker is much
+// slower than checkers that only parse files and is not part of any default
+// group. Uses in external _test packages count as uses outside the package.
+//
+// Identifiers are not reported if they are
+//
+//   - declared in a main package
+//   - methods that may satisfy an interface with a method of the same name,
+//     such as String or Error
+//   - preceded by a //lint:ignore directive in their doc comment
+type Checker struct {
+	// ModulePath is the import path of the module whose packages are searched
+	// for uses, such as github.com/surullabs/lint. Every package matching
+	// ModulePath/... is searched. If it is empty, only the packages passed to
+	// Check are searched.
+	ModulePath string
+}
+
+// Check reports each exported identifier in pkgs that is never used outside its
+// package as
+//
+//	file.go:line: exported Foo is never used outside its package (consider unexporting)
+//
+// Methods are named as Type.Method.
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each exported identifier in pkgs
+// that is never used outside its package.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	srcs, err := checkers.ParseSource(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	searched := srcs
+	if c.ModulePath != "" {
+		if searched, err = checkers.ParseSource(c.ModulePath + "/..."); err != nil {
+			return nil, err
+		}
+		searched = append(searched, srcs...)
+	}
+	u := &usage{used: map[string]bool{}}
+	seen := map[string]bool{}
+	for _, s := range searched {
+		if seen[s.ImportPath] {
+			continue
+		}
+		seen[s.ImportPath] = true
+		u.add(s)
+	}
+	diags := []checkers.Diagnostic{}
+	for _, s := range srcs {
+		diags = append(diags, checkers.ErrorDiagnostics(checkers.Error(s.Errors...))...)
+		diags = append(diags, u.unused(s)...)
+	}
+	return diags, nil
+}
+
+// usage records the identifiers used outside their package and the interfaces
+// seen in the searched packages.
+type usage struct {
+	used       map[string]bool
+	interfaces []*types.Interface
+}
+
+// add records the uses and interfaces in s.
+func (u *usage) add(s *checkers.Source) {
+	pkg, info, _ := s.TypeCheck(true)
+	for id, obj := range info.Uses {
+		if obj.Pkg() == nil || !obj.Exported() {
+			continue
+		}
+		// Uses are recorded for the package of the file and its external tests.
+		from := pkg.Path()
+		if isXTest(s, id.Pos()) {
+			from += "_test"
+		}
+		if obj.Pkg().Path() != from {
+			u.used[objectKey(obj)] = true
+		}
+	}
+	for _, tv := range info.Types {
+		if iface, ok := tv.Type.Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+			u.interfaces = append(u.interfaces, iface)
+		}
+	}
+	for _, imp := range pkg.Imports() {
+		scope := imp.Scope()
+		for _, name := range scope.Names() {
+			if tn, ok := scope.Lookup(name).(*types.TypeName); ok {
+				if iface, ok := tn.Type().Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+					u.interfaces = append(u.interfaces, iface)
+				}
+			}
+		}
+	}
+}
+
+func isXTest(s *checkers.Source, pos token.Pos) bool {
+	for _, f := range s.XTestFiles {
+		if f.Pos() <= pos && pos <= f.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// unused returns a diagnostic for each exported identifier in s that is not used
+// outside its package.
+func (u *usage) unused(s *checkers.Source) []checkers.Diagnostic {
+	if s.Build != nil && s.Build.Name == "main" {
+		return nil
+	}
+	_, info, _ := s.TypeCheck(true)
+	var diags []checkers.Diagnostic
+	report := func(id *ast.Ident, doc *ast.CommentGroup) {
+		obj := info.Defs[id]
+		if obj == nil || !obj.Exported() || ignored(doc) || u.used[objectKey(obj)] {
+			return
+		}
+		if fn, ok := obj.(*types.Func); ok && u.satisfies(fn) {
+			return
+		}
+		diags = append(diags, s.Diagnostic(id.Pos(), "exported %s is never used outside its package (consider unexporting)", displayName(obj)))
+	}
+	for _, f := range s.Files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil || receiverExported(info, decl) {
+					report(decl.Name, decl.Doc)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						report(spec.Name, docOf(decl, spec.Doc))
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							report(name, docOf(decl, spec.Doc))
+						}
+					}
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// satisfies reports whether fn is a method that may be used to satisfy one of
+// the interfaces seen, or the error interface.
+func (u *usage) satisfies(fn *types.Func) bool {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	ptr := types.NewPointer(t)
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	for _, iface := range append([]*types.Interface{errorType}, u.interfaces...) {
+		for i := 0; i < iface.NumMethods(); i++ {
+			if iface.Method(i).Name() == fn.Name() && (types.Implements(t, iface) || types.Implements(ptr, iface)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func receiverExported(info *types.Info, fn *ast.FuncDecl) bool {
+	obj, ok := info.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	named := receiverNamed(obj)
+	return named != nil && named.Obj().Exported()
+}
+
+func receiverNamed(fn *types.Func) *types.Named {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, _ := t.(*types.Named)
+	return named
+}
+
+// objectKey identifies obj across packages, since each package is type checked
+// separately and has its own objects for the packages it imports.
+func objectKey(obj types.Object) string {
+	return obj.Pkg().Path() + "." + displayName(obj)
+}
+
+// displayName returns the name of obj, qualified by its receiver type for
+// methods.
+func displayName(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		if named := receiverNamed(fn); named != nil {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return obj.Name()
+}
+
+// docOf returns the doc comment of spec, or of decl if spec has none and is the
+// only spec in decl.
+func docOf(decl *ast.GenDecl, doc *ast.CommentGroup) *ast.CommentGroup {
+	if doc == nil && len(decl.Specs) == 1 {
+		return decl.Doc
+	}
+	return doc
+}
+
+// ignored reports whether doc contains a //lint:ignore directive.
+func ignored(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, "//lint:ignore") {
+			return true
+		}
+	}
+	return false
+}
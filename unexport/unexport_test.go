@@ -0,0 +1,80 @@
+package unexport_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/surullabs/lint/checkers"
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/unexport"
+)
+
+const a = `// Package a is used by b.
+package a
+
+import "fmt"
+
+// Used is used by b.
+func Used() {}
+
+// Unused is only used here.
+func Unused() {}
+
+//lint:ignore unexport kept for compatibility.
+func Ignored() {}
+
+// UsedInTest is used by the external tests.
+const UsedInTest = 1
+
+// Value is unused.
+var Value = fmt.Sprint(1)
+
+// T is used by b.
+type T struct{}
+
+// String satisfies fmt.Stringer.
+func (T) String() string { return "" }
+
+// Used is used by b.
+func (T) Used() {}
+
+// Unused is not.
+func (*T) Unused() {}
+
+type t struct{}
+
+// Exported is a method of an unexported type.
+func (t) Exported() {}
+
+func init() {
+	Unused()
+	t{}.Exported()
+}
+`
+
+func TestUnexport(t *testing.T) {
+	checkers.Unload("unexporttest/a")
+	tmp, err := testutil.NewGopath("unexporttest", []testutil.SourceFile{
+		{Content: []byte(a), Dest: filepath.Join("unexporttest", "a", "a.go")},
+		{Content: []byte("package a_test\n\nimport \"unexporttest/a\"\n\nvar _ = a.UsedInTest\n"), Dest: filepath.Join("unexporttest", "a", "a_test.go")},
+		{Content: []byte("package main\n\nimport \"unexporttest/a\"\n\nfunc main() {\n\ta.Used()\n\ta.T{}.Used()\n}\n\n// Exported is in main.\nfunc Exported() {}\n"), Dest: filepath.Join("unexporttest", "b", "b.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+
+	expected := `a\.go:10:6: exported Unused is never used outside its package \(consider unexporting\)\n` +
+		`.*a\.go:19:5: exported Value is never used outside its package \(consider unexporting\)\n` +
+		`.*a\.go:31:11: exported T\.Unused is never used outside its package \(consider unexporting\)$`
+	err = unexport.Checker{ModulePath: "unexporttest"}.Check("unexporttest/a", "unexporttest/b")
+	if err := testutil.MatchesRegexp(expected)(err); err != nil {
+		t.Error(err)
+	}
+
+	// Without ModulePath uses in b are not seen.
+	err = unexport.Checker{}.Check("unexporttest/a")
+	if err := testutil.MatchesRegexp(`a\.go:7:6: exported Used is never used`)(err); err != nil {
+		t.Error(err)
+	}
+}
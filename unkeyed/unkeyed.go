@@ -0,0 +1,111 @@
+// Package unkeyed provides lint integration for finding unkeyed composite
+// literals of struct types declared in other packages.
+package unkeyed
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// DefaultAllow holds the types used by Checker if Allow is empty. Their fields
+// are not expected to change.
+var DefaultAllow = []string{
+	"image.Point",
+	"image.Rectangle",
+	"image/color.Alpha",
+	"image/color.Alpha16",
+	"image/color.CMYK",
+	"image/color.Gray",
+	"image/color.Gray16",
+	"image/color.NRGBA",
+	"image/color.NRGBA64",
+	"image/color.RGBA",
+	"image/color.RGBA64",
+}
+
+// Checker implements lint.Checker and reports composite literals in .go files,
+// including test files, that set the fields of a struct type declared in
+// another package without naming them. Such literals stop compiling, or worse
+// assign the wrong fields, when the type gains a field. Literals are resolved
+// using go/types, so elided element literals in slice, array and map literals
+// are reported as well.
+type Checker struct {
+	// Allow holds the types whose literals may be unkeyed, as the import path of
+	// their package and their name, such as "image.Point" or
+	// "image/color.RGBA". If it is empty, DefaultAllow is used.
+	Allow []string
+}
+
+// Check reports each unkeyed literal of an external type in pkgs as
+//
+//	file.go:line:col: unkeyed fields in composite literal of external type pkg.T
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each unkeyed literal of an external
+// type in pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports unkeyed literals of external types in the packages of l,
+// which may be shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	allow := map[string]bool{}
+	list := c.Allow
+	if len(list) == 0 {
+		list = DefaultAllow
+	}
+	for _, name := range list {
+		allow[name] = true
+	}
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for i, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			// External test files are in a package of their own.
+			pkg := s.ImportPath
+			if i == 2 {
+				pkg += "_test"
+			}
+			for _, f := range group {
+				ast.Inspect(f, func(n ast.Node) bool {
+					lit, ok := n.(*ast.CompositeLit)
+					if !ok || len(lit.Elts) == 0 {
+						return true
+					}
+					if _, keyed := lit.Elts[0].(*ast.KeyValueExpr); keyed {
+						return true
+					}
+					// Elided literals of pointer elements have the pointer type.
+					typ := info.TypeOf(lit)
+					if ptr, ok := typ.(*types.Pointer); ok {
+						typ = ptr.Elem()
+					}
+					named, ok := types.Unalias(typ).(*types.Named)
+					if !ok {
+						return true
+					}
+					obj := named.Obj()
+					if _, ok := named.Underlying().(*types.Struct); !ok || obj.Pkg() == nil || obj.Pkg().Path() == pkg {
+						return true
+					}
+					if allow[obj.Pkg().Path()+"."+obj.Name()] {
+						return true
+					}
+					diags = append(diags, s.Diagnostic(lit.Pos(), "unkeyed fields in composite literal of external type %s.%s",
+						obj.Pkg().Name(), obj.Name()))
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
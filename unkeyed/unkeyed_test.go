@@ -0,0 +1,53 @@
+package unkeyed_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/unkeyed"
+)
+
+const src = `package unkeyedtest
+
+import (
+	"image"
+	"net/url"
+	"sync"
+)
+
+type local struct{ a, b int }
+
+var (
+	_ = local{1, 2}
+	_ = url.Userinfo{}
+	_ = &url.URL{Scheme: "https"}
+	_ = &url.Error{"Get", "u", nil}
+	_ = image.Point{1, 2}
+	_ = []url.Error{{"Get", "u", nil}}
+	_ = map[string]*url.Error{"a": {"Get", "u", nil}}
+	_ = [1]sync.Once{}
+	_ = []int{1, 2}
+)
+`
+
+func TestUnkeyed(t *testing.T) {
+	testutil.Test(t, "unkeyedtest", []testutil.StaticCheckTest{
+		{
+			Checker: unkeyed.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:15:7: unkeyed fields in composite literal of external type url\.Error\n` +
+				`[^\n]*file\.go:17:18: unkeyed fields in composite literal of external type url\.Error\n` +
+				`[^\n]*file\.go:18:33: unkeyed fields in composite literal of external type url\.Error$`),
+		},
+		{
+			Checker:  unkeyed.Checker{Allow: []string{"net/url.Error"}},
+			Content:  []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:16:6: unkeyed fields in composite literal of external type image\.Point$`),
+		},
+		{
+			Checker:  unkeyed.Checker{},
+			Content:  []byte("package unkeyedtest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
@@ -0,0 +1,230 @@
+// Package waitgroup provides lint integration for checking that goroutines
+// started with a sync.WaitGroup are waited for.
+package waitgroup
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/surullabs/lint/checkers"
+)
+
+// Checker implements lint.Checker and checks the use of sync.WaitGroup values
+// declared in functions in .go files, including test files. Two problems are
+// reported:
+//
+//   - A go statement running a function literal, in a function that calls Add
+//     on a WaitGroup it declares, where the literal never calls Done on a
+//     WaitGroup. Goroutines running other functions are not checked.
+//   - A call wg.Add(n) with a constant n followed in the same block by a
+//     different number of go statements before wg.Wait(). Blocks in which go
+//     statements or calls to wg.Add are nested in other statements are not
+//     checked.
+//
+// Both checks are conservative and resolve WaitGroups using go/types. Reports
+// can be suppressed with a comment on the line of the statement or the line
+// before it of the form
+//
+//	//nolint:waitgroup
+type Checker struct{}
+
+// Check reports each problem with a WaitGroup in pkgs as one of
+//
+//	file.go:line:col: goroutine launched with WaitGroup but never calls Done
+//	file.go:line:col: wg.Add(2) does not match the 1 goroutines launched before Wait
+func (c Checker) Check(pkgs ...string) error {
+	return checkers.DiagnosticsError(c.CheckDiagnostics(pkgs...))
+}
+
+// CheckDiagnostics returns a diagnostic for each problem with a WaitGroup in
+// pkgs.
+func (c Checker) CheckDiagnostics(pkgs ...string) ([]checkers.Diagnostic, error) {
+	return c.loadedDiagnostics(checkers.NewLoader(pkgs...))
+}
+
+// CheckLoaded reports problems with WaitGroups in the packages of l, which may be
+// shared with other checkers.
+func (c Checker) CheckLoaded(l *checkers.Loader) error {
+	return checkers.DiagnosticsError(c.loadedDiagnostics(l))
+}
+
+func (c Checker) loadedDiagnostics(l *checkers.Loader) ([]checkers.Diagnostic, error) {
+	return l.AnalyzeDiagnostics(func(s *checkers.Source) []checkers.Diagnostic {
+		_, info, _ := s.TypeCheck(true)
+		var diags []checkers.Diagnostic
+		for _, group := range [][]*ast.File{s.Files, s.TestFiles, s.XTestFiles} {
+			for _, f := range group {
+				suppressed := checkers.NoLintLines(s.Fset, f, "waitgroup.Checker")
+				report := func(pos token.Pos, format string, args ...interface{}) {
+					if line := s.Fset.Position(pos).Line; !suppressed[line] && !suppressed[line-1] {
+						diags = append(diags, s.Diagnostic(pos, format, args...))
+					}
+				}
+				ast.Inspect(f, func(n ast.Node) bool {
+					var body *ast.BlockStmt
+					switch fn := n.(type) {
+					case *ast.FuncDecl:
+						body = fn.Body
+					case *ast.FuncLit:
+						body = fn.Body
+					}
+					if body == nil || !addsToLocal(info, body) {
+						return true
+					}
+					for _, stmt := range goStmts(body) {
+						if lit, ok := ast.Unparen(stmt.Call.Fun).(*ast.FuncLit); ok && !callsDone(info, lit.Body) {
+							report(stmt.Pos(), "goroutine launched with WaitGroup but never calls Done")
+						}
+					}
+					return true
+				})
+				ast.Inspect(f, func(n ast.Node) bool {
+					if block, ok := n.(*ast.BlockStmt); ok {
+						checkAdds(info, block.List, report)
+					}
+					return true
+				})
+			}
+		}
+		return diags
+	})
+}
+
+// checkAdds reports calls to Add in stmts that do not match the number of go
+// statements that follow them before the next call to Wait.
+func checkAdds(info *types.Info, stmts []ast.Stmt, report func(token.Pos, string, ...interface{})) {
+	for i, stmt := range stmts {
+		wg, method, call := wgCall(info, stmt)
+		if method != "Add" || len(call.Args) != 1 {
+			continue
+		}
+		tv := info.Types[call.Args[0]]
+		if tv.Value == nil || tv.Value.Kind() != constant.Int {
+			continue
+		}
+		n, ok := constant.Int64Val(tv.Value)
+		if !ok {
+			continue
+		}
+		launched := int64(0)
+	scan:
+		for _, next := range stmts[i+1:] {
+			if _, ok := next.(*ast.GoStmt); ok {
+				launched++
+				continue
+			}
+			switch obj, method, _ := wgCall(info, next); {
+			case obj == wg && method == "Wait":
+				if launched != n {
+					report(call.Pos(), "%s.Add(%d) does not match the %d goroutines launched before Wait", wg.Name(), n, launched)
+				}
+				break scan
+			case obj == wg && method == "Add":
+				break scan
+			}
+			if nested(info, next, wg) {
+				break scan
+			}
+		}
+	}
+}
+
+// nested reports whether stmt contains a go statement or a call to Add on wg.
+func nested(info *types.Info, stmt ast.Stmt, wg types.Object) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			found = true
+		case *ast.ExprStmt:
+			if obj, method, _ := wgCall(info, n); obj == wg && method == "Add" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// wgCall returns the WaitGroup variable, method name and call of stmt if it is a
+// call of a method on a WaitGroup variable.
+func wgCall(info *types.Info, stmt ast.Stmt) (types.Object, string, *ast.CallExpr) {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, "", nil
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return nil, "", nil
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", nil
+	}
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return nil, "", nil
+	}
+	obj := info.Uses[id]
+	if obj == nil || !isWaitGroup(obj.Type()) {
+		return nil, "", nil
+	}
+	return obj, sel.Sel.Name, call
+}
+
+// addsToLocal reports whether body calls Add on a WaitGroup variable declared in
+// body.
+func addsToLocal(info *types.Info, body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if stmt, ok := n.(*ast.ExprStmt); ok {
+			if obj, method, _ := wgCall(info, stmt); method == "Add" && body.Pos() <= obj.Pos() && obj.Pos() < body.End() {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// goStmts returns the go statements in body, outside function literals.
+func goStmts(body *ast.BlockStmt) []*ast.GoStmt {
+	var stmts []*ast.GoStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.GoStmt:
+			stmts = append(stmts, n)
+		}
+		return true
+	})
+	return stmts
+}
+
+// callsDone reports whether body calls Done on a WaitGroup.
+func callsDone(info *types.Info, body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Done" {
+			found = found || isWaitGroup(info.TypeOf(sel.X))
+		}
+		return !found
+	})
+	return found
+}
+
+// isWaitGroup reports whether typ is sync.WaitGroup or a pointer to it.
+func isWaitGroup(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "sync" && named.Obj().Name() == "WaitGroup"
+}
@@ -0,0 +1,88 @@
+package waitgroup_test
+
+import (
+	"testing"
+
+	"github.com/surullabs/lint/testutil"
+	"github.com/surullabs/lint/waitgroup"
+)
+
+const src = `package waitgrouptest
+
+import "sync"
+
+func work() {}
+
+func Missing() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		work()
+	}()
+	go func() {
+		work()
+	}()
+	wg.Wait()
+}
+
+func Count() {
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	go func() { wg.Done() }()
+	go func() { wg.Done() }()
+	wg.Wait()
+}
+
+func Passed() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) { wg.Done() }(&wg)
+	go work() //nolint:waitgroup
+	wg.Wait()
+}
+
+func Loop(n int) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() { defer wg.Done() }()
+	}
+	wg.Wait()
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() { defer wg.Done() }()
+	}
+	wg.Wait()
+}
+
+func NoWaitGroup() {
+	go work()
+	go func() {}()
+}
+
+func Suppressed() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	//nolint:waitgroup
+	go func() {}()
+	wg.Wait()
+}
+`
+
+func TestWaitGroup(t *testing.T) {
+	testutil.Test(t, "waitgrouptest", []testutil.StaticCheckTest{
+		{
+			Checker: waitgroup.Checker{},
+			Content: []byte(src),
+			Validate: testutil.MatchesRegexp(`^[^\n]*file\.go:14:2: goroutine launched with WaitGroup but never calls Done\n` +
+				`[^\n]*file\.go:22:2: wg\.Add\(3\) does not match the 2 goroutines launched before Wait\n` +
+				`[^\n]*file\.go:30:2: wg\.Add\(1\) does not match the 2 goroutines launched before Wait$`),
+		},
+		{
+			Checker:  waitgroup.Checker{},
+			Content:  []byte("package waitgrouptest\nsfsff\n"),
+			Validate: testutil.Contains("expected declaration, found sfsff"),
+		},
+	})
+}
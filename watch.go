@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/surullabs/lint/checkers"
+)
+
+// watchDebounce is how long files must be unchanged before Watch runs a check,
+// so that a burst of saves results in a single check.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch runs c.Check(pkgs...) once and then again each time a .go file in the
+// directories of pkgs is created, modified or removed, sending the result of
+// each run on the returned channel. Changes are coalesced until no .go file has
+// changed for 200ms, so a burst of saves results in a single run.
+//
+// Directories are watched using fsnotify. Packages are resolved using
+// ExpandPackages, and the directories below the root of a ... wildcard are
+// watched as well, so packages added below it after Watch is called are seen.
+// Errors reported by fsnotify are sent on the channel like the result of a run.
+//
+// When ctx is done Watch stops, waiting for a run in progress to finish, and
+// closes the channel. If c implements CheckerContext it is passed ctx.
+func Watch(ctx context.Context, c Checker, pkgs ...string) (<-chan error, error) {
+	dirs, err := ExpandPackages(pkgs...)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &watch{watcher: watcher, watched: map[string]bool{}}
+	for _, dir := range dirs {
+		if err := w.add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+	for _, pkg := range pkgs {
+		if root, ok := checkers.WildcardRoot(pkg); ok {
+			w.roots = append(w.roots, root)
+			if _, err := w.addTree(root); err != nil {
+				watcher.Close()
+				return nil, err
+			}
+		}
+	}
+	results := make(chan error)
+	go w.run(ctx, c, pkgs, results)
+	return results, nil
+}
+
+// watch holds the directories watched by Watch.
+type watch struct {
+	watcher *fsnotify.Watcher
+	watched map[string]bool
+	// roots are the roots of ... wildcards, below which new directories are
+	// watched as they are created.
+	roots []string
+}
+
+func (w *watch) run(ctx context.Context, c Checker, pkgs []string, results chan<- error) {
+	defer close(results)
+	defer w.watcher.Close()
+	send := func(err error) bool {
+		select {
+		case results <- err:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	if !send(watchCheck(ctx, c, pkgs)) {
+		return
+	}
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-w.watcher.Errors:
+			if !ok || !send(err) {
+				return
+			}
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.changed(event) {
+				debounce.Reset(watchDebounce)
+			}
+		case <-debounce.C:
+			if !send(watchCheck(ctx, c, pkgs)) {
+				return
+			}
+		}
+	}
+}
+
+// changed updates the watched directories for event and reports whether it
+// should result in a run.
+func (w *watch) changed(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		// Watches are removed along with their directory, so a directory created
+		// again with the same name must be added again.
+		delete(w.watched, event.Name)
+	}
+	if event.Has(fsnotify.Create) && w.belowRoot(event.Name) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			// Files written before the directory was watched have no events.
+			found, _ := w.addTree(event.Name)
+			return found
+		}
+	}
+	return strings.HasSuffix(event.Name, ".go") && event.Has(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename)
+}
+
+// belowRoot reports whether dir is below the root of a ... wildcard, without
+// passing through a directory the go command ignores.
+func (w *watch) belowRoot(dir string) bool {
+	for _, root := range w.roots {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		ignored := false
+		for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+			ignored = ignored || checkers.IgnoredDir(elem)
+		}
+		if !ignored {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *watch) add(dir string) error {
+	if w.watched[dir] {
+		return nil
+	}
+	if err := w.watcher.Add(dir); err != nil {
+		return err
+	}
+	w.watched[dir] = true
+	return nil
+}
+
+// addTree watches dir and the directories below it that the go command does not
+// ignore. It reports whether any of them hold .go files.
+func (w *watch) addTree(dir string) (bool, error) {
+	found := false
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			found = found || strings.HasSuffix(path, ".go")
+			return nil
+		}
+		if path != dir && checkers.IgnoredDir(info.Name()) {
+			return filepath.SkipDir
+		}
+		return w.add(path)
+	})
+	return found, err
+}
+
+// watchCheck runs c for pkgs, reloading packages so that added and removed files
+// are seen.
+func watchCheck(ctx context.Context, c Checker, pkgs []string) error {
+	checkers.ClearPackageCache()
+	for _, pkg := range pkgs {
+		checkers.Unload(pkg)
+	}
+	if cc, ok := c.(CheckerContext); ok {
+		return cc.CheckContext(ctx, pkgs...)
+	}
+	return safeCheck(c, pkgs)
+}
@@ -0,0 +1,95 @@
+package lint_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/surullabs/lint"
+	"github.com/surullabs/lint/testutil"
+)
+
+func TestWatch(t *testing.T) {
+	tmp, err := testutil.NewGopath("watch", []testutil.SourceFile{
+		{Content: []byte("package a\n"), Dest: filepath.Join("watch", "a", "a.go")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create temporary go path: %v", err)
+	}
+	defer tmp.Reset()
+	dir := filepath.Join(tmp.Path, "src", "watch", "a")
+
+	runs := 0
+	c := checkFn(func(pkgs ...string) error {
+		runs++
+		return fmt.Errorf("run %d", runs)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := lint.Watch(ctx, c, "watch/a")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+
+	next := func() error {
+		select {
+		case err := <-results:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a check")
+			return nil
+		}
+	}
+	err = next()
+	assert(t, err != nil && err.Error() == "run 1", fmt.Sprintf("%v", err))
+
+	// A burst of changes results in a single run. Other files are ignored.
+	for i := 0; i < 3; i++ {
+		write(t, filepath.Join(dir, "b.go"), fmt.Sprintf("package a\n\nvar b = %d\n", i))
+		time.Sleep(20 * time.Millisecond)
+	}
+	err = next()
+	assert(t, err != nil && err.Error() == "run 2", fmt.Sprintf("%v", err))
+
+	write(t, filepath.Join(dir, "notes.txt"), "not go")
+	select {
+	case err := <-results:
+		t.Errorf("unexpected check for a non .go file: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	cancel()
+	for range results {
+	}
+
+	// Packages added below a ... wildcard are watched.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	results, err = lint.Watch(ctx, c, "watch/...")
+	assert(t, err == nil, fmt.Sprintf("%v", err))
+	err = next()
+	assert(t, err != nil && err.Error() == "run 3", fmt.Sprintf("%v", err))
+	if err := os.MkdirAll(filepath.Join(tmp.Path, "src", "watch", "c", "d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write(t, filepath.Join(tmp.Path, "src", "watch", "c", "d", "d.go"), "package d\n")
+	err = next()
+	assert(t, err != nil && err.Error() == "run 4", fmt.Sprintf("%v", err))
+	write(t, filepath.Join(tmp.Path, "src", "watch", "c", "d", "d.go"), "package d\n\nvar d = 1\n")
+	err = next()
+	assert(t, err != nil && err.Error() == "run 5", fmt.Sprintf("%v", err))
+	cancel()
+	for range results {
+	}
+
+	_, err = lint.Watch(context.Background(), c, "watch/nosuchpackage")
+	assert(t, err != nil, "expected an error for a missing package")
+}
+
+func write(t *testing.T, file, content string) {
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}